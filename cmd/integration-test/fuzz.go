@@ -33,6 +33,9 @@ var fuzzingTestCases = []TestCaseInfo{
 	{Path: "fuzz/fuzz-body-params-sqli.yaml", TestCase: &genericFuzzTestCase{expectedResults: 1}},
 	{Path: "fuzz/fuzz-body-xml-sqli.yaml", TestCase: &genericFuzzTestCase{expectedResults: 1}},
 	{Path: "fuzz/fuzz-body-generic-sqli.yaml", TestCase: &genericFuzzTestCase{expectedResults: 4}},
+	{Path: "fuzz/fuzz-body-grpc-sqli.yaml", TestCase: &grpcFuzzTestCase{}},
+	{Path: "fuzz/fuzz-time-based-sqli.yaml", TestCase: &fuzzTimeBasedAnalyzer{}},
+	{Path: "fuzz/fuzz-boolean-based-sqli.yaml", TestCase: &fuzzBaselineComparison{}},
 }
 
 type genericFuzzTestCase struct {
@@ -47,6 +50,39 @@ func (g *genericFuzzTestCase) Execute(filePath string) error {
 	return expectResultsCount(results, g.expectedResults)
 }
 
+type grpcFuzzTestCase struct{}
+
+// Execute executes a test case and returns an error if occurred
+func (g *grpcFuzzTestCase) Execute(filePath string) error {
+	results, err := testutils.RunNucleiWithArgsAndGetResults(debug, "-t", filePath, "-l", "fuzz/testData/grpc.proto", "-im", "grpc", "-var", "grpc_endpoint=http://127.0.0.1:8082")
+	if err != nil {
+		return err
+	}
+	return expectResultsCount(results, 1)
+}
+
+type fuzzTimeBasedAnalyzer struct{}
+
+// Execute executes a test case and returns an error if occurred
+func (f *fuzzTimeBasedAnalyzer) Execute(filePath string) error {
+	results, err := testutils.RunNucleiTemplateAndGetResults(filePath, "http://127.0.0.1:8082/user/search?name=admin", debug, "-fuzz")
+	if err != nil {
+		return err
+	}
+	return expectResultsCount(results, 1)
+}
+
+type fuzzBaselineComparison struct{}
+
+// Execute executes a test case and returns an error if occurred
+func (f *fuzzBaselineComparison) Execute(filePath string) error {
+	results, err := testutils.RunNucleiTemplateAndGetResults(filePath, "http://127.0.0.1:8082/user/lookup?id=75", debug, "-fuzz")
+	if err != nil {
+		return err
+	}
+	return expectResultsCount(results, 1)
+}
+
 type httpFuzzQuery struct{}
 
 // Execute executes a test case and returns an error if occurred
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"path/filepath"
+
+	"github.com/projectdiscovery/goflags"
+	"github.com/projectdiscovery/gologger"
+	"github.com/projectdiscovery/nuclei/v3/internal/runner"
+	"github.com/projectdiscovery/nuclei/v3/pkg/catalog/config"
+)
+
+// runDiffCommand compares the jsonl outputs of two scans
+//
+//	nuclei diff -o new.jsonl -summary diff.json old.jsonl new.jsonl
+func runDiffCommand(args []string) {
+	diffOptions := &runner.DiffOptions{}
+
+	flagSet := goflags.NewFlagSet()
+	flagSet.SetDescription("Writes findings of the new scan not found by the old scan and summarizes resolved findings.")
+	// subcommand flags must not be populated from the scan flags config
+	flagSet.SetConfigFilePath(filepath.Join(config.DefaultConfig.GetConfigDir(), "diff-config.yaml"))
+	flagSet.CreateGroup("output", "Output",
+		flagSet.StringVarP(&diffOptions.Output, "output", "o", "", "file to write new jsonl results to (default stdout)"),
+		flagSet.StringVarP(&diffOptions.Summary, "summary", "s", "", "file to write the json diff summary to"),
+	)
+	flagSet.SetCustomHelpText(`EXAMPLES:
+Write the new findings of a scan and the diff summary:
+	$ nuclei diff -o new.jsonl -summary diff.json old.jsonl new.jsonl
+`)
+	goflags.DisableAutoConfigMigration = true
+	// goflags parses the process arguments when none are given
+	if len(args) == 0 {
+		gologger.Fatal().Msgf("No results to diff provided, see 'nuclei diff -h'\n")
+	}
+	if err := flagSet.Parse(args...); err != nil {
+		gologger.Fatal().Msgf("Could not parse flags: %s\n", err)
+	}
+
+	inputs := flagSet.CommandLine.Args()
+	if len(inputs) != 2 {
+		gologger.Fatal().Msgf("Expected the old and new results, see 'nuclei diff -h'\n")
+	}
+	diffOptions.Old, diffOptions.New = inputs[0], inputs[1]
+	if _, err := runner.DiffResults(diffOptions); err != nil {
+		gologger.Fatal().Msgf("Could not diff results: %s\n", err)
+	}
+}
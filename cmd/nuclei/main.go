@@ -55,6 +55,17 @@ func main() {
 	// enables CLI specific configs mostly interactive behavior
 	config.CurrentAppMode = config.AppModeCLI
 
+	// merge outputs of sharded scans
+	if len(os.Args) > 1 && os.Args[1] == "merge" {
+		runMergeCommand(os.Args[2:])
+		return
+	}
+	// compare outputs of two scans
+	if len(os.Args) > 1 && os.Args[1] == "diff" {
+		runDiffCommand(os.Args[2:])
+		return
+	}
+
 	if err := runner.ConfigureOptions(); err != nil {
 		gologger.Fatal().Msgf("Could not initialize options: %s\n", err)
 	}
@@ -190,6 +201,9 @@ func main() {
 				gologger.Info().Msgf("Uploading scan results to cloud...")
 			}
 			nucleiRunner.Close()
+			if resumeStorePath := nucleiRunner.ResumeStorePath(); resumeStorePath != "" {
+				gologger.Info().Msgf("Scan progression saved to resume store: %s\n", resumeStorePath)
+			}
 			if options.ShouldSaveResume() {
 				gologger.Info().Msgf("Creating resume file: %s\n", resumeFileName)
 				err := nucleiRunner.SaveResumeConfig(resumeFileName)
@@ -201,6 +215,24 @@ func main() {
 		}
 	}()
 
+	// Toggle pause of the running scan on SIGUSR1
+	pauseChan := make(chan os.Signal, 1)
+	notifyPause(pauseChan)
+	defer func() {
+		// the channel must not receive signals once closed
+		signal.Stop(pauseChan)
+		close(pauseChan)
+	}()
+	go func() {
+		for range pauseChan {
+			if nucleiRunner.TogglePause() {
+				gologger.Info().Msgf("Scan paused, in-flight requests are completing (send SIGUSR1 again to resume)")
+			} else {
+				gologger.Info().Msgf("Scan resumed")
+			}
+		}
+	}()
+
 	if err := nucleiRunner.RunEnumeration(); err != nil {
 		if options.Validate {
 			gologger.Fatal().Msgf("Could not validate templates: %s\n", err)
@@ -213,6 +245,9 @@ func main() {
 	if fileutil.FileExists(resumeFileName) {
 		os.Remove(resumeFileName)
 	}
+	if resumeStorePath := nucleiRunner.ResumeStorePath(); resumeStorePath != "" {
+		os.Remove(resumeStorePath)
+	}
 }
 
 func readConfig() *goflags.FlagSet {
@@ -235,7 +270,8 @@ on extensive configurability, massive extensibility and ease of use.`)
 		flagSet.StringSliceVarP(&options.Targets, "target", "u", nil, "target URLs/hosts to scan", goflags.CommaSeparatedStringSliceOptions),
 		flagSet.StringVarP(&options.TargetsFilePath, "list", "l", "", "path to file containing a list of target URLs/hosts to scan (one per line)"),
 		flagSet.StringSliceVarP(&options.ExcludeTargets, "exclude-hosts", "eh", nil, "hosts to exclude to scan from the input list (ip, cidr, hostname)", goflags.FileCommaSeparatedStringSliceOptions),
-		flagSet.StringVar(&options.Resume, "resume", "", "resume scan using resume.cfg (clustering will be disabled)"),
+		flagSet.StringVar(&options.Resume, "resume", "", "resume scan using resume.cfg or resume store (clustering will be disabled)"),
+		flagSet.BoolVarP(&options.ResumeStore, "resume-store", "rs", false, "record scan progression to a crash-safe resume store"),
 		flagSet.BoolVarP(&options.ScanAllIPs, "scan-all-ips", "sa", false, "scan all the IP's associated with dns record"),
 		flagSet.StringSliceVarP(&options.IPVersion, "ip-version", "iv", nil, "IP version to scan of hostname (4,6) - (default 4)", goflags.CommaSeparatedStringSliceOptions),
 	)
@@ -244,6 +280,7 @@ on extensive configurability, massive extensibility and ease of use.`)
 		flagSet.StringVarP(&options.InputFileMode, "input-mode", "im", "list", fmt.Sprintf("mode of input file (%v)", provider.SupportedInputFormats())),
 		flagSet.BoolVarP(&options.FormatUseRequiredOnly, "required-only", "ro", false, "use only required fields in input format when generating requests"),
 		flagSet.BoolVarP(&options.SkipFormatValidation, "skip-format-validation", "sfv", false, "skip format validation (like missing vars) when parsing input file"),
+		flagSet.StringVarP(&options.PostmanEnvironmentFile, "postman-env", "pe", "", "postman environment file to resolve collection variables"),
 	)
 
 	flagSet.CreateGroup("templates", "Templates",
@@ -295,11 +332,17 @@ on extensive configurability, massive extensibility and ease of use.`)
 		flagSet.BoolVarP(&options.NoMeta, "no-meta", "nm", false, "disable printing result metadata in cli output"),
 		flagSet.BoolVarP(&options.Timestamp, "timestamp", "ts", false, "enables printing timestamp in cli output"),
 		flagSet.StringVarP(&options.ReportingDB, "report-db", "rdb", "", "nuclei reporting database (always use this to persist report data)"),
+		flagSet.BoolVarP(&options.ReportingReconcile, "report-reconcile", "rrc", false, "close tracker issues of findings no longer found on rescan (requires -rdb)"),
 		flagSet.BoolVarP(&options.MatcherStatus, "matcher-status", "ms", false, "display match failure status"),
+		flagSet.StringVarP(&options.Baseline, "baseline", "bl", "", "jsonl results of a previous scan to only write new findings and summarize resolved ones"),
 		flagSet.StringVarP(&options.MarkdownExportDirectory, "markdown-export", "me", "", "directory to export results in markdown format"),
 		flagSet.StringVarP(&options.SarifExport, "sarif-export", "se", "", "file to export results in SARIF format"),
 		flagSet.StringVarP(&options.JSONExport, "json-export", "je", "", "file to export results in JSON format"),
 		flagSet.StringVarP(&options.JSONLExport, "jsonl-export", "jle", "", "file to export results in JSONL(ine) format"),
+		flagSet.StringVarP(&options.HTMLExport, "html-export", "he", "", "file to export results as HTML report"),
+		flagSet.StringVarP(&options.VEXExport, "vex-export", "ve", "", "file to export results in CycloneDX VDR or OpenVEX format"),
+		flagSet.StringVarP(&options.VEXFormat, "vex-format", "vf", "cyclonedx", "format of the vex export (cyclonedx, openvex)"),
+		flagSet.StringVarP(&options.SQLExport, "sql-export", "sqe", "", "sqlite database file or postgres connection url to export results to"),
 		flagSet.StringSliceVarP(&options.Redact, "redact", "rd", nil, "redact given list of keys from query parameter, request header and body", goflags.CommaSeparatedStringSliceOptions),
 	)
 
@@ -319,6 +362,7 @@ on extensive configurability, massive extensibility and ease of use.`)
 		flagSet.BoolVarP(&options.DisableClustering, "disable-clustering", "dc", false, "disable clustering of requests"),
 		flagSet.BoolVar(&options.OfflineHTTP, "passive", false, "enable passive HTTP response processing mode"),
 		flagSet.BoolVarP(&options.ForceAttemptHTTP2, "force-http2", "fh2", false, "force http2 connection on requests"),
+		flagSet.BoolVarP(&options.ForceHTTP3, "force-http3", "fh3", false, "force http3 (quic) connection on requests (https only, not supported with -proxy)"),
 		flagSet.BoolVarP(&options.EnvironmentVariables, "env-vars", "ev", false, "enable environment variables to be used in template"),
 		flagSet.StringVarP(&options.ClientCertFile, "client-cert", "cc", "", "client certificate file (PEM-encoded) used for authenticating against scanned hosts"),
 		flagSet.StringVarP(&options.ClientKeyFile, "client-key", "ck", "", "client key file (PEM-encoded) used for authenticating against scanned hosts"),
@@ -337,6 +381,7 @@ on extensive configurability, massive extensibility and ease of use.`)
 		flagSet.CallbackVar(resetCallback, "reset", "reset removes all nuclei configuration and data files (including nuclei-templates)"),
 		flagSet.BoolVarP(&options.TlsImpersonate, "tls-impersonate", "tlsi", false, "enable experimental client hello (ja3) tls randomization"),
 		flagSet.StringVarP(&options.HttpApiEndpoint, "http-api-endpoint", "hae", "", "experimental http api endpoint"),
+		flagSet.BoolVarP(&options.HttpApiService, "http-api-service", "has", false, "run as long-running service with scans started through the http api endpoint"),
 	)
 
 	flagSet.CreateGroup("interactsh", "interactsh",
@@ -396,6 +441,7 @@ on extensive configurability, massive extensibility and ease of use.`)
 			scanstrategy.HostSpray.String():     goflags.EnumVariable(1),
 			scanstrategy.TemplateSpray.String(): goflags.EnumVariable(2),
 		}),
+		flagSet.StringVar(&options.Shard, "shard", "", "execute only the N/M shard of (template, target) pairs for distributed scanning (eg. 1/3)"),
 		flagSet.DurationVarP(&options.InputReadTimeout, "input-read-timeout", "irt", time.Duration(3*time.Minute), "timeout on input read"),
 		flagSet.BoolVarP(&options.DisableHTTPProbe, "no-httpx", "nh", false, "disable httpx probing for non-url input"),
 		flagSet.BoolVar(&options.DisableStdin, "no-stdin", false, "disable stdin processing"),
@@ -580,7 +626,7 @@ Additional documentation is available at: https://docs.nuclei.sh/getting-started
 	return flagSet
 }
 
-// cleanupOldResumeFiles cleans up resume files older than 10 days.
+// cleanupOldResumeFiles cleans up resume files and stores older than 10 days.
 func cleanupOldResumeFiles() {
 	root := config.DefaultConfig.GetCacheDir()
 	filter := fileutil.FileFilters{
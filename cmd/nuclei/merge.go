@@ -0,0 +1,49 @@
+package main
+
+import (
+	"path/filepath"
+
+	"github.com/projectdiscovery/goflags"
+	"github.com/projectdiscovery/gologger"
+	"github.com/projectdiscovery/nuclei/v3/internal/runner"
+	"github.com/projectdiscovery/nuclei/v3/pkg/catalog/config"
+)
+
+// runMergeCommand combines the jsonl outputs and stats of sharded scans
+//
+//	nuclei merge -o merged.jsonl -stats shard1-stats.json,shard2-stats.json shard1.jsonl shard2.jsonl
+func runMergeCommand(args []string) {
+	mergeOptions := &runner.MergeOptions{}
+	var statsFiles goflags.StringSlice
+
+	flagSet := goflags.NewFlagSet()
+	flagSet.SetDescription("Merges the jsonl outputs of sharded (-shard) scans.")
+	// subcommand flags must not be populated from the scan flags config
+	flagSet.SetConfigFilePath(filepath.Join(config.DefaultConfig.GetConfigDir(), "merge-config.yaml"))
+	flagSet.CreateGroup("output", "Output",
+		flagSet.StringVarP(&mergeOptions.Output, "output", "o", "", "file to write merged jsonl results to (default stdout)"),
+		flagSet.StringSliceVarP(&statsFiles, "stats", "s", nil, "files containing -stats-json output of the shards to merge (comma-separated)", goflags.CommaSeparatedStringSliceOptions),
+		flagSet.StringVarP(&mergeOptions.StatsOutput, "stats-output", "so", "", "file to write merged stats to (default stderr)"),
+	)
+	flagSet.SetCustomHelpText(`EXAMPLES:
+Merge the results and stats of two shards:
+	$ nuclei merge -o merged.jsonl -stats shard1-stats.json,shard2-stats.json shard1.jsonl shard2.jsonl
+`)
+	goflags.DisableAutoConfigMigration = true
+	// goflags parses the process arguments when none are given
+	if len(args) == 0 {
+		gologger.Fatal().Msgf("No results to merge provided, see 'nuclei merge -h'\n")
+	}
+	if err := flagSet.Parse(args...); err != nil {
+		gologger.Fatal().Msgf("Could not parse flags: %s\n", err)
+	}
+
+	mergeOptions.Inputs = flagSet.CommandLine.Args()
+	mergeOptions.StatsFiles = statsFiles
+	if len(mergeOptions.Inputs) == 0 {
+		gologger.Fatal().Msgf("No results to merge provided, see 'nuclei merge -h'\n")
+	}
+	if err := runner.MergeResults(mergeOptions); err != nil {
+		gologger.Fatal().Msgf("Could not merge results: %s\n", err)
+	}
+}
@@ -0,0 +1,8 @@
+//go:build windows
+
+package main
+
+import "os"
+
+// notifyPause is a no-op as SIGUSR1 is not available on windows
+func notifyPause(c chan<- os.Signal) {}
@@ -6,6 +6,7 @@ import (
 	_ "github.com/mattn/go-sqlite3"
 	"github.com/projectdiscovery/gologger"
 	"github.com/projectdiscovery/nuclei/v3/pkg/testutils/fuzzplayground"
+	"golang.org/x/net/http2"
 )
 
 var (
@@ -20,8 +21,8 @@ func main() {
 	server := fuzzplayground.GetPlaygroundServer()
 	defer server.Close()
 
-	// Start the server
-	if err := server.Start(addr); err != nil {
+	// Start the server with h2c support for grpc clients
+	if err := server.StartH2CServer(addr, &http2.Server{}); err != nil {
 		gologger.Fatal().Msgf("Could not start server: %s\n", err)
 	}
 }
@@ -3,6 +3,7 @@ package httpapi
 import (
 	"encoding/json"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/projectdiscovery/nuclei/v3/pkg/js/compiler"
@@ -19,23 +20,45 @@ type Concurrency struct {
 	JavascriptConcurrency int    `json:"javascript_concurrency"`
 }
 
-// Server represents the HTTP server that handles the concurrency settings endpoints.
+// Server represents the HTTP server that handles the concurrency settings,
+// scan control and result streaming endpoints.
 type Server struct {
-	addr   string
-	config *types.Options
+	addr    string
+	config  *types.Options
+	results *resultBroker
+
+	mu         sync.RWMutex
+	controller ScanController
 }
 
 // New creates a new instance of Server.
 func New(addr string, config *types.Options) *Server {
 	return &Server{
-		addr:   addr,
-		config: config,
+		addr:    addr,
+		config:  config,
+		results: newResultBroker(),
 	}
 }
 
+// SetController sets the controller used by scan control endpoints
+func (s *Server) SetController(controller ScanController) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.controller = controller
+}
+
 // Start initializes the server and its routes, then starts listening on the specified address.
 func (s *Server) Start() error {
 	http.HandleFunc("/api/concurrency", s.handleConcurrency)
+	http.HandleFunc("/api/scan", s.handleScanStatus)
+	http.HandleFunc("/api/scan/start", s.handleScanAction(ScanController.StartScan))
+	http.HandleFunc("/api/scan/pause", s.handleScanAction(ScanController.PauseScan))
+	http.HandleFunc("/api/scan/resume", s.handleScanAction(ScanController.ResumeScan))
+	http.HandleFunc("/api/scan/cancel", s.handleScanAction(ScanController.CancelScan))
+	http.HandleFunc("/api/targets", s.handleTargets)
+	http.HandleFunc("/api/templates", s.handleTemplates)
+	http.HandleFunc("/api/results/stream", s.handleResultsStream)
+	http.HandleFunc("/api/results/ws", s.handleResultsWebsocket)
 	if err := http.ListenAndServe(s.addr, nil); err != nil {
 		return err
 	}
@@ -0,0 +1,156 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/gobwas/ws"
+	"github.com/gobwas/ws/wsutil"
+	"github.com/logrusorgru/aurora"
+
+	"github.com/projectdiscovery/nuclei/v3/pkg/output"
+)
+
+// subscriberBufferSize is the number of results buffered for a subscriber.
+// results are dropped for subscribers that are not able to keep up.
+const subscriberBufferSize = 1024
+
+// resultBroker broadcasts scan results to streaming subscribers
+type resultBroker struct {
+	mu          sync.RWMutex
+	subscribers map[chan []byte]struct{}
+}
+
+func newResultBroker() *resultBroker {
+	return &resultBroker{subscribers: make(map[chan []byte]struct{})}
+}
+
+// subscribe registers a new subscriber
+func (b *resultBroker) subscribe() chan []byte {
+	ch := make(chan []byte, subscriberBufferSize)
+	b.mu.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.mu.Unlock()
+	return ch
+}
+
+// unsubscribe removes a subscriber
+func (b *resultBroker) unsubscribe(ch chan []byte) {
+	b.mu.Lock()
+	delete(b.subscribers, ch)
+	b.mu.Unlock()
+}
+
+// publish sends a result to all subscribers without blocking
+func (b *resultBroker) publish(data []byte) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for ch := range b.subscribers {
+		select {
+		case ch <- data:
+		default:
+		}
+	}
+}
+
+// ResultWriter returns an output writer publishing results to streaming clients
+func (s *Server) ResultWriter() output.Writer {
+	return &resultWriter{broker: s.results}
+}
+
+// resultWriter is an output.Writer publishing results to the result broker
+type resultWriter struct {
+	broker *resultBroker
+}
+
+var _ output.Writer = &resultWriter{}
+
+func (w *resultWriter) Close() {}
+
+func (w *resultWriter) Colorizer() aurora.Aurora {
+	return aurora.NewAurora(false)
+}
+
+func (w *resultWriter) Write(event *output.ResultEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	w.broker.publish(data)
+	return nil
+}
+
+func (w *resultWriter) WriteFailure(*output.InternalWrappedEvent) error { return nil }
+
+func (w *resultWriter) Request(templateID, url, requestType string, err error) {}
+
+func (w *resultWriter) WriteStoreDebugData(host, templateID, eventType string, data string) {}
+
+// handleResultsStream streams results as Server-Sent Events
+func (s *Server) handleResultsStream(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Unsupported HTTP method", http.StatusMethodNotAllowed)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+	ch := s.results.subscribe()
+	defer s.results.unsubscribe(ch)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case data := <-ch:
+			if _, err := fmt.Fprintf(w, "event: result\ndata: %s\n\n", data); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// handleResultsWebsocket streams results as WebSocket text messages
+func (s *Server) handleResultsWebsocket(w http.ResponseWriter, r *http.Request) {
+	conn, _, _, err := ws.UpgradeHTTP(r, w)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	ch := s.results.subscribe()
+	defer s.results.unsubscribe(ch)
+
+	// messages from clients are discarded, reading detects closed connections
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := wsutil.ReadClientData(conn); err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-closed:
+			return
+		case data := <-ch:
+			if err := wsutil.WriteServerText(conn, data); err != nil {
+				return
+			}
+		}
+	}
+}
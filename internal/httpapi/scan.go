@@ -0,0 +1,172 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+)
+
+var (
+	// ErrScanRunning is returned when a scan is started while another one is running
+	ErrScanRunning = errors.New("a scan is already running")
+	// ErrNoScanRunning is returned when a scan action requires a running scan
+	ErrNoScanRunning = errors.New("no scan is running")
+)
+
+// Scan states reported by the scan status endpoint
+const (
+	ScanStateIdle      = "idle"
+	ScanStateRunning   = "running"
+	ScanStatePaused    = "paused"
+	ScanStateCompleted = "completed"
+	ScanStateCancelled = "cancelled"
+)
+
+// ScanController controls the scans of a nuclei runner
+type ScanController interface {
+	// StartScan starts a new scan with the loaded templates on all targets
+	StartScan() error
+	// PauseScan pauses the running scan
+	PauseScan() error
+	// ResumeScan resumes the paused scan
+	ResumeScan() error
+	// CancelScan cancels the running scan
+	CancelScan() error
+	// Status returns the status of the current or last scan
+	Status() *ScanStatus
+	// AddTargets adds targets to the scan and returns the number of targets added.
+	// Targets added to a running scan are queued and scanned in a follow-up batch
+	// with all templates once the targets of the running scan are done.
+	AddTargets(targets []string) (added int, queued bool, err error)
+	// Templates returns the templates loaded for scans
+	Templates() []*TemplateInfo
+}
+
+// ScanStatus is the status of a scan
+type ScanStatus struct {
+	State      string     `json:"state"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	Targets    int64      `json:"targets"`
+	Templates  int        `json:"templates"`
+	Matched    bool       `json:"matched"`
+}
+
+// TemplateInfo is the information of a loaded template
+type TemplateInfo struct {
+	ID       string   `json:"id"`
+	Name     string   `json:"name"`
+	Path     string   `json:"path"`
+	Type     string   `json:"type"`
+	Severity string   `json:"severity"`
+	Authors  []string `json:"authors,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+}
+
+// addTargetsRequest is the body of add targets requests
+type addTargetsRequest struct {
+	Targets []string `json:"targets"`
+}
+
+// addTargetsResponse is the response of add targets requests
+type addTargetsResponse struct {
+	Added int `json:"added"`
+	// Queued is true if the targets were added to a running scan
+	// and are scanned in a follow-up batch
+	Queued bool `json:"queued"`
+}
+
+// getController returns the scan controller or writes an error if it is not ready
+func (s *Server) getController(w http.ResponseWriter) ScanController {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.controller == nil {
+		http.Error(w, "Scan controller is not ready", http.StatusServiceUnavailable)
+	}
+	return s.controller
+}
+
+// handleScanStatus handles GET requests and returns the scan status
+func (s *Server) handleScanStatus(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Unsupported HTTP method", http.StatusMethodNotAllowed)
+		return
+	}
+	controller := s.getController(w)
+	if controller == nil {
+		return
+	}
+	writeJSON(w, controller.Status())
+}
+
+// handleScanAction returns a handler executing a scan action on POST requests
+func (s *Server) handleScanAction(action func(ScanController) error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Unsupported HTTP method", http.StatusMethodNotAllowed)
+			return
+		}
+		controller := s.getController(w)
+		if controller == nil {
+			return
+		}
+		if err := action(controller); err != nil {
+			if errors.Is(err, ErrScanRunning) || errors.Is(err, ErrNoScanRunning) {
+				http.Error(w, err.Error(), http.StatusConflict)
+			} else {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+			}
+			return
+		}
+		writeJSON(w, controller.Status())
+	}
+}
+
+// handleTargets handles POST requests adding targets to the scan
+func (s *Server) handleTargets(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Unsupported HTTP method", http.StatusMethodNotAllowed)
+		return
+	}
+	controller := s.getController(w)
+	if controller == nil {
+		return
+	}
+	var request addTargetsRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(request.Targets) == 0 {
+		http.Error(w, "No targets provided", http.StatusBadRequest)
+		return
+	}
+	added, queued, err := controller.AddTargets(request.Targets)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, addTargetsResponse{Added: added, Queued: queued})
+}
+
+// handleTemplates handles GET requests and returns the loaded templates
+func (s *Server) handleTemplates(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Unsupported HTTP method", http.StatusMethodNotAllowed)
+		return
+	}
+	controller := s.getController(w)
+	if controller == nil {
+		return
+	}
+	writeJSON(w, controller.Templates())
+}
+
+// writeJSON writes a json response
+func writeJSON(w http.ResponseWriter, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,262 @@
+package runner
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/projectdiscovery/gologger"
+	"github.com/projectdiscovery/nuclei/v3/internal/httpapi"
+	"github.com/projectdiscovery/nuclei/v3/pkg/catalog/loader"
+	"github.com/projectdiscovery/nuclei/v3/pkg/core"
+	"github.com/projectdiscovery/nuclei/v3/pkg/input/provider"
+	"github.com/projectdiscovery/nuclei/v3/pkg/templates"
+)
+
+// apiScanController controls the scans of the runner through the http api
+type apiScanController struct {
+	runner    *Runner
+	engine    *core.Engine
+	templates []*templates.Template
+	matched   *atomic.Bool
+
+	mu         sync.Mutex
+	state      string
+	startedAt  *time.Time
+	finishedAt *time.Time
+	cancel     context.CancelFunc
+	done       chan struct{}
+	// pending are targets added to a running scan. the input provider is
+	// iterated once per template, so they are scanned with all templates in
+	// a follow-up batch once the targets of the input provider are done
+	pending []string
+
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+var _ httpapi.ScanController = &apiScanController{}
+
+func newAPIScanController(runner *Runner, store *loader.Store, engine *core.Engine) *apiScanController {
+	finalTemplates := []*templates.Template{}
+	finalTemplates = append(finalTemplates, store.Templates()...)
+	finalTemplates = append(finalTemplates, store.Workflows()...)
+	if runner.options.VerboseVerbose {
+		for _, template := range finalTemplates {
+			runner.logAvailableTemplate(template.Path)
+		}
+	}
+
+	return &apiScanController{
+		runner:    runner,
+		engine:    engine,
+		templates: finalTemplates,
+		matched:   &atomic.Bool{},
+		state:     httpapi.ScanStateIdle,
+		closed:    make(chan struct{}),
+	}
+}
+
+// run executes a scan and waits for it to finish. In service mode
+// scans are only started through the api and run blocks until the controller is closed.
+func (c *apiScanController) run(service bool) (*atomic.Bool, error) {
+	if service {
+		gologger.Info().Msgf("Waiting for scans to be started through the api endpoint")
+		<-c.closed
+		return c.matched, nil
+	}
+	if err := c.StartScan(); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	done := c.done
+	c.mu.Unlock()
+	<-done
+	return c.matched, nil
+}
+
+// close cancels the running scan and releases a blocked run call
+func (c *apiScanController) close() {
+	c.closeOnce.Do(func() {
+		_ = c.CancelScan()
+		close(c.closed)
+	})
+}
+
+// StartScan starts a new scan with the loaded templates on all targets
+func (c *apiScanController) StartScan() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.state == httpapi.ScanStateRunning || c.state == httpapi.ScanStatePaused {
+		return httpapi.ErrScanRunning
+	}
+	if len(c.templates) == 0 {
+		return errNoTemplates
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	now := time.Now()
+	c.state = httpapi.ScanStateRunning
+	c.startedAt = &now
+	c.finishedAt = nil
+	c.cancel = cancel
+	c.done = make(chan struct{})
+	c.engine.Resume()
+
+	go c.execute(ctx, c.done)
+	return nil
+}
+
+// execute executes a scan on all targets followed by batches of targets added while running
+func (c *apiScanController) execute(ctx context.Context, done chan struct{}) {
+	defer close(done)
+
+	c.executeBatch(ctx, c.runner.inputProvider)
+
+	for {
+		c.mu.Lock()
+		pending := c.pending
+		c.pending = nil
+		if len(pending) == 0 || ctx.Err() != nil {
+			c.addToInputProvider(pending)
+			now := time.Now()
+			c.finishedAt = &now
+			if ctx.Err() != nil {
+				c.state = httpapi.ScanStateCancelled
+			} else {
+				c.state = httpapi.ScanStateCompleted
+			}
+			c.cancel()
+			c.mu.Unlock()
+			return
+		}
+		c.mu.Unlock()
+
+		c.executeBatch(ctx, provider.NewSimpleInputProviderWithUrls(pending...))
+
+		c.mu.Lock()
+		c.addToInputProvider(pending)
+		c.mu.Unlock()
+	}
+}
+
+// executeBatch executes the templates on the targets of the input provider
+func (c *apiScanController) executeBatch(ctx context.Context, inputProvider provider.InputProvider) {
+	results := c.engine.ExecuteScanWithOpts(ctx, c.templates, inputProvider, c.runner.options.DisableClustering)
+	c.matched.CompareAndSwap(false, results.Load())
+}
+
+// addToInputProvider adds targets to the input provider for subsequent scans
+func (c *apiScanController) addToInputProvider(targets []string) {
+	for _, target := range targets {
+		c.runner.inputProvider.Set(target)
+	}
+}
+
+// cancelled returns true if the last scan was cancelled
+func (c *apiScanController) cancelled() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.state == httpapi.ScanStateCancelled
+}
+
+// PauseScan pauses the running scan
+func (c *apiScanController) PauseScan() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.state != httpapi.ScanStateRunning {
+		return httpapi.ErrNoScanRunning
+	}
+	c.engine.Pause()
+	c.state = httpapi.ScanStatePaused
+	return nil
+}
+
+// ResumeScan resumes the paused scan
+func (c *apiScanController) ResumeScan() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// the engine can also be paused outside of the api
+	if c.state != httpapi.ScanStatePaused && (c.state != httpapi.ScanStateRunning || !c.engine.IsPaused()) {
+		return httpapi.ErrNoScanRunning
+	}
+	c.engine.Resume()
+	c.state = httpapi.ScanStateRunning
+	return nil
+}
+
+// CancelScan cancels the running scan
+func (c *apiScanController) CancelScan() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.state != httpapi.ScanStateRunning && c.state != httpapi.ScanStatePaused {
+		return httpapi.ErrNoScanRunning
+	}
+	c.cancel()
+	return nil
+}
+
+// Status returns the status of the current or last scan
+func (c *apiScanController) Status() *httpapi.ScanStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	state := c.state
+	if state == httpapi.ScanStateRunning && c.engine.IsPaused() {
+		state = httpapi.ScanStatePaused
+	}
+	return &httpapi.ScanStatus{
+		State:      state,
+		StartedAt:  c.startedAt,
+		FinishedAt: c.finishedAt,
+		Targets:    c.runner.inputProvider.Count() + int64(len(c.pending)),
+		Templates:  len(c.templates),
+		Matched:    c.matched.Load(),
+	}
+}
+
+// AddTargets adds targets to the scan. Targets added to a running scan
+// are queued and scanned in a follow-up batch after the existing targets.
+func (c *apiScanController) AddTargets(targets []string) (int, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	queued := c.state == httpapi.ScanStateRunning || c.state == httpapi.ScanStatePaused
+	var added int
+	for _, target := range targets {
+		target = strings.TrimSpace(target)
+		if target == "" {
+			continue
+		}
+		if queued {
+			c.pending = append(c.pending, target)
+		} else {
+			c.runner.inputProvider.Set(target)
+		}
+		added++
+	}
+	return added, queued, nil
+}
+
+// Templates returns the templates loaded for scans
+func (c *apiScanController) Templates() []*httpapi.TemplateInfo {
+	infos := make([]*httpapi.TemplateInfo, 0, len(c.templates))
+	for _, template := range c.templates {
+		infos = append(infos, &httpapi.TemplateInfo{
+			ID:       template.ID,
+			Name:     template.Info.Name,
+			Path:     template.Path,
+			Type:     template.Type().String(),
+			Severity: template.Info.SeverityHolder.Severity.String(),
+			Authors:  template.Info.Authors.ToSlice(),
+			Tags:     template.Info.Tags.ToSlice(),
+		})
+	}
+	return infos
+}
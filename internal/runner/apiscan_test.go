@@ -0,0 +1,96 @@
+package runner
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/logrusorgru/aurora"
+	"github.com/stretchr/testify/require"
+
+	"github.com/projectdiscovery/nuclei/v3/internal/httpapi"
+	"github.com/projectdiscovery/nuclei/v3/pkg/core"
+	"github.com/projectdiscovery/nuclei/v3/pkg/input/provider"
+	"github.com/projectdiscovery/nuclei/v3/pkg/output"
+	"github.com/projectdiscovery/nuclei/v3/pkg/protocols"
+	"github.com/projectdiscovery/nuclei/v3/pkg/scan"
+	"github.com/projectdiscovery/nuclei/v3/pkg/templates"
+	"github.com/projectdiscovery/nuclei/v3/pkg/types"
+)
+
+// blockingExecuter records the scanned targets and blocks the first target until released
+type blockingExecuter struct {
+	started chan struct{}
+	release chan struct{}
+	once    sync.Once
+
+	mu      sync.Mutex
+	scanned []string
+}
+
+func (e *blockingExecuter) Compile() error { return nil }
+
+func (e *blockingExecuter) Requests() int { return 1 }
+
+func (e *blockingExecuter) Execute(ctx *scan.ScanContext) (bool, error) {
+	e.once.Do(func() {
+		close(e.started)
+		<-e.release
+	})
+	e.mu.Lock()
+	e.scanned = append(e.scanned, ctx.Input.MetaInput.Input)
+	e.mu.Unlock()
+	return false, nil
+}
+
+func (e *blockingExecuter) ExecuteWithResults(ctx *scan.ScanContext) ([]*output.ResultEvent, error) {
+	_, err := e.Execute(ctx)
+	return nil, err
+}
+
+func TestAPIScanAddTargetsWhileRunning(t *testing.T) {
+	options := &types.Options{BulkSize: 2, TemplateThreads: 2, HeadlessBulkSize: 1, HeadlessTemplateThreads: 1, DisableClustering: true}
+	engine := core.New(options)
+	engine.SetExecuterOptions(protocols.ExecutorOptions{
+		Options:   options,
+		Colorizer: aurora.NewAurora(false),
+		ResumeCfg: types.NewResumeCfg(),
+	})
+	executer := &blockingExecuter{started: make(chan struct{}), release: make(chan struct{})}
+	controller := &apiScanController{
+		runner:    &Runner{options: options, inputProvider: provider.NewSimpleInputProviderWithUrls("a.example.com")},
+		engine:    engine,
+		templates: []*templates.Template{{ID: "test", Executer: executer}},
+		matched:   &atomic.Bool{},
+		state:     httpapi.ScanStateIdle,
+		closed:    make(chan struct{}),
+	}
+
+	require.NoError(t, controller.StartScan())
+	<-executer.started
+
+	added, queued, err := controller.AddTargets([]string{"b.example.com", " "})
+	require.NoError(t, err)
+	require.Equal(t, 1, added)
+	require.True(t, queued, "targets added to a running scan should be queued")
+	require.Equal(t, int64(2), controller.Status().Targets)
+
+	close(executer.release)
+	select {
+	case <-controller.done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("scan did not finish")
+	}
+
+	// queued targets are scanned in a follow-up batch after the running targets
+	require.Equal(t, []string{"a.example.com", "b.example.com"}, executer.scanned)
+	require.Equal(t, httpapi.ScanStateCompleted, controller.Status().State)
+	require.Equal(t, int64(2), controller.runner.inputProvider.Count(), "queued targets should be added for subsequent scans")
+
+	// targets added to an idle controller are not queued
+	_, queued, err = controller.AddTargets([]string{"c.example.com"})
+	require.NoError(t, err)
+	require.False(t, queued)
+	require.Equal(t, int64(3), controller.runner.inputProvider.Count())
+}
@@ -0,0 +1,201 @@
+package runner
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/projectdiscovery/gologger"
+	"github.com/projectdiscovery/nuclei/v3/pkg/output"
+)
+
+// DiffOptions contains the options for comparing the results of two scans
+type DiffOptions struct {
+	// Old is the jsonl result file of the previous scan
+	Old string
+	// New is the jsonl result file of the current scan
+	New string
+	// Output is the file to write new findings to (stdout if empty)
+	Output string
+	// Summary is the file to write the json diff summary to
+	Summary string
+}
+
+// DiffSummary is the summary of the comparison of two scans
+type DiffSummary struct {
+	New       int              `json:"new"`
+	Unchanged int              `json:"unchanged"`
+	Resolved  int              `json:"resolved"`
+	Findings  []*DiffedFinding `json:"resolved-findings,omitempty"`
+}
+
+// DiffedFinding is a finding of a scan diff
+type DiffedFinding struct {
+	TemplateID       string   `json:"template-id"`
+	MatcherName      string   `json:"matcher-name,omitempty"`
+	Host             string   `json:"host"`
+	Matched          string   `json:"matched-at,omitempty"`
+	Severity         string   `json:"severity"`
+	ExtractedResults []string `json:"extracted-results,omitempty"`
+}
+
+// findingIdentity returns the stable identity of a finding across scans
+func findingIdentity(event *output.ResultEvent) string {
+	extracted := append([]string(nil), event.ExtractedResults...)
+	sort.Strings(extracted)
+	return strings.Join([]string{
+		event.TemplateID,
+		event.MatcherName,
+		event.Host,
+		strings.Join(extracted, ","),
+	}, "\x00")
+}
+
+func newDiffedFinding(event *output.ResultEvent) *DiffedFinding {
+	return &DiffedFinding{
+		TemplateID:       event.TemplateID,
+		MatcherName:      event.MatcherName,
+		Host:             event.Host,
+		Matched:          event.Matched,
+		Severity:         event.Info.SeverityHolder.Severity.String(),
+		ExtractedResults: event.ExtractedResults,
+	}
+}
+
+// DiffResults compares the jsonl results of two scans. Findings only found
+// by the new scan are written to the output and a summary is returned.
+func DiffResults(options *DiffOptions) (*DiffSummary, error) {
+	baseline, err := loadBaseline(options.Old)
+	if err != nil {
+		return nil, err
+	}
+
+	var writer io.Writer = os.Stdout
+	if options.Output != "" {
+		file, err := os.Create(options.Output)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not create output file")
+		}
+		defer file.Close()
+		writer = file
+	}
+	bufwriter := bufio.NewWriter(writer)
+
+	err = readResultFile(options.New, func(result mergedResult) {
+		if baseline.observe(result.event) {
+			_, _ = bufwriter.Write(result.raw)
+			_ = bufwriter.WriteByte('\n')
+		}
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read results from %s", options.New)
+	}
+	if err := bufwriter.Flush(); err != nil {
+		return nil, errors.Wrap(err, "could not write new results")
+	}
+
+	summary := baseline.summary()
+	logDiffSummary(summary)
+	if options.Summary != "" {
+		data, _ := json.MarshalIndent(summary, "", "  ")
+		if err := os.WriteFile(options.Summary, append(data, '\n'), 0644); err != nil {
+			return nil, errors.Wrap(err, "could not write diff summary")
+		}
+	}
+	return summary, nil
+}
+
+// scanBaseline contains the findings of a previous scan and
+// classifies the findings of the current scan against them
+type scanBaseline struct {
+	mu       sync.Mutex
+	findings map[string]*output.ResultEvent
+	// seen contains the identities of findings of the current scan
+	seen       map[string]bool
+	newCount   int
+	unchanged  int
+	identities []string
+}
+
+// loadBaseline reads the findings of a previous scan
+func loadBaseline(path string) (*scanBaseline, error) {
+	baseline := &scanBaseline{
+		findings: make(map[string]*output.ResultEvent),
+		seen:     make(map[string]bool),
+	}
+	err := readResultFile(path, func(result mergedResult) {
+		identity := findingIdentity(result.event)
+		if _, ok := baseline.findings[identity]; !ok {
+			baseline.identities = append(baseline.identities, identity)
+		}
+		baseline.findings[identity] = result.event
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read baseline results from %s", path)
+	}
+	return baseline, nil
+}
+
+// observe records a finding of the current scan and returns true if it is new
+func (b *scanBaseline) observe(event *output.ResultEvent) bool {
+	identity := findingIdentity(event)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	_, known := b.findings[identity]
+	if !b.seen[identity] {
+		b.seen[identity] = true
+		if known {
+			b.unchanged++
+		} else {
+			b.newCount++
+		}
+	}
+	return !known
+}
+
+// summary returns the diff summary with the findings of the baseline not found again
+func (b *scanBaseline) summary() *DiffSummary {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	summary := &DiffSummary{New: b.newCount, Unchanged: b.unchanged}
+	for _, identity := range b.identities {
+		if !b.seen[identity] {
+			summary.Findings = append(summary.Findings, newDiffedFinding(b.findings[identity]))
+		}
+	}
+	summary.Resolved = len(summary.Findings)
+	return summary
+}
+
+// logDiffSummary logs the counts of a diff summary and its resolved findings
+func logDiffSummary(summary *DiffSummary) {
+	gologger.Info().Msgf("Scan diff: %d new, %d unchanged, %d resolved findings", summary.New, summary.Unchanged, summary.Resolved)
+	for _, finding := range summary.Findings {
+		gologger.Info().Msgf("Resolved: [%s] [%s] %s", finding.TemplateID, finding.Severity, finding.Host)
+	}
+}
+
+// baselineWriter is an output writer only writing findings
+// which are not part of the baseline of a previous scan
+type baselineWriter struct {
+	output.Writer
+	baseline *scanBaseline
+}
+
+var _ output.Writer = &baselineWriter{}
+
+// Write writes the event if it is a new finding
+func (w *baselineWriter) Write(event *output.ResultEvent) error {
+	if !w.baseline.observe(event) {
+		return nil
+	}
+	return w.Writer.Write(event)
+}
@@ -0,0 +1,86 @@
+package runner
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/projectdiscovery/nuclei/v3/pkg/output"
+)
+
+func TestDiffResults(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name, data string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(data), 0600))
+		return path
+	}
+
+	old := writeFile("old.jsonl", `{"template-id":"fixed","type":"http","host":"https://example.com","matched-at":"https://example.com/fixed","info":{"severity":"high"}}
+{"template-id":"same","type":"http","host":"https://example.com","matcher-name":"nginx","extracted-results":["1.0","2.0"]}
+{"template-id":"changed","type":"http","host":"https://example.com","extracted-results":["v1"]}
+`)
+	// extracted results of unchanged findings are compared regardless of their order
+	current := writeFile("new.jsonl", `{"template-id":"same","type":"http","host":"https://example.com","matcher-name":"nginx","extracted-results":["2.0","1.0"],"matched-at":"https://example.com/other"}
+{"template-id":"changed","type":"http","host":"https://example.com","extracted-results":["v2"]}
+{"template-id":"added","type":"dns","host":"example.com"}
+{"template-id":"added","type":"dns","host":"example.com"}
+`)
+
+	newOutput := filepath.Join(dir, "new-findings.jsonl")
+	summaryOutput := filepath.Join(dir, "summary.json")
+	summary, err := DiffResults(&DiffOptions{Old: old, New: current, Output: newOutput, Summary: summaryOutput})
+	require.NoError(t, err, "could not diff results")
+	require.Equal(t, 2, summary.New)
+	require.Equal(t, 1, summary.Unchanged)
+	require.Equal(t, 2, summary.Resolved)
+	require.Equal(t, "fixed", summary.Findings[0].TemplateID)
+	require.Equal(t, "high", summary.Findings[0].Severity)
+	require.Equal(t, "changed", summary.Findings[1].TemplateID)
+
+	data, err := os.ReadFile(newOutput)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 3)
+	for _, line := range lines {
+		require.NotContains(t, line, `"template-id":"same"`)
+	}
+
+	data, err = os.ReadFile(summaryOutput)
+	require.NoError(t, err)
+	var written DiffSummary
+	require.NoError(t, json.Unmarshal(data, &written))
+	require.Equal(t, summary, &written)
+}
+
+type recordingWriter struct {
+	output.Writer
+	written []string
+}
+
+func (w *recordingWriter) Write(event *output.ResultEvent) error {
+	w.written = append(w.written, event.TemplateID)
+	return nil
+}
+
+func TestBaselineWriter(t *testing.T) {
+	baseline := &scanBaseline{findings: make(map[string]*output.ResultEvent), seen: make(map[string]bool)}
+	known := &output.ResultEvent{TemplateID: "known", Host: "example.com"}
+	baseline.findings[findingIdentity(known)] = known
+	baseline.identities = append(baseline.identities, findingIdentity(known))
+
+	recorder := &recordingWriter{}
+	writer := &baselineWriter{Writer: recorder, baseline: baseline}
+	require.NoError(t, writer.Write(known))
+	require.NoError(t, writer.Write(&output.ResultEvent{TemplateID: "new", Host: "example.com"}))
+	require.Equal(t, []string{"new"}, recorder.written)
+
+	summary := baseline.summary()
+	require.Equal(t, 1, summary.New)
+	require.Equal(t, 1, summary.Unchanged)
+	require.Zero(t, summary.Resolved)
+}
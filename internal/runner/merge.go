@@ -0,0 +1,265 @@
+package runner
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/projectdiscovery/gologger"
+	"github.com/projectdiscovery/nuclei/v3/pkg/output"
+)
+
+// MergeOptions contains the options for merging the outputs of sharded scans
+type MergeOptions struct {
+	// Inputs are the jsonl result files of the shards
+	Inputs []string
+	// Output is the file to write merged results to (stdout if empty)
+	Output string
+	// StatsFiles are the files containing -stats-json output of the shards
+	StatsFiles []string
+	// StatsOutput is the file to write merged stats to (stderr if empty)
+	StatsOutput string
+}
+
+// maxResultLineSize is the maximum size of a single jsonl result
+const maxResultLineSize = 100 * 1024 * 1024
+
+// mergedResult is a raw jsonl result with fields used for sorting and deduplication
+type mergedResult struct {
+	raw       []byte
+	key       string
+	timestamp time.Time
+	event     *output.ResultEvent
+}
+
+// MergeResults combines the jsonl results and stats of sharded scans (-shard)
+// into a single output. Results are sorted by timestamp and duplicates are removed.
+func MergeResults(options *MergeOptions) error {
+	if len(options.Inputs) == 0 {
+		return errors.New("no result files provided to merge")
+	}
+
+	seen := make(map[string]struct{})
+	var results []mergedResult
+	var duplicates int
+	for _, input := range options.Inputs {
+		err := readResultFile(input, func(result mergedResult) {
+			if _, ok := seen[result.key]; ok {
+				duplicates++
+				return
+			}
+			seen[result.key] = struct{}{}
+			results = append(results, result)
+		})
+		if err != nil {
+			return errors.Wrapf(err, "could not read results from %s", input)
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].timestamp.Before(results[j].timestamp)
+	})
+
+	var writer io.Writer = os.Stdout
+	if options.Output != "" {
+		file, err := os.Create(options.Output)
+		if err != nil {
+			return errors.Wrap(err, "could not create output file")
+		}
+		defer file.Close()
+		writer = file
+	}
+	bufwriter := bufio.NewWriter(writer)
+	for _, result := range results {
+		_, _ = bufwriter.Write(result.raw)
+		_ = bufwriter.WriteByte('\n')
+	}
+	if err := bufwriter.Flush(); err != nil {
+		return errors.Wrap(err, "could not write merged results")
+	}
+	gologger.Info().Msgf("Merged %d results from %d files (%d duplicates removed)", len(results), len(options.Inputs), duplicates)
+
+	if len(options.StatsFiles) == 0 {
+		return nil
+	}
+	stats, err := mergeStatsFiles(options.StatsFiles)
+	if err != nil {
+		return err
+	}
+	data, _ := json.Marshal(stats)
+	if options.StatsOutput == "" {
+		fmt.Fprintf(os.Stderr, "%s\n", data)
+		return nil
+	}
+	return os.WriteFile(options.StatsOutput, append(data, '\n'), 0644)
+}
+
+// readResultFile reads jsonl results from a file
+func readResultFile(path string, callback func(mergedResult)) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxResultLineSize)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(strings.TrimSpace(string(line))) == 0 {
+			continue
+		}
+		var event output.ResultEvent
+		if err := json.Unmarshal(line, &event); err != nil {
+			gologger.Warning().Msgf("Skipping invalid result in %s: %s\n", path, err)
+			continue
+		}
+		raw := make([]byte, len(line))
+		copy(raw, line)
+		callback(mergedResult{
+			raw:       raw,
+			key:       resultKey(&event),
+			timestamp: event.Timestamp,
+			event:     &event,
+		})
+	}
+	return scanner.Err()
+}
+
+// resultKey returns a key identifying a unique finding
+func resultKey(event *output.ResultEvent) string {
+	return strings.Join([]string{
+		event.TemplateID,
+		event.Type,
+		event.Host,
+		event.Matched,
+		event.MatcherName,
+		event.ExtractorName,
+		strings.Join(event.ExtractedResults, ","),
+	}, "\x00")
+}
+
+// mergeStatsFiles combines the last -stats-json line of each file. Counters
+// are summed while hosts and templates are the same for all shards of a scan.
+func mergeStatsFiles(paths []string) (map[string]interface{}, error) {
+	var (
+		startedAt                             time.Time
+		duration                              time.Duration
+		hosts, templates                      uint64
+		matched, requests, total, errorsCount uint64
+	)
+	for _, path := range paths {
+		stats, err := readLastStats(path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not read stats from %s", path)
+		}
+		if value, err := time.Parse(time.RFC3339Nano, fmt.Sprint(stats["startedAt"])); err == nil {
+			if startedAt.IsZero() || value.Before(startedAt) {
+				startedAt = value
+			}
+		}
+		if value, err := parseStatsDuration(fmt.Sprint(stats["duration"])); err == nil && value > duration {
+			duration = value
+		}
+		hosts = max(hosts, parseStatsCounter(stats["hosts"]))
+		templates = max(templates, parseStatsCounter(stats["templates"]))
+		matched += parseStatsCounter(stats["matched"])
+		requests += parseStatsCounter(stats["requests"])
+		total += parseStatsCounter(stats["total"])
+		errorsCount += parseStatsCounter(stats["errors"])
+	}
+
+	results := map[string]interface{}{
+		"startedAt": startedAt,
+		"duration":  fmtStatsDuration(duration),
+		"shards":    strconv.Itoa(len(paths)),
+		"templates": strconv.FormatUint(templates, 10),
+		"hosts":     strconv.FormatUint(hosts, 10),
+		"matched":   strconv.FormatUint(matched, 10),
+		"requests":  strconv.FormatUint(requests, 10),
+		"total":     strconv.FormatUint(total, 10),
+		"errors":    strconv.FormatUint(errorsCount, 10),
+		"rps":       "0",
+		"percent":   "0",
+	}
+	if duration > 0 {
+		results["rps"] = strconv.FormatUint(uint64(float64(requests)/duration.Seconds()), 10)
+	}
+	if total > 0 {
+		// totals of shards are approximations so percent is capped
+		results["percent"] = strconv.FormatUint(min(requests*100/total, 100), 10)
+	}
+	return results, nil
+}
+
+// readLastStats returns the last stats json object of a file
+func readLastStats(path string) (map[string]interface{}, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var last map[string]interface{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "{") {
+			continue
+		}
+		var stats map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &stats); err != nil {
+			continue
+		}
+		if _, ok := stats["requests"]; ok {
+			last = stats
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if last == nil {
+		return nil, errors.New("no stats found")
+	}
+	return last, nil
+}
+
+func parseStatsCounter(value interface{}) uint64 {
+	parsed, _ := strconv.ParseUint(fmt.Sprint(value), 10, 64)
+	return parsed
+}
+
+// parseStatsDuration parses durations in the h:mm:ss format of stats
+func parseStatsDuration(value string) (time.Duration, error) {
+	parts := strings.Split(value, ":")
+	if len(parts) != 3 {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+	var duration time.Duration
+	units := []time.Duration{time.Hour, time.Minute, time.Second}
+	for i, part := range parts {
+		number, err := strconv.Atoi(part)
+		if err != nil {
+			return 0, err
+		}
+		duration += time.Duration(number) * units[i]
+	}
+	return duration, nil
+}
+
+// fmtStatsDuration formats durations in the h:mm:ss format of stats
+func fmtStatsDuration(duration time.Duration) string {
+	duration = duration.Round(time.Second)
+	hours := duration / time.Hour
+	duration -= hours * time.Hour
+	minutes := duration / time.Minute
+	duration -= minutes * time.Minute
+	seconds := duration / time.Second
+	return fmt.Sprintf("%d:%02d:%02d", hours, minutes, seconds)
+}
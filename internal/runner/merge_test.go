@@ -0,0 +1,68 @@
+package runner
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeResults(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name, data string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(data), 0600))
+		return path
+	}
+
+	shard1 := writeFile("shard1.jsonl", `{"template-id":"b","type":"http","host":"https://example.com","matched-at":"https://example.com/b","timestamp":"2024-01-01T00:00:02Z"}
+{"template-id":"a","type":"http","host":"https://example.com","matched-at":"https://example.com/a","timestamp":"2024-01-01T00:00:00Z"}
+`)
+	shard2 := writeFile("shard2.jsonl", `{"template-id":"c","type":"dns","host":"example.org","matched-at":"example.org","timestamp":"2024-01-01T00:00:01Z"}
+{"template-id":"a","type":"http","host":"https://example.com","matched-at":"https://example.com/a","timestamp":"2024-01-01T00:00:03Z"}
+`)
+	stats1 := writeFile("stats1.json", `[INF] Current nuclei version
+{"duration":"0:00:05","errors":"1","hosts":"2","matched":"1","percent":"50","requests":"10","rps":"2","startedAt":"2024-01-01T00:00:00Z","templates":"3","total":"20"}
+{"duration":"0:00:10","errors":"1","hosts":"2","matched":"2","percent":"100","requests":"20","rps":"2","startedAt":"2024-01-01T00:00:00Z","templates":"3","total":"20"}
+`)
+	stats2 := writeFile("stats2.json", `{"duration":"0:01:00","errors":"2","hosts":"2","matched":"2","percent":"100","requests":"30","rps":"0","startedAt":"2023-12-31T23:59:00Z","templates":"3","total":"30"}
+`)
+
+	output := filepath.Join(dir, "merged.jsonl")
+	statsOutput := filepath.Join(dir, "merged-stats.json")
+	err := MergeResults(&MergeOptions{
+		Inputs:      []string{shard1, shard2},
+		Output:      output,
+		StatsFiles:  []string{stats1, stats2},
+		StatsOutput: statsOutput,
+	})
+	require.NoError(t, err, "could not merge results")
+
+	data, err := os.ReadFile(output)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 3, "duplicate result was not removed")
+	var templateIDs []string
+	for _, line := range lines {
+		var event map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &event))
+		templateIDs = append(templateIDs, event["template-id"].(string))
+	}
+	require.Equal(t, []string{"a", "c", "b"}, templateIDs, "results are not sorted by timestamp")
+
+	data, err = os.ReadFile(statsOutput)
+	require.NoError(t, err)
+	var stats map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &stats))
+	require.Equal(t, "2", stats["shards"])
+	require.Equal(t, "50", stats["requests"])
+	require.Equal(t, "50", stats["total"])
+	require.Equal(t, "4", stats["matched"])
+	require.Equal(t, "3", stats["errors"])
+	require.Equal(t, "2", stats["hosts"])
+	require.Equal(t, "0:01:00", stats["duration"])
+	require.Equal(t, "2023-12-31T23:59:00Z", stats["startedAt"])
+}
@@ -22,10 +22,13 @@ import (
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/utils/vardump"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/headless/engine"
 	"github.com/projectdiscovery/nuclei/v3/pkg/reporting"
+	"github.com/projectdiscovery/nuclei/v3/pkg/reporting/exporters/html"
 	"github.com/projectdiscovery/nuclei/v3/pkg/reporting/exporters/jsonexporter"
 	"github.com/projectdiscovery/nuclei/v3/pkg/reporting/exporters/jsonl"
 	"github.com/projectdiscovery/nuclei/v3/pkg/reporting/exporters/markdown"
 	"github.com/projectdiscovery/nuclei/v3/pkg/reporting/exporters/sarif"
+	"github.com/projectdiscovery/nuclei/v3/pkg/reporting/exporters/sqlexporter"
+	"github.com/projectdiscovery/nuclei/v3/pkg/reporting/exporters/vex"
 	"github.com/projectdiscovery/nuclei/v3/pkg/templates/extensions"
 	"github.com/projectdiscovery/nuclei/v3/pkg/types"
 	"github.com/projectdiscovery/nuclei/v3/pkg/utils/yaml"
@@ -154,6 +157,22 @@ func ValidateOptions(options *types.Options) error {
 		return errors.New("both verbose and silent mode specified")
 	}
 
+	if _, err := types.ParseShard(options.Shard); err != nil {
+		return err
+	}
+	if options.HttpApiService && options.HttpApiEndpoint == "" {
+		return errors.New("http api endpoint (-hae) is required for http api service mode")
+	}
+	if options.ReportingReconcile {
+		if options.ReportingDB == "" {
+			return errors.New("reporting database (-rdb) is required to reconcile issues")
+		}
+		// partial scans do not retest all findings of previous scans
+		if options.Shard != "" || options.Resume != "" || options.AutomaticScan || options.HttpApiEndpoint != "" {
+			return errors.New("issue reconciliation can't be used with -shard, -resume, -as or -hae")
+		}
+	}
+
 	if (options.HeadlessOptionalArguments != nil || options.ShowBrowser || options.UseInstalledChrome) && !options.Headless {
 		return errors.New("headless mode (-headless) is required if -ho, -sb, -sc or -lha are set")
 	}
@@ -168,6 +187,9 @@ func ValidateOptions(options *types.Options) error {
 	if err := loadProxyServers(options); err != nil {
 		return err
 	}
+	if options.ForceHTTP3 && (types.ProxyURL != "" || types.ProxySocksURL != "") {
+		return errors.New("force http3 (-fh3) can't be used with a proxy as quic connections can't be sent through http or socks proxies")
+	}
 	if options.Validate {
 		validateTemplatePaths(config.DefaultConfig.TemplatesDirectory, options.Templates, options.Workflows)
 	}
@@ -311,6 +333,28 @@ func createReportingOptions(options *types.Options) (*reporting.Options, error)
 		}
 	}
 
+	if options.VEXExport != "" {
+		reportingOptions.VEXExporter = &vex.Options{
+			File:   options.VEXExport,
+			Format: options.VEXFormat,
+		}
+	}
+	if options.SQLExport != "" {
+		reportingOptions.SQLExporter = &sqlexporter.Options{
+			ConnectionString: options.SQLExport,
+			OmitRaw:          options.OmitRawRequests,
+		}
+	}
+	if reportingOptions.SQLExporter != nil && reportingOptions.SQLExporter.ScanID == "" {
+		reportingOptions.SQLExporter.ScanID = options.ScanID
+	}
+	if options.HTMLExport != "" {
+		reportingOptions.HTMLExporter = &html.Options{
+			File:    options.HTMLExport,
+			OmitRaw: options.OmitRawRequests,
+		}
+	}
+
 	reportingOptions.OmitRaw = options.OmitRawRequests
 	return reportingOptions, nil
 }
@@ -60,6 +60,7 @@ import (
 	"github.com/projectdiscovery/nuclei/v3/pkg/reporting"
 	"github.com/projectdiscovery/nuclei/v3/pkg/templates"
 	"github.com/projectdiscovery/nuclei/v3/pkg/types"
+	"github.com/projectdiscovery/nuclei/v3/pkg/types/resume"
 	"github.com/projectdiscovery/nuclei/v3/pkg/utils"
 	"github.com/projectdiscovery/nuclei/v3/pkg/utils/stats"
 	"github.com/projectdiscovery/nuclei/v3/pkg/utils/yaml"
@@ -88,6 +89,7 @@ type Runner struct {
 	rateLimiter        *ratelimit.Limiter
 	hostErrors         hosterrorscache.CacheInterface
 	resumeCfg          *types.ResumeCfg
+	resumeStorePath    string
 	pprofServer        *http.Server
 	pdcpUploadErrMsg   string
 	inputProvider      provider.InputProvider
@@ -96,10 +98,19 @@ type Runner struct {
 	tmpDir          string
 	parser          parser.Parser
 	httpApiEndpoint *httpapi.Server
+	apiController   *apiScanController
+	// baseline contains the findings of a previous scan (-baseline)
+	baseline *scanBaseline
+	// engine is the engine of the running scan
+	engine atomic.Pointer[core.Engine]
+	// reconcileScope contains the pairs completed by the scan (-reconcile)
+	reconcileScope *reporting.ReconcileScope
 }
 
 const pprofServerAddress = "127.0.0.1:8086"
 
+var errNoTemplates = errors.New("no templates provided for scan")
+
 // New creates a new client for running the enumeration process.
 func New(options *types.Options) (*Runner, error) {
 	runner := &Runner{
@@ -256,6 +267,19 @@ func New(options *types.Options) (*Runner, error) {
 	}
 	// setup a proxy writer to automatically upload results to PDCP
 	runner.output = runner.setupPDCPUpload(outputWriter)
+	// stream results to http api clients
+	if runner.httpApiEndpoint != nil {
+		runner.output = output.NewMultiWriter(runner.output, runner.httpApiEndpoint.ResultWriter())
+	}
+	// only write findings not found by the baseline scan
+	if options.Baseline != "" {
+		baseline, err := loadBaseline(options.Baseline)
+		if err != nil {
+			return nil, err
+		}
+		runner.baseline = baseline
+		runner.output = &baselineWriter{Writer: runner.output, baseline: baseline}
+	}
 
 	if options.JSONL && options.EnableProgressBar {
 		options.StatsJSON = true
@@ -284,15 +308,38 @@ func New(options *types.Options) (*Runner, error) {
 	resumeCfg := types.NewResumeCfg()
 	if runner.options.ShouldLoadResume() {
 		gologger.Info().Msg("Resuming from save checkpoint")
-		file, err := os.ReadFile(runner.options.Resume)
-		if err != nil {
-			return nil, err
+		if resume.IsStore(runner.options.Resume) {
+			store, err := resume.NewBoltStore(runner.options.Resume)
+			if err != nil {
+				return nil, err
+			}
+			resumeCfg.Store = store
+			runner.resumeStorePath = runner.options.Resume
+		} else {
+			file, err := os.ReadFile(runner.options.Resume)
+			if err != nil {
+				return nil, err
+			}
+			err = json.Unmarshal(file, &resumeCfg)
+			if err != nil {
+				return nil, err
+			}
+			resumeCfg.Compile()
+		}
+	}
+	// record the scan progression as it happens so that crashed scans can be resumed
+	if resumeCfg.Store == nil && runner.options.ResumeStore {
+		resumeStorePath := types.DefaultResumeStorePath()
+		if err := fileutil.CreateFolder(filepath.Dir(resumeStorePath)); err != nil {
+			return nil, errors.Wrap(err, "could not create resume store directory")
 		}
-		err = json.Unmarshal(file, &resumeCfg)
+		store, err := resume.NewBoltStore(resumeStorePath)
 		if err != nil {
 			return nil, err
 		}
-		resumeCfg.Compile()
+		gologger.Info().Msgf("Recording scan progression to resume store: %s", resumeStorePath)
+		resumeCfg.Store = store
+		runner.resumeStorePath = resumeStorePath
 	}
 	runner.resumeCfg = resumeCfg
 
@@ -357,11 +404,14 @@ func (r *Runner) runStandardEnumeration(executerOpts protocols.ExecutorOptions,
 	if r.options.AutomaticScan {
 		return r.executeSmartWorkflowInput(executerOpts, store, engine)
 	}
-	return r.executeTemplatesInput(store, engine)
+	return r.executeTemplatesInput(context.Background(), store, engine)
 }
 
 // Close releases all the resources and cleans up
 func (r *Runner) Close() {
+	if r.apiController != nil {
+		r.apiController.close()
+	}
 	// dump hosterrors cache
 	if r.hostErrors != nil {
 		r.hostErrors.Close()
@@ -392,6 +442,9 @@ func (r *Runner) Close() {
 	if r.tmpDir != "" {
 		_ = os.RemoveAll(r.tmpDir)
 	}
+	if r.resumeCfg != nil && r.resumeCfg.Store != nil {
+		_ = r.resumeCfg.Store.Close()
+	}
 
 	//this is no-op unless nuclei is built with stats build tag
 	events.Close()
@@ -523,6 +576,11 @@ func (r *Runner) RunEnumeration() error {
 
 	executorEngine := core.New(r.options)
 	executorEngine.SetExecuterOptions(executorOpts)
+	r.engine.Store(executorEngine)
+	if r.options.ReportingReconcile && r.issuesClient != nil {
+		r.reconcileScope = reporting.NewReconcileScope()
+		executorEngine.OnCompleted = r.addReconcileScope
+	}
 
 	workflowLoader, err := parsers.NewLoader(&executorOpts)
 	if err != nil {
@@ -623,10 +681,23 @@ func (r *Runner) RunEnumeration() error {
 		JsConcurrency:       r.options.JsConcurrency,
 		Retries:             r.options.Retries,
 	}, "")
+	events.AddRunEvent(events.ScanEvent{
+		Time:           time.Now(),
+		EventType:      events.RunStarted,
+		TargetCount:    int(r.inputProvider.Count()),
+		TemplatesCount: len(store.Templates()) + len(store.Workflows()),
+	})
 
 	enumeration := false
 	var results *atomic.Bool
-	results, err = r.runStandardEnumeration(executorOpts, store, executorEngine)
+	if r.httpApiEndpoint != nil && !r.options.AutomaticScan {
+		// scans are controlled through the http api
+		r.apiController = newAPIScanController(r, store, executorEngine)
+		r.httpApiEndpoint.SetController(r.apiController)
+		results, err = r.apiController.run(r.options.HttpApiService)
+	} else {
+		results, err = r.runStandardEnumeration(executorOpts, store, executorEngine)
+	}
 	enumeration = true
 
 	if !enumeration {
@@ -643,6 +714,18 @@ func (r *Runner) RunEnumeration() error {
 		_ = executorOpts.InputHelper.Close()
 	}
 	r.fuzzFrequencyCache.Close()
+	if r.baseline != nil {
+		logDiffSummary(r.baseline.summary())
+	}
+	events.AddRunEvent(events.ScanEvent{
+		Time:      time.Now(),
+		EventType: events.RunFinished,
+	})
+
+	// findings of cancelled scans can't be told apart from fixed findings
+	if err == nil && r.reconcileScope != nil && !r.scanCancelled() {
+		r.reconcileIssues()
+	}
 
 	// todo: error propagation without canonical straight error check is required by cloud?
 	// use safe dereferencing to avoid potential panics in case of previous unchecked errors
@@ -689,7 +772,7 @@ func (r *Runner) executeSmartWorkflowInput(executorOpts protocols.ExecutorOption
 	return result, nil
 }
 
-func (r *Runner) executeTemplatesInput(store *loader.Store, engine *core.Engine) (*atomic.Bool, error) {
+func (r *Runner) executeTemplatesInput(ctx context.Context, store *loader.Store, engine *core.Engine) (*atomic.Bool, error) {
 	if r.options.VerboseVerbose {
 		for _, template := range store.Templates() {
 			r.logAvailableTemplate(template.Path)
@@ -704,7 +787,7 @@ func (r *Runner) executeTemplatesInput(store *loader.Store, engine *core.Engine)
 	finalTemplates = append(finalTemplates, store.Workflows()...)
 
 	if len(finalTemplates) == 0 {
-		return nil, errors.New("no templates provided for scan")
+		return nil, errNoTemplates
 	}
 
 	// pass input provider to engine
@@ -712,7 +795,7 @@ func (r *Runner) executeTemplatesInput(store *loader.Store, engine *core.Engine)
 	if r.inputProvider == nil {
 		return nil, errors.New("no input provider found")
 	}
-	results := engine.ExecuteScanWithOpts(context.Background(), finalTemplates, r.inputProvider, r.options.DisableClustering)
+	results := engine.ExecuteScanWithOpts(ctx, finalTemplates, r.inputProvider, r.options.DisableClustering)
 	return results, nil
 }
 
@@ -783,6 +866,56 @@ func (r *Runner) displayExecutionInfo(store *loader.Store) {
 	}
 }
 
+// addReconcileScope adds a template executed to completion on a target to the reconcile scope
+func (r *Runner) addReconcileScope(template *templates.Template, target *contextargs.MetaInput) {
+	// clustered templates are executed as a single template
+	if cluster, ok := template.Executer.(*templates.ClusterExecuter); ok {
+		for _, id := range cluster.TemplateIDs() {
+			r.reconcileScope.AddCompleted(id, target.Input)
+		}
+		return
+	}
+	r.reconcileScope.AddCompleted(template.ID, target.Input)
+}
+
+// scanCancelled returns true if the scan was cancelled before completion
+func (r *Runner) scanCancelled() bool {
+	return r.apiController != nil && r.apiController.cancelled()
+}
+
+// reconcileIssues closes the tracker issues of findings which were
+// tested by the scan and no longer match
+func (r *Runner) reconcileIssues() {
+	reconciled, err := r.issuesClient.Reconcile(r.reconcileScope)
+	if err != nil {
+		gologger.Warning().Msgf("Could not reconcile issues: %s\n", err)
+	}
+	if reconciled > 0 {
+		gologger.Info().Msgf("Closed %d issues of findings no longer found", reconciled)
+	}
+}
+
+// TogglePause pauses the running scan or resumes it if it is paused
+// and returns true if the scan is paused afterwards.
+func (r *Runner) TogglePause() bool {
+	engine := r.engine.Load()
+	if engine == nil {
+		return false
+	}
+	if engine.IsPaused() {
+		engine.Resume()
+		return false
+	}
+	engine.Pause()
+	return true
+}
+
+// ResumeStorePath returns the path of the resume store
+// recording the scan progression (empty if disabled)
+func (r *Runner) ResumeStorePath() string {
+	return r.resumeStorePath
+}
+
 // SaveResumeConfig to file
 func (r *Runner) SaveResumeConfig(path string) error {
 	dir := filepath.Dir(path)
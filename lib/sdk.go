@@ -269,6 +269,22 @@ func (e *NucleiEngine) ExecuteWithCallback(callback ...func(event *output.Result
 	return e.ExecuteCallbackWithCtx(context.Background(), callback...)
 }
 
+// Pause pauses a running scan. In-flight requests complete while
+// new templates and targets are not executed until Resume is called.
+func (e *NucleiEngine) Pause() {
+	e.engine.Pause()
+}
+
+// Resume resumes a paused scan from where it was paused
+func (e *NucleiEngine) Resume() {
+	e.engine.Resume()
+}
+
+// IsPaused returns true if the scan is paused
+func (e *NucleiEngine) IsPaused() bool {
+	return e.engine.IsPaused()
+}
+
 // Options return nuclei Type Options
 func (e *NucleiEngine) Options() *types.Options {
 	return e.opts
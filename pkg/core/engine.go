@@ -3,6 +3,8 @@ package core
 import (
 	"github.com/projectdiscovery/nuclei/v3/pkg/output"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols"
+	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/contextargs"
+	"github.com/projectdiscovery/nuclei/v3/pkg/templates"
 	"github.com/projectdiscovery/nuclei/v3/pkg/types"
 )
 
@@ -18,7 +20,9 @@ type Engine struct {
 	workPool     *WorkPool
 	options      *types.Options
 	executerOpts protocols.ExecutorOptions
-	Callback     func(*output.ResultEvent) // Executed on results
+	Callback     func(*output.ResultEvent)                                         // Executed on results
+	OnCompleted  func(template *templates.Template, target *contextargs.MetaInput) // Executed on templates completed on a target without errors
+	shard        *types.Shard
 }
 
 // New returns a new Engine instance
@@ -26,6 +30,8 @@ func New(options *types.Options) *Engine {
 	engine := &Engine{
 		options: options,
 	}
+	// shard is validated with the rest of options, invalid values disable sharding
+	engine.shard, _ = types.ParseShard(options.Shard)
 	engine.workPool = engine.GetWorkPool()
 	return engine
 }
@@ -42,7 +48,12 @@ func (e *Engine) GetWorkPoolConfig() WorkPoolConfig {
 
 // GetWorkPool returns a workpool from options
 func (e *Engine) GetWorkPool() *WorkPool {
-	return NewWorkPool(e.GetWorkPoolConfig())
+	wp := NewWorkPool(e.GetWorkPoolConfig())
+	// pools share the pause state of the engine
+	if e.workPool != nil {
+		wp.gate = e.workPool.gate
+	}
+	return wp
 }
 
 // SetExecuterOptions sets the executer options for the engine. This is required
@@ -62,3 +73,19 @@ func (e *Engine) WorkPool() *WorkPool {
 	e.workPool.RefreshWithConfig(e.GetWorkPoolConfig())
 	return e.workPool
 }
+
+// Pause pauses the scan. Templates and targets are not scheduled for
+// execution until Resume is called while in-flight executions complete.
+func (e *Engine) Pause() {
+	e.workPool.Pause()
+}
+
+// Resume resumes a paused scan
+func (e *Engine) Resume() {
+	e.workPool.Resume()
+}
+
+// IsPaused returns true if the scan is paused
+func (e *Engine) IsPaused() bool {
+	return e.workPool.IsPaused()
+}
@@ -63,7 +63,12 @@ func (e *Engine) ExecuteScanWithOpts(ctx context.Context, templatesList []*templ
 		// workflow requests are not counted as they can be conditional
 		// templateList count is user requested templates count (before clustering)
 		// totalReqAfterClustering is total requests count after clustering
-		e.executerOpts.Progress.Init(target.Count(), len(templatesList), int64(totalReqAfterClustering))
+		totalRequests := int64(totalReqAfterClustering)
+		if e.shard != nil {
+			// pairs are distributed uniformly so each shard executes ~1/M of the requests
+			totalRequests /= int64(e.shard.Count)
+		}
+		e.executerOpts.Progress.Init(target.Count(), len(templatesList), totalRequests)
 	}
 
 	if stringsutil.EqualFoldAny(e.options.ScanStrategy, scanstrategy.Auto.String(), "") {
@@ -77,7 +82,9 @@ func (e *Engine) ExecuteScanWithOpts(ctx context.Context, templatesList []*templ
 	// Filter Self Contained templates since they are not bound to target
 	for _, v := range finalTemplates {
 		if v.SelfContained {
-			selfContained = append(selfContained, v)
+			if e.shard.Contains(v.ID, "") {
+				selfContained = append(selfContained, v)
+			}
 		} else {
 			filtered = append(filtered, v)
 		}
@@ -127,6 +134,7 @@ func (e *Engine) executeTemplateSpray(ctx context.Context, templatesList []*temp
 		}
 
 		wg.Add()
+		wp.WaitIfPaused(ctx)
 		go func(tpl *templates.Template) {
 			defer wg.Done()
 			// All other request types are executed here
@@ -152,6 +160,8 @@ func (e *Engine) executeHostSpray(ctx context.Context, templatesList []*template
 		}
 
 		wp.Add()
+		// wait after acquiring a slot so no work is dispatched once paused
+		e.workPool.WaitIfPaused(ctx)
 		go func(targetval *contextargs.MetaInput) {
 			defer wp.Done()
 			e.executeTemplatesOnTarget(ctx, templatesList, targetval, results)
@@ -84,6 +84,12 @@ func (e *Engine) executeTemplateWithTargets(ctx context.Context, template *templ
 		default:
 		}
 
+		// the pair is executed by another shard
+		if !e.shard.Contains(template.ID, scannedValue.ID()) {
+			index++
+			return true
+		}
+
 		// Best effort to track the host progression
 		// skips indexes lower than the minimum in-flight at interruption time
 		var skip bool
@@ -101,6 +107,10 @@ func (e *Engine) executeTemplateWithTargets(ctx context.Context, template *templ
 			// skip is already false - but leaving it here for clarity
 			skip = false
 		}
+		if !skip && e.isCompleted(template.ID, scannedValue) { // the target was completed before an interruption
+			gologger.Debug().Msgf("[%s] Skipping \"%s\": Resume - Target already completed\n", template.ID, scannedValue.Input)
+			skip = true
+		}
 
 		currentInfo.Lock()
 		currentInfo.InFlight[index] = struct{}{}
@@ -112,6 +122,8 @@ func (e *Engine) executeTemplateWithTargets(ctx context.Context, template *templ
 		}
 
 		wg.Add()
+		// wait after acquiring a slot so no work is dispatched once paused
+		e.workPool.WaitIfPaused(ctx)
 		go func(index uint32, skip bool, value *contextargs.MetaInput) {
 			defer wg.Done()
 			defer cleanupInFlight(index)
@@ -143,6 +155,10 @@ func (e *Engine) executeTemplateWithTargets(ctx context.Context, template *templ
 				gologger.Warning().Msgf("[%s] Could not execute step: %s\n", e.executerOpts.Colorizer.BrightBlue(template.ID), err)
 			}
 			results.CompareAndSwap(false, match)
+			if executionSucceeded(ctx, err) {
+				e.markCompleted(template.ID, value)
+				e.notifyCompleted(template, value)
+			}
 		}(index, skip, scannedValue)
 		index++
 		return true
@@ -171,6 +187,14 @@ func (e *Engine) executeTemplatesOnTarget(ctx context.Context, alltemplates []*t
 		default:
 		}
 
+		if !e.shard.Contains(tpl.ID, target.ID()) {
+			continue
+		}
+		if e.isCompleted(tpl.ID, target) {
+			gologger.Debug().Msgf("[%s] Skipping \"%s\": Resume - Target already completed\n", tpl.ID, target.Input)
+			continue
+		}
+
 		// resize check point - nop if there are no changes
 		wp.RefreshWithConfig(e.GetWorkPoolConfig())
 
@@ -181,6 +205,7 @@ func (e *Engine) executeTemplatesOnTarget(ctx context.Context, alltemplates []*t
 			sg = wp.Default
 		}
 		sg.Add()
+		wp.WaitIfPaused(ctx)
 		go func(template *templates.Template, value *contextargs.MetaInput, wg *syncutil.AdaptiveWaitGroup) {
 			defer wg.Done()
 
@@ -208,7 +233,45 @@ func (e *Engine) executeTemplatesOnTarget(ctx context.Context, alltemplates []*t
 				gologger.Warning().Msgf("[%s] Could not execute step: %s\n", e.executerOpts.Colorizer.BrightBlue(template.ID), err)
 			}
 			results.CompareAndSwap(false, match)
+			if executionSucceeded(ctx, err) {
+				e.markCompleted(template.ID, value)
+				e.notifyCompleted(template, value)
+			}
 		}(tpl, target, sg)
 	}
 	wp.Wait()
 }
+
+// isCompleted returns true if the template was already completed on the target
+// according to the scan state store of an interrupted scan
+func (e *Engine) isCompleted(templateID string, target *contextargs.MetaInput) bool {
+	if e.executerOpts.ResumeCfg == nil || e.executerOpts.ResumeCfg.Store == nil {
+		return false
+	}
+	return e.executerOpts.ResumeCfg.Store.IsCompleted(templateID, target.ID())
+}
+
+// executionSucceeded returns true if a template was executed on a target without errors.
+// executions which failed, logged errors (e.g. unreachable hosts) or were cancelled
+// are not completed so they are retried on resume and not reconciled.
+func executionSucceeded(ctx *scan.ScanContext, err error) bool {
+	return err == nil && ctx.GenerateErrorMessage() == nil && ctx.Context().Err() == nil
+}
+
+// markCompleted records the template as completed on the target in the scan state store.
+func (e *Engine) markCompleted(templateID string, target *contextargs.MetaInput) {
+	if e.executerOpts.ResumeCfg == nil || e.executerOpts.ResumeCfg.Store == nil {
+		return
+	}
+	if err := e.executerOpts.ResumeCfg.Store.MarkCompleted(templateID, target.ID()); err != nil {
+		gologger.Warning().Msgf("[%s] Could not save scan state for %s: %s\n", templateID, target.Input, err)
+	}
+}
+
+// notifyCompleted executes the completion callback for a template completed on a target
+func (e *Engine) notifyCompleted(template *templates.Template, target *contextargs.MetaInput) {
+	if e.OnCompleted == nil {
+		return
+	}
+	e.OnCompleted(template, target)
+}
@@ -0,0 +1,96 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/logrusorgru/aurora"
+	"github.com/stretchr/testify/require"
+
+	"github.com/projectdiscovery/nuclei/v3/pkg/input/provider"
+	"github.com/projectdiscovery/nuclei/v3/pkg/output"
+	"github.com/projectdiscovery/nuclei/v3/pkg/protocols"
+	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/contextargs"
+	"github.com/projectdiscovery/nuclei/v3/pkg/scan"
+	"github.com/projectdiscovery/nuclei/v3/pkg/templates"
+	"github.com/projectdiscovery/nuclei/v3/pkg/types"
+)
+
+// unreachableExecuter logs an error on the scan context for unreachable targets like protocol executers
+type unreachableExecuter struct {
+	unreachable string
+}
+
+func (m *unreachableExecuter) Compile() error { return nil }
+
+func (m *unreachableExecuter) Requests() int { return 1 }
+
+func (m *unreachableExecuter) Execute(ctx *scan.ScanContext) (bool, error) {
+	if ctx.Input.MetaInput.Input == m.unreachable {
+		ctx.LogError(errors.New("no address found for host"))
+	}
+	return false, nil
+}
+
+func (m *unreachableExecuter) ExecuteWithResults(ctx *scan.ScanContext) ([]*output.ResultEvent, error) {
+	_, err := m.Execute(ctx)
+	return nil, err
+}
+
+// memoryStore is an in-memory resume store
+type memoryStore struct {
+	mu        sync.Mutex
+	completed map[string]struct{}
+}
+
+func (m *memoryStore) IsCompleted(templateID, target string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_, ok := m.completed[templateID+"@"+target]
+	return ok
+}
+
+func (m *memoryStore) MarkCompleted(templateID, target string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.completed[templateID+"@"+target] = struct{}{}
+	return nil
+}
+
+func (m *memoryStore) Close() error { return nil }
+
+func TestEngineOnCompleted(t *testing.T) {
+	options := &types.Options{BulkSize: 2, TemplateThreads: 2, HeadlessBulkSize: 1, HeadlessTemplateThreads: 1}
+	store := &memoryStore{completed: make(map[string]struct{})}
+	resumeCfg := types.NewResumeCfg()
+	resumeCfg.Store = store
+	engine := New(options)
+	engine.SetExecuterOptions(protocols.ExecutorOptions{
+		Options:   options,
+		Colorizer: aurora.NewAurora(false),
+		ResumeCfg: resumeCfg,
+	})
+
+	var (
+		mu        sync.Mutex
+		completed []string
+	)
+	engine.OnCompleted = func(template *templates.Template, target *contextargs.MetaInput) {
+		mu.Lock()
+		completed = append(completed, template.ID+"@"+target.Input)
+		mu.Unlock()
+	}
+
+	template := &templates.Template{ID: "test", Executer: &unreachableExecuter{unreachable: "unreachable.com"}}
+	targets := provider.NewSimpleInputProviderWithUrls("example.com", "unreachable.com")
+	engine.executeTemplateWithTargets(context.Background(), template, targets, &atomic.Bool{})
+
+	sort.Strings(completed)
+	require.Equal(t, []string{"test@example.com"}, completed, "failed executions should not be completed")
+	require.True(t, store.IsCompleted("test", "example.com"))
+	require.False(t, store.IsCompleted("test", "unreachable.com"), "failed executions should be retried on resume")
+}
@@ -0,0 +1,57 @@
+package core
+
+import (
+	"context"
+	"sync"
+)
+
+// pauseGate blocks the scheduling of new work while a scan is paused.
+// The zero value is an unpaused gate.
+type pauseGate struct {
+	mu     sync.Mutex
+	paused bool
+	resume chan struct{}
+}
+
+// pause pauses the gate, it is a no-op if already paused
+func (g *pauseGate) pause() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if !g.paused {
+		g.paused = true
+		g.resume = make(chan struct{})
+	}
+}
+
+// unpause releases all waiters, it is a no-op if not paused
+func (g *pauseGate) unpause() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.paused {
+		g.paused = false
+		close(g.resume)
+	}
+}
+
+// isPaused returns true if the gate is paused
+func (g *pauseGate) isPaused() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.paused
+}
+
+// wait blocks while the gate is paused or until the context is done
+func (g *pauseGate) wait(ctx context.Context) {
+	g.mu.Lock()
+	if !g.paused {
+		g.mu.Unlock()
+		return
+	}
+	resume := g.resume
+	g.mu.Unlock()
+
+	select {
+	case <-resume:
+	case <-ctx.Done():
+	}
+}
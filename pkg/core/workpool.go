@@ -17,6 +17,9 @@ type WorkPool struct {
 	Headless *syncutil.AdaptiveWaitGroup
 	Default  *syncutil.AdaptiveWaitGroup
 	config   WorkPoolConfig
+	// gate pauses the dispatch of new work, it is shared
+	// by the work pools of an engine
+	gate *pauseGate
 }
 
 // WorkPoolConfig is the configuration for work pool
@@ -40,6 +43,7 @@ func NewWorkPool(config WorkPoolConfig) *WorkPool {
 		config:   config,
 		Headless: headlessWg,
 		Default:  defaultWg,
+		gate:     &pauseGate{},
 	}
 }
 
@@ -49,6 +53,29 @@ func (w *WorkPool) Wait() {
 	w.Headless.Wait()
 }
 
+// Pause pauses the dispatch of new work. In-flight work is
+// not interrupted and completes while the pool is paused.
+func (w *WorkPool) Pause() {
+	w.gate.pause()
+}
+
+// Resume resumes the dispatch of work of a paused pool
+func (w *WorkPool) Resume() {
+	w.gate.unpause()
+}
+
+// IsPaused returns true if the pool is paused
+func (w *WorkPool) IsPaused() bool {
+	return w.gate.isPaused()
+}
+
+// WaitIfPaused blocks while the pool is paused or until the context is done.
+// It is called after acquiring a slot of the pool or its input pools and
+// before dispatching work, so no work is dispatched once paused.
+func (w *WorkPool) WaitIfPaused(ctx context.Context) {
+	w.gate.wait(ctx)
+}
+
 // InputPool returns a work pool for an input type
 func (w *WorkPool) InputPool(templateType types.ProtocolType) *syncutil.AdaptiveWaitGroup {
 	var count int
@@ -0,0 +1,47 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/projectdiscovery/nuclei/v3/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkPoolPause(t *testing.T) {
+	engine := New(&types.Options{BulkSize: 1, TemplateThreads: 1})
+	wp := engine.GetWorkPool()
+
+	engine.Pause()
+	require.True(t, wp.IsPaused(), "work pools of the engine do not share the pause state")
+
+	released := make(chan struct{})
+	go func() {
+		wp.WaitIfPaused(context.Background())
+		close(released)
+	}()
+	select {
+	case <-released:
+		t.Fatal("paused work pool dispatched work")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	engine.Resume()
+	select {
+	case <-released:
+	case <-time.After(time.Second):
+		t.Fatal("resumed work pool did not dispatch work")
+	}
+	require.False(t, engine.IsPaused())
+
+	t.Run("context", func(t *testing.T) {
+		engine.Pause()
+		defer engine.Resume()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		wp.WaitIfPaused(ctx)
+		require.Error(t, ctx.Err(), "wait returned before the context was done")
+	})
+}
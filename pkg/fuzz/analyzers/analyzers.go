@@ -0,0 +1,81 @@
+// Package analyzers contains analyzers which are run on the responses of
+// fuzzing requests to detect issues that can not be detected by matchers,
+// for example blind injections only observable through response times.
+package analyzers
+
+import (
+	"sync"
+	"time"
+
+	"github.com/projectdiscovery/nuclei/v3/pkg/fuzz/component"
+	"github.com/projectdiscovery/retryablehttp-go"
+)
+
+// Analyzer is an interface for all the analyzers of fuzzing rules
+type Analyzer interface {
+	// Name returns the name of the analyzer
+	Name() string
+	// ApplyInitialTransformation applies the transformation to the payload
+	// used for the initial fuzzing request (ex: replacing markers)
+	ApplyInitialTransformation(data string, params map[string]interface{}) string
+	// Analyze analyzes the response of a fuzzing request and returns true
+	// along with details of the analysis if an issue was found
+	Analyze(options *Options) (bool, string, error)
+}
+
+// AnalyzerTemplate is the analyzer configuration of a fuzzing rule
+type AnalyzerTemplate struct {
+	// description: |
+	//   Name is the name of the analyzer to run.
+	// values:
+	//   - "time_delay"
+	Name string `yaml:"name" json:"name" jsonschema:"title=name of the analyzer,description=Name of the analyzer to run,enum=time_delay"`
+	// description: |
+	//   Parameters is the map of parameters for the analyzer.
+	//
+	//   Parameters differ for each analyzer, for example time_delay accepts
+	//   sleep_duration, requests_limit, time_slope_error_range and
+	//   time_correlation_error_range.
+	Parameters map[string]interface{} `yaml:"parameters,omitempty" json:"parameters,omitempty" jsonschema:"title=parameters of the analyzer,description=Parameters of the analyzer"`
+}
+
+// Options contains the data required for analyzing a fuzzing request
+type Options struct {
+	// BaseRequest is the request without fuzzing payloads used as baseline
+	BaseRequest *retryablehttp.Request
+	// Component is the fuzzed component of the request
+	Component component.Component
+	// Key is the fuzzed key of the component
+	Key string
+	// Value is the original value of the fuzzed key
+	Value string
+	// Payload is the fuzzed value before the initial transformation
+	Payload string
+	// ResponseTimeDelay is the response time of the fuzzing request
+	ResponseTimeDelay time.Duration
+	// HttpClient is the client used for sending analysis requests
+	HttpClient *retryablehttp.Client
+	// RateLimitTake is called before sending each analysis request
+	RateLimitTake func()
+	// AnalyzerParameters are the parameters from the analyzer template
+	AnalyzerParameters map[string]interface{}
+}
+
+var (
+	analyzers   = make(map[string]Analyzer)
+	analyzersMu sync.RWMutex
+)
+
+// RegisterAnalyzer registers a new analyzer
+func RegisterAnalyzer(name string, analyzer Analyzer) {
+	analyzersMu.Lock()
+	defer analyzersMu.Unlock()
+	analyzers[name] = analyzer
+}
+
+// GetAnalyzer returns the analyzer for a given name or nil
+func GetAnalyzer(name string) Analyzer {
+	analyzersMu.RLock()
+	defer analyzersMu.RUnlock()
+	return analyzers[name]
+}
@@ -0,0 +1,87 @@
+package timedelay
+
+import (
+	"math"
+)
+
+// timingSample is the response time of a request sent with a delay
+type timingSample struct {
+	delay   float64
+	elapsed float64
+}
+
+// regression is the result of a simple linear regression of the
+// response times on the requested delays
+type regression struct {
+	samples     int
+	slope       float64
+	intercept   float64
+	correlation float64
+}
+
+func newRegression(samples []timingSample) regression {
+	n := float64(len(samples))
+	var sumX, sumY float64
+	for _, sample := range samples {
+		sumX += sample.delay
+		sumY += sample.elapsed
+	}
+	meanX, meanY := sumX/n, sumY/n
+
+	var covariance, varianceX, varianceY float64
+	for _, sample := range samples {
+		dx, dy := sample.delay-meanX, sample.elapsed-meanY
+		covariance += dx * dy
+		varianceX += dx * dx
+		varianceY += dy * dy
+	}
+
+	r := regression{samples: len(samples)}
+	if varianceX == 0 {
+		return r
+	}
+	r.slope = covariance / varianceX
+	r.intercept = meanY - r.slope*meanX
+	if varianceY > 0 {
+		r.correlation = covariance / math.Sqrt(varianceX*varianceY)
+	}
+	return r
+}
+
+// matches returns true if the response times follow the delays,
+// i.e. the slope is close to 1, the correlation is close to 1 and
+// the correlation is statistically significant
+func (r regression) matches(params parameters) bool {
+	if r.samples < 3 {
+		return false
+	}
+	if math.Abs(r.slope-1) > params.timeSlopeErrorRange {
+		return false
+	}
+	if r.correlation < 1-params.timeCorrelationErrorRange {
+		return false
+	}
+	return r.isSignificant()
+}
+
+// criticalTValues are the critical values of the one-tailed student's
+// t-distribution at a significance level of 0.01 by degrees of freedom
+var criticalTValues = []float64{31.821, 6.965, 4.541, 3.747, 3.365, 3.143, 2.998, 2.896, 2.821, 2.764}
+
+// isSignificant tests the null hypothesis that response times are not
+// correlated with the delays using a t-test on the correlation coefficient
+func (r regression) isSignificant() bool {
+	degrees := r.samples - 2
+	if degrees < 1 {
+		return false
+	}
+	if r.correlation >= 1 {
+		return true
+	}
+	t := r.correlation * math.Sqrt(float64(degrees)/(1-r.correlation*r.correlation))
+	critical := criticalTValues[len(criticalTValues)-1]
+	if degrees <= len(criticalTValues) {
+		critical = criticalTValues[degrees-1]
+	}
+	return t > critical
+}
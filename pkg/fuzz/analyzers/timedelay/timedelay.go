@@ -0,0 +1,197 @@
+// Package timedelay implements the time_delay analyzer which detects
+// blind time based injections (ex: SQL injection, command injection)
+// by correlating requested delays with the response times.
+package timedelay
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/projectdiscovery/nuclei/v3/pkg/fuzz/analyzers"
+	"github.com/projectdiscovery/nuclei/v3/pkg/types"
+	"github.com/projectdiscovery/retryablehttp-go"
+)
+
+// Name is the name of the analyzer used in templates
+const Name = "time_delay"
+
+// SleepTimeMarker is replaced in payloads with the delay in seconds
+const SleepTimeMarker = "[SLEEPTIME]"
+
+const (
+	// DefaultSleepDuration is the delay in seconds used for the initial
+	// request and the largest delay used for analysis
+	DefaultSleepDuration = 5
+	// DefaultRequestsLimit is the number of delayed requests sent
+	DefaultRequestsLimit = 4
+	// DefaultTimeSlopeErrorRange is the allowed deviation of the
+	// regression slope from 1 (one second of response time per second of delay)
+	DefaultTimeSlopeErrorRange = 0.30
+	// DefaultTimeCorrelationErrorRange is the allowed deviation of the
+	// correlation coefficient from 1
+	DefaultTimeCorrelationErrorRange = 0.15
+)
+
+func init() {
+	analyzers.RegisterAnalyzer(Name, &Analyzer{})
+}
+
+// Analyzer is a time delay analyzer. The initial fuzzing request is sent
+// with the maximum delay and only when its response took at least as long,
+// requests with different delays are interleaved with baseline requests and
+// a linear regression of the response times on the delays is performed.
+type Analyzer struct{}
+
+var _ analyzers.Analyzer = &Analyzer{}
+
+// Name returns the name of the analyzer
+func (a *Analyzer) Name() string {
+	return Name
+}
+
+// ApplyInitialTransformation replaces the sleep time marker with the
+// configured sleep duration
+func (a *Analyzer) ApplyInitialTransformation(data string, params map[string]interface{}) string {
+	return strings.ReplaceAll(data, SleepTimeMarker, strconv.Itoa(parseParameters(params).sleepDuration))
+}
+
+// parameters are the parsed parameters of the analyzer
+type parameters struct {
+	sleepDuration             int
+	requestsLimit             int
+	timeSlopeErrorRange       float64
+	timeCorrelationErrorRange float64
+}
+
+func parseParameters(params map[string]interface{}) parameters {
+	p := parameters{
+		sleepDuration:             DefaultSleepDuration,
+		requestsLimit:             DefaultRequestsLimit,
+		timeSlopeErrorRange:       DefaultTimeSlopeErrorRange,
+		timeCorrelationErrorRange: DefaultTimeCorrelationErrorRange,
+	}
+	if value, err := strconv.Atoi(types.ToString(params["sleep_duration"])); err == nil && value > 0 {
+		p.sleepDuration = value
+	}
+	if value, err := strconv.Atoi(types.ToString(params["requests_limit"])); err == nil && value > 1 {
+		p.requestsLimit = value
+	}
+	if value, err := strconv.ParseFloat(types.ToString(params["time_slope_error_range"]), 64); err == nil && value > 0 {
+		p.timeSlopeErrorRange = value
+	}
+	if value, err := strconv.ParseFloat(types.ToString(params["time_correlation_error_range"]), 64); err == nil && value > 0 {
+		p.timeCorrelationErrorRange = value
+	}
+	return p
+}
+
+// delays returns the delays to analyze, spread between one second and the
+// sleep duration and interleaved from both ends to reduce the effect of
+// drifting response times
+func (p parameters) delays() []int {
+	sorted := make([]int, p.requestsLimit)
+	for i := range sorted {
+		step := float64(p.sleepDuration-1) / float64(p.requestsLimit-1)
+		sorted[i] = p.sleepDuration - int(math.Round(float64(i)*step))
+	}
+	delays := make([]int, 0, len(sorted))
+	for low, high := len(sorted)-1, 0; high <= low; low, high = low-1, high+1 {
+		delays = append(delays, sorted[high])
+		if high != low {
+			delays = append(delays, sorted[low])
+		}
+	}
+	return delays
+}
+
+// Analyze sends the delayed and baseline requests and reports an issue
+// when the response times are significantly correlated with the delays
+func (a *Analyzer) Analyze(options *analyzers.Options) (bool, string, error) {
+	params := parseParameters(options.AnalyzerParameters)
+	// the initial request was sent with the sleep duration, so it is
+	// only worth analyzing when it took at least as long
+	if options.ResponseTimeDelay < time.Duration(params.sleepDuration)*time.Second {
+		return false, "", nil
+	}
+	// restore the component to its state before the analysis
+	defer func() {
+		_ = options.Component.SetValue(options.Key, a.ApplyInitialTransformation(options.Payload, options.AnalyzerParameters))
+	}()
+
+	var samples []timingSample
+	baseline := func() error {
+		elapsed, err := sendRequest(options, options.BaseRequest.Clone(options.BaseRequest.Context()))
+		if err != nil {
+			return errors.Wrap(err, "could not send baseline request")
+		}
+		samples = append(samples, timingSample{delay: 0, elapsed: elapsed})
+		return nil
+	}
+
+	for _, delay := range params.delays() {
+		if err := baseline(); err != nil {
+			return false, "", err
+		}
+		payload := strings.ReplaceAll(options.Payload, SleepTimeMarker, strconv.Itoa(delay))
+		if err := options.Component.SetValue(options.Key, payload); err != nil {
+			return false, "", errors.Wrap(err, "could not set delayed payload")
+		}
+		req, err := options.Component.Rebuild()
+		if err != nil {
+			return false, "", errors.Wrap(err, "could not rebuild request")
+		}
+		elapsed, err := sendRequest(options, req)
+		if err != nil {
+			return false, "", errors.Wrap(err, "could not send delayed request")
+		}
+		// a response faster than the requested delay means the
+		// payload did not cause the delay
+		if elapsed < float64(delay) {
+			return false, "", nil
+		}
+		samples = append(samples, timingSample{delay: float64(delay), elapsed: elapsed})
+	}
+	if err := baseline(); err != nil {
+		return false, "", err
+	}
+
+	result := newRegression(samples)
+	if !result.matches(params) {
+		return false, "", nil
+	}
+	return true, result.details(samples), nil
+}
+
+// sendRequest sends a request and returns the time taken in seconds
+// until the full response body was read
+func sendRequest(options *analyzers.Options, req *retryablehttp.Request) (float64, error) {
+	if options.RateLimitTake != nil {
+		options.RateLimitTake()
+	}
+	start := time.Now()
+	resp, err := options.HttpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+	return time.Since(start).Seconds(), nil
+}
+
+// details returns the human readable timing data of the analysis
+func (r regression) details(samples []timingSample) string {
+	var builder strings.Builder
+	for i, sample := range samples {
+		if i > 0 {
+			builder.WriteString(", ")
+		}
+		fmt.Fprintf(&builder, "%gs=>%.2fs", sample.delay, sample.elapsed)
+	}
+	return fmt.Sprintf("[%s] made %d requests (delay=>response time: %s) with a regression slope of %.2f, intercept of %.2fs and correlation of %.2f",
+		Name, len(samples), builder.String(), r.slope, r.intercept, r.correlation)
+}
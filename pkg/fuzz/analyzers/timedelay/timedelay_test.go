@@ -0,0 +1,43 @@
+package timedelay
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDelays(t *testing.T) {
+	params := parseParameters(nil)
+	require.Equal(t, []int{5, 1, 4, 2}, params.delays(), "invalid default delays")
+
+	params = parseParameters(map[string]interface{}{"sleep_duration": 10, "requests_limit": "5"})
+	require.Equal(t, []int{10, 1, 8, 3, 5}, params.delays(), "invalid custom delays")
+}
+
+func TestRegression(t *testing.T) {
+	params := parseParameters(nil)
+
+	t.Run("delayed", func(t *testing.T) {
+		samples := []timingSample{
+			{0, 0.21}, {5, 5.23}, {0, 0.19}, {1, 1.22}, {0, 0.2}, {4, 4.18}, {0, 0.22}, {2, 2.25}, {0, 0.2},
+		}
+		result := newRegression(samples)
+		require.InDelta(t, 1, result.slope, 0.05, "invalid slope")
+		require.InDelta(t, 0.2, result.intercept, 0.05, "invalid intercept")
+		require.True(t, result.matches(params), "could not detect correlated delays")
+	})
+	t.Run("slow", func(t *testing.T) {
+		// constantly slow responses are not correlated with the delays
+		samples := []timingSample{
+			{0, 5.4}, {5, 5.3}, {0, 5.1}, {1, 5.6}, {0, 5.2}, {4, 5.1}, {0, 5.5}, {2, 5.3}, {0, 5.2},
+		}
+		require.False(t, newRegression(samples).matches(params), "constant response times should not match")
+	})
+	t.Run("multiplied", func(t *testing.T) {
+		// delays executed multiple times have a slope far from 1
+		samples := []timingSample{
+			{0, 0.2}, {5, 15.2}, {0, 0.2}, {1, 3.2}, {0, 0.2}, {4, 12.2}, {0, 0.2}, {2, 6.2}, {0, 0.2},
+		}
+		require.False(t, newRegression(samples).matches(params), "slope of 3 should not match")
+	})
+}
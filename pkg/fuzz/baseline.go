@@ -0,0 +1,168 @@
+package fuzz
+
+import (
+	"encoding/json"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/projectdiscovery/retryablehttp-go"
+)
+
+// Baseline is the response of the original (unfuzzed) request which is
+// compared with the responses of fuzzing requests to detect differences
+// that are not visible through error strings (ex: boolean based injections).
+//
+// The differences are exposed to matchers and DSL as the following variables:
+//
+//	baseline_status_code    - status code of the baseline response
+//	baseline_content_length - length of the baseline response body
+//	baseline_status_changed - true if the status code differs from the baseline
+//	baseline_length_delta   - body length difference to the baseline
+//	baseline_words_delta    - body word count difference to the baseline
+//	baseline_similarity     - structural similarity (0-1) with the baseline
+type Baseline struct {
+	statusCode int
+	length     int
+	words      int
+	shingles   map[string]struct{}
+}
+
+// shingleSize is the number of consecutive structural tokens
+// compared to calculate the similarity of two responses
+const shingleSize = 3
+
+var (
+	reHTMLTag = regexp.MustCompile(`<\s*(/?)\s*([a-zA-Z][a-zA-Z0-9:-]*)`)
+	reWord    = regexp.MustCompile(`[\p{L}\p{N}_]+`)
+)
+
+// NewBaseline creates a new baseline from a response
+func NewBaseline(statusCode int, body string) *Baseline {
+	return &Baseline{
+		statusCode: statusCode,
+		length:     len(body),
+		words:      len(reWord.FindAllStringIndex(body, -1)),
+		shingles:   structuralShingles(body),
+	}
+}
+
+// CaptureBaseline sends the original request and creates a baseline
+// from its response. maxSize limits the size of the body read (0 for no limit).
+func CaptureBaseline(client *retryablehttp.Client, req *retryablehttp.Request, maxSize int) (*Baseline, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not send baseline request")
+	}
+	defer resp.Body.Close()
+
+	var reader io.Reader = resp.Body
+	if maxSize > 0 {
+		reader = io.LimitReader(resp.Body, int64(maxSize))
+	}
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read baseline response")
+	}
+	return NewBaseline(resp.StatusCode, string(body)), nil
+}
+
+// Compare compares a response with the baseline and returns
+// the differences as DSL variables
+func (b *Baseline) Compare(statusCode int, body string) map[string]interface{} {
+	return map[string]interface{}{
+		"baseline_status_code":    b.statusCode,
+		"baseline_content_length": b.length,
+		"baseline_status_changed": statusCode != b.statusCode,
+		"baseline_length_delta":   len(body) - b.length,
+		"baseline_words_delta":    len(reWord.FindAllStringIndex(body, -1)) - b.words,
+		"baseline_similarity":     jaccard(b.shingles, structuralShingles(body)),
+	}
+}
+
+// structuralTokens returns tokens describing the structure of a body.
+// tags are used for html/xml, key paths with value types for json
+// and lowercased words for anything else.
+func structuralTokens(body string) []string {
+	trimmed := strings.TrimSpace(body)
+	if strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+		var data interface{}
+		if err := json.Unmarshal([]byte(trimmed), &data); err == nil {
+			var tokens []string
+			jsonTokens(data, "", &tokens)
+			return tokens
+		}
+	}
+	if tags := reHTMLTag.FindAllStringSubmatch(body, -1); len(tags) > 0 {
+		tokens := make([]string, 0, len(tags))
+		for _, tag := range tags {
+			tokens = append(tokens, tag[1]+strings.ToLower(tag[2]))
+		}
+		return tokens
+	}
+	words := reWord.FindAllString(body, -1)
+	for i, word := range words {
+		words[i] = strings.ToLower(word)
+	}
+	return words
+}
+
+// jsonTokens appends the key paths and value types of a json value
+func jsonTokens(data interface{}, path string, tokens *[]string) {
+	switch v := data.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			jsonTokens(v[key], path+"."+key, tokens)
+		}
+	case []interface{}:
+		for _, item := range v {
+			jsonTokens(item, path+"[]", tokens)
+		}
+	case string:
+		*tokens = append(*tokens, path+":string")
+	case float64:
+		*tokens = append(*tokens, path+":number")
+	case bool:
+		*tokens = append(*tokens, path+":bool")
+	default:
+		*tokens = append(*tokens, path+":null")
+	}
+}
+
+// structuralShingles returns the set of consecutive structural tokens
+func structuralShingles(body string) map[string]struct{} {
+	tokens := structuralTokens(body)
+	shingles := make(map[string]struct{})
+	if len(tokens) < shingleSize {
+		if len(tokens) > 0 {
+			shingles[strings.Join(tokens, " ")] = struct{}{}
+		}
+		return shingles
+	}
+	for i := 0; i+shingleSize <= len(tokens); i++ {
+		shingles[strings.Join(tokens[i:i+shingleSize], " ")] = struct{}{}
+	}
+	return shingles
+}
+
+// jaccard returns the jaccard similarity of two sets
+func jaccard(a, b map[string]struct{}) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 1
+	}
+	intersection := 0
+	for item := range a {
+		if _, ok := b[item]; ok {
+			intersection++
+		}
+	}
+	union := len(a) + len(b) - intersection
+	return float64(intersection) / float64(union)
+}
@@ -0,0 +1,49 @@
+package fuzz
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBaselineCompare(t *testing.T) {
+	t.Run("identical", func(t *testing.T) {
+		body := "<html><body><h1>Welcome</h1><p>user found</p></body></html>"
+		baseline := NewBaseline(200, body)
+
+		result := baseline.Compare(200, body)
+		require.Equal(t, 200, result["baseline_status_code"])
+		require.Equal(t, len(body), result["baseline_content_length"])
+		require.Equal(t, false, result["baseline_status_changed"])
+		require.Equal(t, 0, result["baseline_length_delta"])
+		require.Equal(t, 0, result["baseline_words_delta"])
+		require.Equal(t, 1.0, result["baseline_similarity"])
+	})
+	t.Run("status-and-length", func(t *testing.T) {
+		baseline := NewBaseline(200, "user admin found")
+
+		result := baseline.Compare(500, "error")
+		require.Equal(t, true, result["baseline_status_changed"])
+		require.Equal(t, -11, result["baseline_length_delta"])
+		require.Equal(t, -2, result["baseline_words_delta"])
+		require.Less(t, result["baseline_similarity"], 0.5)
+	})
+	t.Run("json", func(t *testing.T) {
+		baseline := NewBaseline(200, `{"id":1,"name":"admin","roles":["a","b"]}`)
+
+		result := baseline.Compare(200, `{"id":2,"name":"guest","roles":["c","d"]}`)
+		require.Equal(t, 1.0, result["baseline_similarity"], "same structure should be similar")
+
+		result = baseline.Compare(200, `{"error":"not found"}`)
+		require.Equal(t, 0.0, result["baseline_similarity"], "different structure should not be similar")
+	})
+	t.Run("html", func(t *testing.T) {
+		baseline := NewBaseline(200, "<html><body><table><tr><td>1</td></tr></table></body></html>")
+
+		result := baseline.Compare(200, "<html><body><table><tr><td>2</td></tr></table></body></html>")
+		require.Equal(t, 1.0, result["baseline_similarity"], "same tags should be similar")
+
+		result = baseline.Compare(200, "<html><body><div>no results</div></body></html>")
+		require.Less(t, result["baseline_similarity"], 0.5)
+	})
+}
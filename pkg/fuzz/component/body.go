@@ -67,6 +67,10 @@ func (b *Body) Parse(req *retryablehttp.Request) (bool, error) {
 		return b.parseBody(dataformat.XMLDataFormat, req)
 	case strings.Contains(contentType, "multipart/form-data") && tmp.IsNIL():
 		return b.parseBody(dataformat.MultiPartFormDataFormat, req)
+	case strings.Contains(contentType, "application/graphql") && tmp.IsNIL():
+		return b.parseBody(dataformat.GraphQLDataFormat, req)
+	case isProtobufContentType(contentType):
+		return b.parseBody(dataformat.ProtobufDataFormat, req)
 	}
 	parsed, err := b.parseBody(dataformat.FormDataFormat, req)
 	if err != nil {
@@ -76,6 +80,15 @@ func (b *Body) Parse(req *retryablehttp.Request) (bool, error) {
 	return parsed, err
 }
 
+// isProtobufContentType returns true if the content type is
+// used for protobuf encoded bodies including grpc
+func isProtobufContentType(contentType string) bool {
+	return strings.HasPrefix(contentType, "application/grpc") ||
+		strings.Contains(contentType, "application/x-protobuf") ||
+		strings.Contains(contentType, "application/protobuf") ||
+		strings.Contains(contentType, "application/vnd.google.protobuf")
+}
+
 // parseBody parses a body with a custom decoder
 func (b *Body) parseBody(decoderName string, req *retryablehttp.Request) (bool, error) {
 	decoder := dataformat.Get(decoderName)
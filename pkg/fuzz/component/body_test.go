@@ -79,6 +79,81 @@ func TestBodyXMLComponent(t *testing.T) {
 	require.Equal(t, "<?xml version=\"1.0\" encoding=\"UTF-8\"?><stockCheck><productId>2'6842</productId><storeId>1</storeId></stockCheck>", string(newBody), "unexpected body")
 }
 
+func TestBodyGraphQLComponent(t *testing.T) {
+	var body = `{"query":"mutation { login(username: \"admin\", password: $password) { token } }","variables":{"password":"secret"}}`
+
+	req, err := retryablehttp.NewRequest("POST", "https://example.com/graphql", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	bodyComponent := New(RequestBodyComponent)
+	parsed, err := bodyComponent.Parse(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	require.True(t, parsed, "could not parse body")
+
+	var keys []string
+	_ = bodyComponent.Iterate(func(key string, value interface{}) error {
+		keys = append(keys, key)
+		return nil
+	})
+	require.ElementsMatch(t, []string{"login.username", "password"}, keys, "unexpected keys")
+
+	_ = bodyComponent.SetValue("login.username", "admin'")
+	_ = bodyComponent.SetValue("password", "' OR 1=1--")
+	rebuilt, err := bodyComponent.Rebuild()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newBody, err := io.ReadAll(rebuilt.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	require.Equal(t, `{"query":"mutation { login(username: \"admin'\", password: $password) { token } }","variables":{"password":"' OR 1=1--"}}`, string(newBody), "unexpected body")
+}
+
+func TestBodyProtobufComponent(t *testing.T) {
+	// grpc framed message {1: 1, 2: "admin", 3: {1: "x"}}
+	var body = "\x00\x00\x00\x00\x0e\x08\x01\x12\x05admin\x1a\x03\x0a\x01x"
+
+	req, err := retryablehttp.NewRequest("POST", "https://example.com/playground.UserService/GetUser", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/grpc")
+
+	bodyComponent := New(RequestBodyComponent)
+	parsed, err := bodyComponent.Parse(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	require.True(t, parsed, "could not parse body")
+
+	var keys []string
+	_ = bodyComponent.Iterate(func(key string, value interface{}) error {
+		keys = append(keys, key)
+		return nil
+	})
+	require.ElementsMatch(t, []string{"1", "2", "3~1"}, keys, "unexpected keys")
+
+	_ = bodyComponent.SetValue("2", "admin'")
+	_ = bodyComponent.SetValue("3~1", "x'")
+	rebuilt, err := bodyComponent.Rebuild()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newBody, err := io.ReadAll(rebuilt.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	require.Equal(t, "\x00\x00\x00\x00\x10\x08\x01\x12\x06admin'\x1a\x04\x0a\x02x'", string(newBody), "unexpected body")
+}
+
 func TestBodyFormComponent(t *testing.T) {
 	formData := urlutil.NewOrderedParams()
 	formData.Set("key1", "value1")
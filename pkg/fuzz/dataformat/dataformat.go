@@ -8,6 +8,10 @@ import (
 // dataformats is a list of dataformats
 var dataformats map[string]DataFormat
 
+// dataformatsOrder is the order in which dataformats are checked
+// while decoding, more specific formats are registered first
+var dataformatsOrder []string
+
 const (
 	// DefaultKey is the key i.e used when given
 	// data is not of k-v type
@@ -18,11 +22,15 @@ func init() {
 	dataformats = make(map[string]DataFormat)
 
 	// register the default data formats
+	// graphql must be checked before json as graphql
+	// requests are json encoded as well
+	RegisterDataFormat(NewGraphQL())
 	RegisterDataFormat(NewJSON())
 	RegisterDataFormat(NewXML())
 	RegisterDataFormat(NewRaw())
 	RegisterDataFormat(NewForm())
 	RegisterDataFormat(NewMultiPartForm())
+	RegisterDataFormat(NewProtobuf())
 }
 
 const (
@@ -36,6 +44,10 @@ const (
 	FormDataFormat = "form"
 	// MultiPartFormDataFormat is the name of the MultiPartForm data format
 	MultiPartFormDataFormat = "multipart/form-data"
+	// GraphQLDataFormat is the name of the GraphQL data format
+	GraphQLDataFormat = "graphql"
+	// ProtobufDataFormat is the name of the Protobuf data format
+	ProtobufDataFormat = "protobuf"
 )
 
 // Get returns the dataformat by name
@@ -45,6 +57,9 @@ func Get(name string) DataFormat {
 
 // RegisterEncoder registers an encoder
 func RegisterDataFormat(dataformat DataFormat) {
+	if _, ok := dataformats[dataformat.Name()]; !ok {
+		dataformatsOrder = append(dataformatsOrder, dataformat.Name())
+	}
 	dataformats[dataformat.Name()] = dataformat
 }
 
@@ -70,7 +85,8 @@ type Decoded struct {
 
 // Decode decodes the data from a format
 func Decode(data string) (*Decoded, error) {
-	for _, dataformat := range dataformats {
+	for _, name := range dataformatsOrder {
+		dataformat := dataformats[name]
 		if dataformat.IsType(data) {
 			decoded, err := dataformat.Decode(data)
 			if err != nil {
@@ -60,3 +60,78 @@ func TestDataformatDecodeEncode_XML(t *testing.T) {
 		t.Fatal("unexpected data")
 	}
 }
+
+func TestDataformatDecodeEncode_GraphQL(t *testing.T) {
+	obj := `{"query":"query GetUser($id: ID!) { user(id: $id) { posts(first: 10, filter: {title: \"hello\", tags: [\"a\", \"b\"]}, order: DESC) @include(if: true) { title } } }","operationName":"GetUser","variables":{"id":"1"}}`
+
+	decoded, err := Decode(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded.DataFormat != "graphql" {
+		t.Fatalf("unexpected data format: %s", decoded.DataFormat)
+	}
+	expected := map[string]interface{}{
+		"id":                  "1",
+		"posts.first":         int64(10),
+		"posts.filter.title":  "hello",
+		"posts.filter.tags.0": "a",
+		"posts.filter.tags.1": "b",
+		"posts.order":         "DESC",
+	}
+	for key, value := range expected {
+		if got := decoded.Data.Get(key); got != value {
+			t.Fatalf("unexpected value for %s: %v", key, got)
+		}
+	}
+	if decoded.Data.Get("include.if") != nil {
+		t.Fatal("directive arguments should not be decoded")
+	}
+
+	decoded.Data.Set("id", "2")
+	decoded.Data.Set("posts.filter.title", `x" OR 1=1`)
+	decoded.Data.Set("posts.first", int64(100))
+	encoded, err := Encode(decoded.Data, decoded.DataFormat)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedEncoded := `{"query":"query GetUser($id: ID!) { user(id: $id) { posts(first: 100, filter: {title: \"x\\\" OR 1=1\", tags: [\"a\", \"b\"]}, order: DESC) @include(if: true) { title } } }","operationName":"GetUser","variables":{"id":"2"}}`
+	if encoded != expectedEncoded {
+		t.Fatalf("unexpected data: %s", encoded)
+	}
+}
+
+func TestDataformatDecodeEncode_Protobuf(t *testing.T) {
+	// grpc framed message {1: 1, 2: "admin", 3: {1: "x"}}
+	obj := "\x00\x00\x00\x00\x0e\x08\x01\x12\x05admin\x1a\x03\x0a\x01x"
+
+	protobuf := NewProtobuf()
+	decoded, err := protobuf.Decode(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Get("1") != int64(1) || decoded.Get("2") != "admin" {
+		t.Fatal("unexpected data")
+	}
+	if nested, ok := decoded.Get("3").(map[string]interface{}); !ok || nested["1"] != "x" {
+		t.Fatal("unexpected nested data")
+	}
+
+	encoded, err := protobuf.Encode(decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if encoded != obj {
+		t.Fatalf("unexpected data: %q", encoded)
+	}
+
+	decoded.Set("1", int64(1337))
+	decoded.Set("2", "admin'")
+	encoded, err = protobuf.Encode(decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if encoded != "\x00\x00\x00\x00\x10\x08\xb9\x0a\x12\x06admin'\x1a\x03\x0a\x01x" {
+		t.Fatalf("unexpected data: %q", encoded)
+	}
+}
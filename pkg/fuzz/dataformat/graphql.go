@@ -0,0 +1,327 @@
+package dataformat
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+	"github.com/projectdiscovery/nuclei/v3/pkg/utils/graphql"
+)
+
+// == Handling GraphQL Requests ==
+// GraphQL requests are usually sent as JSON with the query document
+// and its variables. Fuzzing them as plain JSON would only mutate the
+// query string as a whole, so the GraphQL data format instead exposes
+// each variable and each inline literal argument of the query as a key.
+// Inline arguments are keyed by field and argument name (ex: `user.id`),
+// nested input object fields and list items are appended to the key
+// (ex: `users.filter.name`, `users.ids.0`) and repeated keys get a `#N`
+// suffix (ex: `user.id#2`). The query itself along with the operation
+// name and extensions are kept as internal `#_` keys and are not fuzzed.
+
+const (
+	graphqlQueryKey         = "#_graphql_query"
+	graphqlOperationNameKey = "#_graphql_operation_name"
+	graphqlExtensionsKey    = "#_graphql_extensions"
+	graphqlRawKey           = "#_graphql_raw"
+)
+
+// GraphQL is a GraphQL request encoder
+type GraphQL struct{}
+
+var (
+	_ DataFormat = &GraphQL{}
+)
+
+// NewGraphQL returns a new GraphQL encoder
+func NewGraphQL() *GraphQL {
+	return &GraphQL{}
+}
+
+// graphqlRequest is a GraphQL request sent over http
+type graphqlRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+	Extensions    map[string]interface{} `json:"extensions,omitempty"`
+}
+
+// IsType returns true if the data is a JSON encoded GraphQL request
+func (g *GraphQL) IsType(data string) bool {
+	if !strings.HasPrefix(data, "{") || !strings.HasSuffix(data, "}") || !strings.Contains(data, `"query"`) {
+		return false
+	}
+	var request struct {
+		Query *string `json:"query"`
+	}
+	if err := jsoniter.Unmarshal([]byte(data), &request); err != nil || request.Query == nil {
+		return false
+	}
+	return isGraphQLDocument(*request.Query)
+}
+
+// Encode encodes the data into GraphQL request format
+func (g *GraphQL) Encode(data KV) (string, error) {
+	query, _ := data.Get(graphqlQueryKey).(string)
+	arguments, err := parseGraphQLArguments(query)
+	if err != nil {
+		return "", err
+	}
+
+	inlineKeys := make(map[string]struct{}, len(arguments))
+	var sb strings.Builder
+	last := 0
+	for _, argument := range arguments {
+		inlineKeys[argument.key] = struct{}{}
+		sb.WriteString(query[last:argument.token.Start])
+		sb.WriteString(formatGraphQLValue(argument.token, data.Get(argument.key)))
+		last = argument.token.End
+	}
+	sb.WriteString(query[last:])
+	rebuilt := sb.String()
+
+	if raw, _ := data.Get(graphqlRawKey).(bool); raw {
+		return rebuilt, nil
+	}
+
+	request := graphqlRequest{Query: rebuilt}
+	request.OperationName, _ = data.Get(graphqlOperationNameKey).(string)
+	request.Extensions, _ = data.Get(graphqlExtensionsKey).(map[string]interface{})
+	data.Iterate(func(key string, value any) bool {
+		if strings.HasPrefix(key, "#_") {
+			return true
+		}
+		if _, ok := inlineKeys[key]; ok {
+			return true
+		}
+		if request.Variables == nil {
+			request.Variables = make(map[string]interface{})
+		}
+		request.Variables[key] = value
+		return true
+	})
+	encoded, err := jsoniter.Marshal(request)
+	return string(encoded), err
+}
+
+// Decode decodes the data from GraphQL request format. Both JSON
+// encoded requests and raw query documents are supported.
+func (g *GraphQL) Decode(data string) (KV, error) {
+	var request graphqlRequest
+	raw := false
+	if err := jsoniter.Unmarshal([]byte(data), &request); err != nil || request.Query == "" {
+		if !isGraphQLDocument(data) {
+			return KV{}, errors.New("could not decode graphql request")
+		}
+		request = graphqlRequest{Query: data}
+		raw = true
+	}
+	arguments, err := parseGraphQLArguments(request.Query)
+	if err != nil {
+		return KV{}, err
+	}
+
+	decoded := make(map[string]interface{})
+	for key, value := range request.Variables {
+		decoded[key] = value
+	}
+	for _, argument := range arguments {
+		decoded[argument.key] = argument.value()
+	}
+	decoded[graphqlQueryKey] = request.Query
+	if request.OperationName != "" {
+		decoded[graphqlOperationNameKey] = request.OperationName
+	}
+	if len(request.Extensions) > 0 {
+		decoded[graphqlExtensionsKey] = request.Extensions
+	}
+	if raw {
+		decoded[graphqlRawKey] = true
+	}
+	return KVMap(decoded), nil
+}
+
+// Name returns the name of the encoder
+func (g *GraphQL) Name() string {
+	return GraphQLDataFormat
+}
+
+// isGraphQLDocument returns true if the data looks like a graphql
+// executable document i.e a query, mutation or subscription
+func isGraphQLDocument(data string) bool {
+	tokens, err := graphql.Lex(data)
+	if err != nil || len(tokens) == 0 {
+		return false
+	}
+	first := tokens[0]
+	return first.Is("{") || first.Is("query") || first.Is("mutation") || first.Is("subscription") || first.Is("fragment")
+}
+
+// graphqlArgument is an inline literal argument value in a query
+type graphqlArgument struct {
+	key   string
+	token graphql.Token
+}
+
+// value returns the go value of the literal argument
+func (a graphqlArgument) value() interface{} {
+	switch a.token.Kind {
+	case graphql.Int:
+		if value, err := strconv.ParseInt(a.token.Value, 10, 64); err == nil {
+			return value
+		}
+	case graphql.Float:
+		if value, err := strconv.ParseFloat(a.token.Value, 64); err == nil {
+			return value
+		}
+	case graphql.Name:
+		if a.token.Value == "true" || a.token.Value == "false" {
+			return a.token.Value == "true"
+		}
+	}
+	return a.token.Value
+}
+
+// formatGraphQLValue formats a value as graphql literal of the same
+// kind as the original token
+func formatGraphQLValue(token graphql.Token, value interface{}) string {
+	if value == nil {
+		return token.Value
+	}
+	switch token.Kind {
+	case graphql.String, graphql.BlockString:
+		encoded, _ := jsoniter.MarshalToString(fmt.Sprint(value))
+		return encoded
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// graphqlArgumentParser collects inline literal arguments of a query
+type graphqlArgumentParser struct {
+	tokens    []graphql.Token
+	seen      map[string]int
+	arguments []graphqlArgument
+}
+
+// parseGraphQLArguments returns the inline literal arguments of
+// fields in the query in the order they appear in the document.
+//
+// Variable definitions and directive arguments are not included.
+func parseGraphQLArguments(query string) ([]graphqlArgument, error) {
+	tokens, err := graphql.Lex(query)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not lex graphql query")
+	}
+	p := &graphqlArgumentParser{tokens: tokens, seen: make(map[string]int)}
+
+	depth := 0
+	for i := 0; i < len(tokens); i++ {
+		switch {
+		case tokens[i].Is("{"):
+			depth++
+		case tokens[i].Is("}"):
+			depth--
+		case tokens[i].Is("("):
+			if depth == 0 || i < 2 || tokens[i-1].Kind != graphql.Name || tokens[i-2].Is("@") {
+				if i, err = p.skipParens(i); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			if i, err = p.parseArguments(i, tokens[i-1].Value); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return p.arguments, nil
+}
+
+// skipParens returns the index of the closing parenthesis
+func (p *graphqlArgumentParser) skipParens(i int) (int, error) {
+	depth := 0
+	for ; i < len(p.tokens); i++ {
+		if p.tokens[i].Is("(") {
+			depth++
+		} else if p.tokens[i].Is(")") {
+			depth--
+			if depth == 0 {
+				return i, nil
+			}
+		}
+	}
+	return 0, errors.New("unterminated arguments in graphql query")
+}
+
+// parseArguments parses the arguments starting at i and returns
+// the index of the closing parenthesis
+func (p *graphqlArgumentParser) parseArguments(i int, field string) (int, error) {
+	for i++; i < len(p.tokens) && !p.tokens[i].Is(")"); i++ {
+		if p.tokens[i].Kind != graphql.Name || i+2 >= len(p.tokens) || !p.tokens[i+1].Is(":") {
+			return 0, fmt.Errorf("invalid argument in graphql query at %d", p.tokens[i].Start)
+		}
+		var err error
+		if i, err = p.parseValue(i+2, field+"."+p.tokens[i].Value); err != nil {
+			return 0, err
+		}
+	}
+	if i >= len(p.tokens) {
+		return 0, errors.New("unterminated arguments in graphql query")
+	}
+	return i, nil
+}
+
+// parseValue parses the value starting at i and returns the index
+// of the last token of the value
+func (p *graphqlArgumentParser) parseValue(i int, path string) (int, error) {
+	if i >= len(p.tokens) {
+		return 0, errors.New("unexpected end of graphql query")
+	}
+	token := p.tokens[i]
+	switch {
+	case token.Is("$"):
+		// variables are fuzzed from the variables object
+		return i + 1, nil
+	case token.Is("["):
+		index := 0
+		for i++; i < len(p.tokens) && !p.tokens[i].Is("]"); i++ {
+			var err error
+			if i, err = p.parseValue(i, path+"."+strconv.Itoa(index)); err != nil {
+				return 0, err
+			}
+			index++
+		}
+		if i >= len(p.tokens) {
+			return 0, errors.New("unterminated list in graphql query")
+		}
+		return i, nil
+	case token.Is("{"):
+		for i++; i < len(p.tokens) && !p.tokens[i].Is("}"); i++ {
+			if p.tokens[i].Kind != graphql.Name || i+2 >= len(p.tokens) || !p.tokens[i+1].Is(":") {
+				return 0, fmt.Errorf("invalid object field in graphql query at %d", p.tokens[i].Start)
+			}
+			var err error
+			if i, err = p.parseValue(i+2, path+"."+p.tokens[i].Value); err != nil {
+				return 0, err
+			}
+		}
+		if i >= len(p.tokens) {
+			return 0, errors.New("unterminated object in graphql query")
+		}
+		return i, nil
+	case token.Kind == graphql.Punctuator:
+		return 0, fmt.Errorf("unexpected %s in graphql query at %d", token.Value, token.Start)
+	case token.Is("null"):
+		return i, nil
+	}
+
+	key := path
+	if count := p.seen[path]; count > 0 {
+		key = fmt.Sprintf("%s#%d", path, count+1)
+	}
+	p.seen[path]++
+	p.arguments = append(p.arguments, graphqlArgument{key: key, token: token})
+	return i, nil
+}
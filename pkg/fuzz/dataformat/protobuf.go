@@ -0,0 +1,309 @@
+package dataformat
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// == Handling Protobuf Messages ==
+// Protobuf messages are decoded without a schema, so fields are keyed by
+// their field numbers and nested messages are decoded as nested maps
+// (ex: `2~1` for field 1 of the message in field 2). Since the wire format
+// does not describe types, length-delimited fields are decoded as strings
+// when they are printable, as nested messages when they parse as one and
+// as raw bytes otherwise. The wire type of every field is kept in the
+// internal `#_protobuf_types` key so that the message can be re-encoded
+// after mutation. gRPC messages with the 5 byte length prefix are
+// supported as well and the prefix is rebuilt on encoding.
+
+const (
+	protobufTypesKey     = "#_protobuf_types"
+	protobufGRPCFrameKey = "#_protobuf_grpc_frame"
+
+	// maxProtobufDepth is the maximum depth of decoded nested messages
+	maxProtobufDepth = 10
+)
+
+const (
+	protobufVarint  = "varint"
+	protobufFixed32 = "fixed32"
+	protobufFixed64 = "fixed64"
+	protobufString  = "string"
+	protobufBytes   = "bytes"
+	protobufMessage = "message"
+)
+
+// Protobuf is a schemaless protobuf encoder
+type Protobuf struct{}
+
+var (
+	_ DataFormat = &Protobuf{}
+)
+
+// NewProtobuf returns a new Protobuf encoder
+func NewProtobuf() *Protobuf {
+	return &Protobuf{}
+}
+
+// IsType returns true if the data is Protobuf encoded
+func (p *Protobuf) IsType(data string) bool {
+	// protobuf is a binary format without any markers, so it is
+	// only used when the content type of the request is protobuf
+	return false
+}
+
+// Encode encodes the data into Protobuf format
+func (p *Protobuf) Encode(data KV) (string, error) {
+	fields := make(map[string]interface{})
+	data.Iterate(func(key string, value any) bool {
+		fields[key] = value
+		return true
+	})
+	types, _ := fields[protobufTypesKey].(map[string]interface{})
+
+	encoded, err := encodeProtobufMessage(fields, types, "")
+	if err != nil {
+		return "", err
+	}
+	if framed, _ := fields[protobufGRPCFrameKey].(bool); framed {
+		prefix := make([]byte, 5)
+		binary.BigEndian.PutUint32(prefix[1:], uint32(len(encoded)))
+		encoded = append(prefix, encoded...)
+	}
+	return string(encoded), nil
+}
+
+// Decode decodes the data from Protobuf format
+func (p *Protobuf) Decode(data string) (KV, error) {
+	bin := []byte(data)
+	framed := false
+	if len(bin) >= 5 && (bin[0] == 0 || bin[0] == 1) && int(binary.BigEndian.Uint32(bin[1:5])) == len(bin)-5 {
+		if bin[0] == 1 {
+			return KV{}, errors.New("compressed grpc messages are not supported")
+		}
+		bin = bin[5:]
+		framed = true
+	}
+
+	types := make(map[string]interface{})
+	decoded, err := decodeProtobufMessage(bin, types, "", 0)
+	if err != nil {
+		return KV{}, errors.Wrap(err, "could not decode protobuf")
+	}
+	decoded[protobufTypesKey] = types
+	if framed {
+		decoded[protobufGRPCFrameKey] = true
+	}
+	return KVMap(decoded), nil
+}
+
+// Name returns the name of the encoder
+func (p *Protobuf) Name() string {
+	return ProtobufDataFormat
+}
+
+// decodeProtobufMessage decodes a message into a map keyed by field
+// numbers and records the wire type of each field in types
+func decodeProtobufMessage(bin []byte, types map[string]interface{}, prefix string, depth int) (map[string]interface{}, error) {
+	message := make(map[string]interface{})
+	for len(bin) > 0 {
+		number, wireType, n := protowire.ConsumeTag(bin)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		bin = bin[n:]
+
+		var value interface{}
+		var fieldType string
+		switch wireType {
+		case protowire.VarintType:
+			v, n := protowire.ConsumeVarint(bin)
+			if n < 0 {
+				return nil, protowire.ParseError(n)
+			}
+			bin = bin[n:]
+			value, fieldType = int64(v), protobufVarint
+		case protowire.Fixed32Type:
+			v, n := protowire.ConsumeFixed32(bin)
+			if n < 0 {
+				return nil, protowire.ParseError(n)
+			}
+			bin = bin[n:]
+			value, fieldType = int64(v), protobufFixed32
+		case protowire.Fixed64Type:
+			v, n := protowire.ConsumeFixed64(bin)
+			if n < 0 {
+				return nil, protowire.ParseError(n)
+			}
+			bin = bin[n:]
+			value, fieldType = int64(v), protobufFixed64
+		case protowire.BytesType:
+			v, n := protowire.ConsumeBytes(bin)
+			if n < 0 {
+				return nil, protowire.ParseError(n)
+			}
+			bin = bin[n:]
+			value, fieldType = decodeProtobufBytes(v, types, protobufPath(prefix, number), depth)
+		default:
+			return nil, fmt.Errorf("unsupported wire type %d for field %d", wireType, number)
+		}
+
+		key := strconv.Itoa(int(number))
+		types[protobufPath(prefix, number)] = fieldType
+		// repeated fields are decoded as a list of values
+		if existing, ok := message[key]; ok {
+			if list, ok := existing.([]interface{}); ok {
+				message[key] = append(list, value)
+			} else {
+				message[key] = []interface{}{existing, value}
+			}
+			continue
+		}
+		message[key] = value
+	}
+	return message, nil
+}
+
+// decodeProtobufBytes guesses the type of a length-delimited field
+func decodeProtobufBytes(bin []byte, types map[string]interface{}, path string, depth int) (interface{}, string) {
+	if isPrintable(bin) {
+		return string(bin), protobufString
+	}
+	if depth < maxProtobufDepth && len(bin) > 0 {
+		nestedTypes := make(map[string]interface{})
+		if nested, err := decodeProtobufMessage(bin, nestedTypes, path, depth+1); err == nil {
+			for k, v := range nestedTypes {
+				types[k] = v
+			}
+			return nested, protobufMessage
+		}
+	}
+	if utf8.Valid(bin) {
+		return string(bin), protobufString
+	}
+	return string(bin), protobufBytes
+}
+
+// encodeProtobufMessage encodes a map keyed by field numbers using
+// the wire types recorded while decoding
+func encodeProtobufMessage(message map[string]interface{}, types map[string]interface{}, prefix string) ([]byte, error) {
+	numbers := make([]int, 0, len(message))
+	for key := range message {
+		if strings.HasPrefix(key, "#_") {
+			continue
+		}
+		number, err := strconv.Atoi(key)
+		if err != nil || number <= 0 {
+			continue
+		}
+		numbers = append(numbers, number)
+	}
+	sort.Ints(numbers)
+
+	var bin []byte
+	for _, number := range numbers {
+		fieldNumber := protowire.Number(number)
+		path := protobufPath(prefix, fieldNumber)
+		fieldType, _ := types[path].(string)
+
+		values, ok := message[strconv.Itoa(number)].([]interface{})
+		if !ok {
+			values = []interface{}{message[strconv.Itoa(number)]}
+		}
+		for _, value := range values {
+			var err error
+			if bin, err = appendProtobufField(bin, fieldNumber, fieldType, value, types, path); err != nil {
+				return nil, errors.Wrapf(err, "could not encode field %s", path)
+			}
+		}
+	}
+	return bin, nil
+}
+
+// appendProtobufField appends a single field value to the message
+func appendProtobufField(bin []byte, number protowire.Number, fieldType string, value interface{}, types map[string]interface{}, path string) ([]byte, error) {
+	if nested, ok := value.(map[string]interface{}); ok {
+		encoded, err := encodeProtobufMessage(nested, types, path)
+		if err != nil {
+			return nil, err
+		}
+		bin = protowire.AppendTag(bin, number, protowire.BytesType)
+		return protowire.AppendBytes(bin, encoded), nil
+	}
+
+	switch fieldType {
+	case protobufVarint, protobufFixed32, protobufFixed64:
+		number64, err := toProtobufInt(value)
+		if err != nil {
+			return nil, err
+		}
+		switch fieldType {
+		case protobufVarint:
+			bin = protowire.AppendTag(bin, number, protowire.VarintType)
+			bin = protowire.AppendVarint(bin, uint64(number64))
+		case protobufFixed32:
+			bin = protowire.AppendTag(bin, number, protowire.Fixed32Type)
+			bin = protowire.AppendFixed32(bin, uint32(number64))
+		case protobufFixed64:
+			bin = protowire.AppendTag(bin, number, protowire.Fixed64Type)
+			bin = protowire.AppendFixed64(bin, uint64(number64))
+		}
+	default:
+		bin = protowire.AppendTag(bin, number, protowire.BytesType)
+		bin = protowire.AppendString(bin, fmt.Sprint(value))
+	}
+	return bin, nil
+}
+
+// toProtobufInt converts a decoded or mutated value to an integer
+func toProtobufInt(value interface{}) (int64, error) {
+	switch v := value.(type) {
+	case int64:
+		return v, nil
+	case int:
+		return int64(v), nil
+	case float64:
+		if v == math.Trunc(v) {
+			return int64(v), nil
+		}
+		return int64(math.Float64bits(v)), nil
+	case bool:
+		if v {
+			return 1, nil
+		}
+		return 0, nil
+	case string:
+		return strconv.ParseInt(v, 10, 64)
+	}
+	return 0, fmt.Errorf("invalid numeric value %v", value)
+}
+
+// protobufPath returns the path of a field used as key in types
+func protobufPath(prefix string, number protowire.Number) string {
+	if prefix == "" {
+		return strconv.Itoa(int(number))
+	}
+	return prefix + "." + strconv.Itoa(int(number))
+}
+
+// isPrintable returns true if the data is a non-empty printable string
+func isPrintable(bin []byte) bool {
+	if len(bin) == 0 || !utf8.Valid(bin) {
+		return len(bin) == 0
+	}
+	for _, r := range string(bin) {
+		if !unicode.IsPrint(r) {
+			return false
+		}
+	}
+	return true
+}
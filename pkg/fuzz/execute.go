@@ -9,6 +9,8 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/projectdiscovery/gologger"
+	"github.com/projectdiscovery/nuclei/v3/pkg/fuzz/analyzers"
+	_ "github.com/projectdiscovery/nuclei/v3/pkg/fuzz/analyzers/timedelay"
 	"github.com/projectdiscovery/nuclei/v3/pkg/fuzz/component"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/contextargs"
@@ -50,6 +52,9 @@ type ExecuteRuleInput struct {
 	BaseRequest *retryablehttp.Request
 	// DisplayFuzzPoints is a flag to display fuzz points
 	DisplayFuzzPoints bool
+	// Baseline is the response of the base request used for
+	// differential comparison if required by the template
+	Baseline *Baseline
 }
 
 // GeneratedRequest is a single generated request for rule
@@ -64,6 +69,18 @@ type GeneratedRequest struct {
 	Component component.Component
 	// Parameter being fuzzed
 	Parameter string
+	// Key is the key of the component being fuzzed
+	Key string
+	// OriginalValue is the value of the key before fuzzing
+	OriginalValue string
+	// OriginalPayload is the fuzzed value before the analyzer transformation
+	OriginalPayload string
+	// BaseRequest is the base http request used as baseline by analyzers
+	BaseRequest *retryablehttp.Request
+	// Analyzer is the analyzer of the rule if any
+	Analyzer *analyzers.AnalyzerTemplate
+	// Baseline is the response of the base request if captured
+	Baseline *Baseline
 }
 
 // Execute executes a fuzzing rule accepting a callback on which
@@ -257,7 +274,7 @@ func (rule *Rule) executeRuleValues(input *ExecuteRuleInput, ruleComponent compo
 			if err != nil {
 				return err
 			}
-			if gotErr := rule.execWithInput(input, req, input.InteractURLs, ruleComponent, "", ""); gotErr != nil {
+			if gotErr := rule.execWithInput(input, req, input.InteractURLs, ruleComponent, "", "", ""); gotErr != nil {
 				return gotErr
 			}
 		}
@@ -329,6 +346,15 @@ func (rule *Rule) Compile(generator *generators.PayloadGenerator, options *proto
 		}
 		rule.keysRegex = append(rule.keysRegex, compiled)
 	}
+	if rule.Analyzer != nil {
+		rule.analyzer = analyzers.GetAnalyzer(rule.Analyzer.Name)
+		if rule.analyzer == nil {
+			return errors.Errorf("invalid analyzer specified: %s", rule.Analyzer.Name)
+		}
+		if rule.modeType != singleModeType {
+			return errors.Errorf("analyzers are only supported with single mode")
+		}
+	}
 	if rule.ruleType != replaceRegexRuleType {
 		if rule.ReplaceRegex != "" {
 			return errors.Errorf("replace-regex is only applicable for replace and replace-regex rule types")
@@ -4,6 +4,7 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/projectdiscovery/nuclei/v3/pkg/fuzz/analyzers"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/generators"
 )
@@ -98,8 +99,16 @@ type Rule struct {
 	//     replace-regex: "https?://.*"
 	ReplaceRegex string         `yaml:"replace-regex,omitempty" json:"replace-regex,omitempty" jsonschema:"title=replace regex of rule,description=Regex for regex-replace rule type"`
 	replaceRegex *regexp.Regexp `yaml:"-" json:"-"`
-	options      *protocols.ExecutorOptions
-	generator    *generators.PayloadGenerator
+	// description: |
+	//   Analyzer is the optional analyzer run on the responses of the
+	//   generated requests to detect blind issues (ex: time based injections).
+	//
+	//   Analyzers are only supported with single mode. The time_delay analyzer
+	//   replaces the [SLEEPTIME] marker in payloads with the delay in seconds.
+	Analyzer  *analyzers.AnalyzerTemplate `yaml:"analyzer,omitempty" json:"analyzer,omitempty" jsonschema:"title=analyzer of rule,description=Analyzer to run on responses of fuzzing requests"`
+	analyzer  analyzers.Analyzer
+	options   *protocols.ExecutorOptions
+	generator *generators.PayloadGenerator
 }
 
 // ruleType is the type of rule enum declaration
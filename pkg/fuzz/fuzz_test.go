@@ -3,6 +3,7 @@ package fuzz
 import (
 	"testing"
 
+	"github.com/projectdiscovery/nuclei/v3/pkg/fuzz/analyzers"
 	"github.com/stretchr/testify/require"
 )
 
@@ -37,3 +38,18 @@ func TestRuleMatchKeyOrValue(t *testing.T) {
 		require.False(t, result, "could not get correct result")
 	})
 }
+
+func TestRuleCompileAnalyzer(t *testing.T) {
+	rule := &Rule{Part: "query", Mode: "single", Analyzer: &analyzers.AnalyzerTemplate{Name: "time_delay"}}
+	err := rule.Compile(nil, nil)
+	require.NoError(t, err, "could not compile rule")
+	require.Equal(t, "sleep(5)", rule.applyAnalyzerTransformation("sleep([SLEEPTIME])"), "could not apply initial transformation")
+
+	rule = &Rule{Part: "query", Analyzer: &analyzers.AnalyzerTemplate{Name: "time_delay"}}
+	err = rule.Compile(nil, nil)
+	require.Error(t, err, "analyzer should not be supported with multiple mode")
+
+	rule = &Rule{Part: "query", Mode: "single", Analyzer: &analyzers.AnalyzerTemplate{Name: "invalid"}}
+	err = rule.Compile(nil, nil)
+	require.Error(t, err, "invalid analyzer should return an error")
+}
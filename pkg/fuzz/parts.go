@@ -59,6 +59,8 @@ func (rule *Rule) executePartComponentOnValues(input *ExecuteRuleInput, payloadS
 
 		var evaluated string
 		evaluated, input.InteractURLs = rule.executeEvaluate(input, key, valueStr, payloadStr, input.InteractURLs)
+		payload := evaluated
+		evaluated = rule.applyAnalyzerTransformation(evaluated)
 		if err := ruleComponent.SetValue(key, evaluated); err != nil {
 			// gologger.Warning().Msgf("could not set value due to format restriction original(%s, %s[%T]) , new(%s,%s[%T])", key, valueStr, value, key, evaluated, evaluated)
 			return nil
@@ -70,7 +72,7 @@ func (rule *Rule) executePartComponentOnValues(input *ExecuteRuleInput, payloadS
 				return err
 			}
 
-			if qerr := rule.execWithInput(input, req, input.InteractURLs, ruleComponent, key, valueStr); qerr != nil {
+			if qerr := rule.execWithInput(input, req, input.InteractURLs, ruleComponent, key, valueStr, payload); qerr != nil {
 				return qerr
 			}
 			// fmt.Printf("executed with value: %s\n", evaluated)
@@ -92,7 +94,7 @@ func (rule *Rule) executePartComponentOnValues(input *ExecuteRuleInput, payloadS
 		if err != nil {
 			return err
 		}
-		if qerr := rule.execWithInput(input, req, input.InteractURLs, ruleComponent, "", ""); qerr != nil {
+		if qerr := rule.execWithInput(input, req, input.InteractURLs, ruleComponent, "", "", ""); qerr != nil {
 			err = qerr
 			return err
 		}
@@ -118,6 +120,8 @@ func (rule *Rule) executePartComponentOnKV(input *ExecuteRuleInput, payload Valu
 	return func(key, value string) error {
 		var evaluated string
 		evaluated, input.InteractURLs = rule.executeEvaluate(input, key, "", value, input.InteractURLs)
+		payload := evaluated
+		evaluated = rule.applyAnalyzerTransformation(evaluated)
 		if err := ruleComponent.SetValue(key, evaluated); err != nil {
 			return err
 		}
@@ -127,7 +131,7 @@ func (rule *Rule) executePartComponentOnKV(input *ExecuteRuleInput, payload Valu
 				return err
 			}
 
-			if qerr := rule.execWithInput(input, req, input.InteractURLs, ruleComponent, key, value); qerr != nil {
+			if qerr := rule.execWithInput(input, req, input.InteractURLs, ruleComponent, key, value, payload); qerr != nil {
 				return err
 			}
 
@@ -146,7 +150,8 @@ func (rule *Rule) executePartComponentOnKV(input *ExecuteRuleInput, payload Valu
 }
 
 // execWithInput executes a rule with input via callback
-func (rule *Rule) execWithInput(input *ExecuteRuleInput, httpReq *retryablehttp.Request, interactURLs []string, component component.Component, parameter, parameterValue string) error {
+func (rule *Rule) execWithInput(input *ExecuteRuleInput, httpReq *retryablehttp.Request, interactURLs []string, component component.Component, parameter, parameterValue, payload string) error {
+	key := parameter
 	// If the parameter is a number, replace it with the parameter value
 	// or if the parameter is empty and the parameter value is not empty
 	// replace it with the parameter value
@@ -169,6 +174,14 @@ func (rule *Rule) execWithInput(input *ExecuteRuleInput, httpReq *retryablehttp.
 		DynamicValues: input.Values,
 		Component:     component,
 		Parameter:     parameter,
+		Baseline:      input.Baseline,
+	}
+	if rule.Analyzer != nil {
+		request.Key = key
+		request.OriginalValue = parameterValue
+		request.OriginalPayload = payload
+		request.BaseRequest = input.BaseRequest
+		request.Analyzer = rule.Analyzer
 	}
 	if !input.Callback(request) {
 		return types.ErrNoMoreRequests
@@ -176,6 +189,15 @@ func (rule *Rule) execWithInput(input *ExecuteRuleInput, httpReq *retryablehttp.
 	return nil
 }
 
+// applyAnalyzerTransformation applies the initial transformation of the
+// rule analyzer (if any) on an evaluated payload
+func (rule *Rule) applyAnalyzerTransformation(payload string) string {
+	if rule.analyzer == nil {
+		return payload
+	}
+	return rule.analyzer.ApplyInitialTransformation(payload, rule.Analyzer.Parameters)
+}
+
 // executeEvaluate executes evaluation of payload on a key and value and
 // returns completed values to be replaced and processed
 // for fuzzing.
@@ -0,0 +1,223 @@
+package graphql
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httputil"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/projectdiscovery/nuclei/v3/pkg/input/formats"
+	httpTypes "github.com/projectdiscovery/nuclei/v3/pkg/input/types"
+)
+
+const (
+	// maxSelectionDepth is the maximum depth of nested object
+	// fields selected in generated operations
+	maxSelectionDepth = 2
+	// maxInputDepth is the maximum depth of nested input
+	// objects generated for variables
+	maxInputDepth = 3
+)
+
+// generator generates graphql operations from a schema
+type generator struct {
+	schema *schema
+	opts   formats.InputFormatOptions
+}
+
+// generateRequests generates a request for every query and
+// mutation field of the schema
+func generateRequests(s *schema, endpoint string, opts formats.InputFormatOptions, callback formats.ParseReqRespCallback) error {
+	g := &generator{schema: s, opts: opts}
+
+	operations := []struct {
+		keyword  string
+		typeName string
+	}{
+		{keyword: "query", typeName: s.queryType},
+		{keyword: "mutation", typeName: s.mutationType},
+	}
+	for _, operation := range operations {
+		root, ok := s.types[operation.typeName]
+		if operation.typeName == "" || !ok {
+			continue
+		}
+		for _, f := range root.Fields {
+			body, err := json.Marshal(g.operation(operation.keyword, f))
+			if err != nil {
+				return errors.Wrap(err, "could not marshal graphql request")
+			}
+			req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(body))
+			if err != nil {
+				return errors.Wrap(err, "could not create request")
+			}
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Accept", "application/json")
+
+			dumped, err := httputil.DumpRequestOut(req, true)
+			if err != nil {
+				return errors.Wrap(err, "could not dump request")
+			}
+			rr, err := httpTypes.ParseRawRequestWithURL(string(dumped), req.URL.String())
+			if err != nil {
+				return errors.Wrap(err, "could not parse raw request")
+			}
+			callback(rr)
+		}
+	}
+	return nil
+}
+
+// graphqlRequest is the json body of a graphql request
+type graphqlRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+// operation generates an operation for the root field passing
+// all arguments as variables with placeholder values
+func (g *generator) operation(keyword string, f *field) *graphqlRequest {
+	request := &graphqlRequest{OperationName: f.Name}
+
+	var definitions, arguments []string
+	for _, arg := range f.Args {
+		if g.opts.RequiredOnly && !arg.isRequired() {
+			continue
+		}
+		definitions = append(definitions, fmt.Sprintf("$%s: %s", arg.Name, arg.Type.String()))
+		arguments = append(arguments, fmt.Sprintf("%s: $%s", arg.Name, arg.Name))
+
+		if request.Variables == nil {
+			request.Variables = make(map[string]interface{})
+		}
+		if value, ok := g.opts.Variables[arg.Name]; ok {
+			request.Variables[arg.Name] = value
+		} else {
+			request.Variables[arg.Name] = g.example(arg.Type, 0)
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(keyword)
+	sb.WriteString(" ")
+	sb.WriteString(f.Name)
+	if len(definitions) > 0 {
+		sb.WriteString("(" + strings.Join(definitions, ", ") + ")")
+	}
+	sb.WriteString(" { ")
+	sb.WriteString(f.Name)
+	if len(arguments) > 0 {
+		sb.WriteString("(" + strings.Join(arguments, ", ") + ")")
+	}
+	if selection := g.selection(f.Type.named(), 0); selection != "" {
+		sb.WriteString(" " + selection)
+	}
+	sb.WriteString(" }")
+	request.Query = sb.String()
+	return request
+}
+
+// selection returns the selection set for a type selecting all
+// leaf fields and nested objects up to maxSelectionDepth
+func (g *generator) selection(typeName string, depth int) string {
+	t, ok := g.schema.types[typeName]
+	if !ok {
+		return ""
+	}
+	switch t.Kind {
+	case "OBJECT", "INTERFACE":
+	case "UNION":
+		return "{ __typename }"
+	default:
+		return ""
+	}
+
+	var fields []string
+	for _, f := range t.Fields {
+		if hasRequiredArgs(f) {
+			continue
+		}
+		fieldType, ok := g.schema.types[f.Type.named()]
+		if !ok {
+			continue
+		}
+		switch fieldType.Kind {
+		case "SCALAR", "ENUM":
+			fields = append(fields, f.Name)
+		default:
+			if depth >= maxSelectionDepth {
+				continue
+			}
+			if nested := g.selection(fieldType.Name, depth+1); nested != "" {
+				fields = append(fields, f.Name+" "+nested)
+			}
+		}
+	}
+	if len(fields) == 0 {
+		fields = append(fields, "__typename")
+	}
+	return "{ " + strings.Join(fields, " ") + " }"
+}
+
+// example returns a placeholder value for the type
+func (g *generator) example(t *typeRef, depth int) interface{} {
+	if t == nil {
+		return nil
+	}
+	switch t.Kind {
+	case "NON_NULL":
+		return g.example(t.OfType, depth)
+	case "LIST":
+		return []interface{}{g.example(t.OfType, depth)}
+	}
+
+	named, ok := g.schema.types[t.Name]
+	if !ok {
+		return "string"
+	}
+	switch named.Kind {
+	case "ENUM":
+		if len(named.EnumValues) > 0 {
+			return named.EnumValues[0].Name
+		}
+		return ""
+	case "INPUT_OBJECT":
+		object := make(map[string]interface{})
+		if depth >= maxInputDepth {
+			return object
+		}
+		for _, inputField := range named.InputFields {
+			if g.opts.RequiredOnly && !inputField.isRequired() {
+				continue
+			}
+			object[inputField.Name] = g.example(inputField.Type, depth+1)
+		}
+		return object
+	}
+
+	switch named.Name {
+	case "Int":
+		return 1
+	case "Float":
+		return 1.1
+	case "Boolean":
+		return true
+	case "ID":
+		return "1"
+	}
+	return "string"
+}
+
+// hasRequiredArgs returns true if the field has any required argument
+func hasRequiredArgs(f *field) bool {
+	for _, arg := range f.Args {
+		if arg.isRequired() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+package graphql
+
+import (
+	"bytes"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/projectdiscovery/gologger"
+	"github.com/projectdiscovery/nuclei/v3/pkg/input/formats"
+	"github.com/projectdiscovery/nuclei/v3/pkg/types"
+)
+
+// EndpointVariable is the variable containing the url of the
+// graphql endpoint requests are generated for
+const EndpointVariable = "graphql_endpoint"
+
+// GraphQLFormat is a GraphQL Schema (SDL or introspection result) File parser
+type GraphQLFormat struct {
+	opts formats.InputFormatOptions
+}
+
+// New creates a new GraphQL format parser
+func New() *GraphQLFormat {
+	return &GraphQLFormat{}
+}
+
+var _ formats.Format = &GraphQLFormat{}
+
+// Name returns the name of the format
+func (j *GraphQLFormat) Name() string {
+	return "graphql"
+}
+
+func (j *GraphQLFormat) SetOptions(options formats.InputFormatOptions) {
+	j.opts = options
+}
+
+// Parse parses the input and calls the provided callback
+// function for each RawRequest it discovers.
+func (j *GraphQLFormat) Parse(input string, resultsCb formats.ParseReqRespCallback) error {
+	data, err := os.ReadFile(input)
+	if err != nil {
+		return errors.Wrap(err, "could not read graphql schema file")
+	}
+
+	var s *schema
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		s, err = parseIntrospection(data)
+	} else {
+		s, err = parseSDL(string(data))
+	}
+	if err != nil {
+		return errors.Wrap(err, "could not parse graphql schema")
+	}
+
+	endpoint := types.ToString(j.opts.Variables[EndpointVariable])
+	if endpoint == "" {
+		if j.opts.SkipFormatValidation {
+			gologger.Verbose().Msgf("graphql: skipping all requests due to missing %s variable\n", EndpointVariable)
+			return nil
+		}
+		return errors.Errorf("no graphql endpoint found, use -var %s=https://example.com/graphql to specify it", EndpointVariable)
+	}
+	return generateRequests(s, endpoint, j.opts, resultsCb)
+}
@@ -0,0 +1,83 @@
+package graphql
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/projectdiscovery/nuclei/v3/pkg/input/formats"
+	"github.com/projectdiscovery/nuclei/v3/pkg/input/types"
+	"github.com/stretchr/testify/require"
+)
+
+const endpoint = "http://localhost:8080/graphql"
+
+func parseRequests(t *testing.T, input string, opts formats.InputFormatOptions) map[string]*graphqlRequest {
+	format := New()
+	format.SetOptions(opts)
+
+	requests := make(map[string]*graphqlRequest)
+	err := format.Parse(input, func(rr *types.RequestResponse) bool {
+		require.Equal(t, "POST", rr.Request.Method)
+		require.Equal(t, endpoint, rr.URL.String())
+
+		var request graphqlRequest
+		require.Nil(t, json.Unmarshal([]byte(rr.Request.Body), &request), "could not decode request body")
+		requests[request.OperationName] = &request
+		return false
+	})
+	require.Nil(t, err, "could not parse graphql schema")
+	return requests
+}
+
+func TestGraphQLSDLParser(t *testing.T) {
+	requests := parseRequests(t, "../testdata/graphql.graphql", formats.InputFormatOptions{
+		Variables: map[string]interface{}{EndpointVariable: endpoint, "id": "1337"},
+	})
+	require.Len(t, requests, 7, "invalid number of requests")
+
+	user := requests["user"]
+	require.Equal(t, "query user($id: ID!) { user(id: $id) { id name role posts { id title createdAt author { id name role } } } }", user.Query)
+	require.Equal(t, map[string]interface{}{"id": "1337"}, user.Variables, "could not use provided variable")
+
+	require.Equal(t, "query search($term: String!) { search(term: $term) { __typename } }", requests["search"].Query)
+	require.Equal(t, "query me { me { id name role posts { id title createdAt author { id name role } } } }", requests["me"].Query)
+
+	createPost := requests["createPost"]
+	require.Equal(t, "mutation createPost($input: CreatePostInput!) { createPost(input: $input) { id title createdAt author { id name role posts { id title createdAt } } } }", createPost.Query)
+	require.Equal(t, map[string]interface{}{
+		"input": map[string]interface{}{
+			"title": "string",
+			"body":  "string",
+			"filter": map[string]interface{}{
+				"title":        "string",
+				"tags":         []interface{}{"string"},
+				"createdAfter": "string",
+			},
+		},
+	}, createPost.Variables)
+}
+
+func TestGraphQLIntrospectionParser(t *testing.T) {
+	requests := parseRequests(t, "../testdata/graphql_introspection.json", formats.InputFormatOptions{
+		Variables:    map[string]interface{}{EndpointVariable: endpoint},
+		RequiredOnly: true,
+	})
+	require.Len(t, requests, 3, "invalid number of requests")
+
+	require.Equal(t, "query products { products { sku price category } }", requests["products"].Query)
+
+	addReview := requests["addReview"]
+	require.Equal(t, "mutation addReview($sku: String!, $review: ReviewInput!) { addReview(sku: $sku, review: $review) }", addReview.Query)
+	require.Equal(t, map[string]interface{}{
+		"sku":    "string",
+		"review": map[string]interface{}{"stars": float64(1)},
+	}, addReview.Variables)
+}
+
+func TestGraphQLMissingEndpoint(t *testing.T) {
+	format := New()
+	err := format.Parse("../testdata/graphql.graphql", func(rr *types.RequestResponse) bool {
+		return false
+	})
+	require.NotNil(t, err, "missing endpoint should return error")
+}
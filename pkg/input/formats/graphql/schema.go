@@ -0,0 +1,400 @@
+package graphql
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/projectdiscovery/nuclei/v3/pkg/utils/graphql"
+)
+
+// schema is a minimal graphql schema model built either
+// from SDL or from an introspection query result
+type schema struct {
+	queryType    string
+	mutationType string
+	types        map[string]*typeDef
+}
+
+type typeDef struct {
+	Kind          string        `json:"kind"`
+	Name          string        `json:"name"`
+	Fields        []*field      `json:"fields"`
+	InputFields   []*inputValue `json:"inputFields"`
+	EnumValues    []*enumValue  `json:"enumValues"`
+	PossibleTypes []*typeRef    `json:"possibleTypes"`
+}
+
+type field struct {
+	Name string        `json:"name"`
+	Args []*inputValue `json:"args"`
+	Type *typeRef      `json:"type"`
+}
+
+type inputValue struct {
+	Name         string   `json:"name"`
+	Type         *typeRef `json:"type"`
+	DefaultValue *string  `json:"defaultValue"`
+}
+
+type enumValue struct {
+	Name string `json:"name"`
+}
+
+// typeRef is a reference to a type with NON_NULL and LIST wrappers
+type typeRef struct {
+	Kind   string   `json:"kind"`
+	Name   string   `json:"name"`
+	OfType *typeRef `json:"ofType"`
+}
+
+// named returns the innermost named type of the reference
+func (t *typeRef) named() string {
+	for t != nil && t.Name == "" {
+		t = t.OfType
+	}
+	if t == nil {
+		return ""
+	}
+	return t.Name
+}
+
+// String returns the type reference in SDL notation
+func (t *typeRef) String() string {
+	if t == nil {
+		return ""
+	}
+	switch t.Kind {
+	case "NON_NULL":
+		return t.OfType.String() + "!"
+	case "LIST":
+		return "[" + t.OfType.String() + "]"
+	}
+	return t.Name
+}
+
+// isRequired returns true if the value is non-null without a default
+func (v *inputValue) isRequired() bool {
+	return v.Type != nil && v.Type.Kind == "NON_NULL" && v.DefaultValue == nil
+}
+
+// builtinScalars are the scalars defined by the graphql specification
+var builtinScalars = []string{"String", "Int", "Float", "Boolean", "ID"}
+
+// parseIntrospection parses the result of an introspection query
+// with or without the top level data field
+func parseIntrospection(data []byte) (*schema, error) {
+	var result struct {
+		Data struct {
+			Schema *introspectionSchema `json:"__schema"`
+		} `json:"data"`
+		Schema *introspectionSchema `json:"__schema"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, errors.Wrap(err, "could not decode introspection result")
+	}
+	introspected := result.Schema
+	if introspected == nil {
+		introspected = result.Data.Schema
+	}
+	if introspected == nil {
+		return nil, errors.New("no __schema found in introspection result")
+	}
+
+	s := &schema{types: make(map[string]*typeDef)}
+	if introspected.QueryType != nil {
+		s.queryType = introspected.QueryType.Name
+	}
+	if introspected.MutationType != nil {
+		s.mutationType = introspected.MutationType.Name
+	}
+	for _, t := range introspected.Types {
+		s.types[t.Name] = t
+	}
+	return s, nil
+}
+
+type introspectionSchema struct {
+	QueryType    *typeRef   `json:"queryType"`
+	MutationType *typeRef   `json:"mutationType"`
+	Types        []*typeDef `json:"types"`
+}
+
+// parseSDL parses a schema definition language document
+func parseSDL(document string) (*schema, error) {
+	tokens, err := graphql.Lex(document)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not lex graphql schema")
+	}
+	p := &sdlParser{document: document, tokens: tokens}
+	s := &schema{types: make(map[string]*typeDef)}
+	for _, name := range builtinScalars {
+		s.types[name] = &typeDef{Kind: "SCALAR", Name: name}
+	}
+
+	for !p.eof() {
+		p.skipDescription()
+		keyword := p.next()
+		if keyword.Is("extend") {
+			keyword = p.next()
+		}
+		switch {
+		case keyword.Is("schema"):
+			p.skipDirectives()
+			if err := p.expect("{"); err != nil {
+				return nil, err
+			}
+			for !p.eof() && !p.peek().Is("}") {
+				operation := p.next()
+				if err := p.expect(":"); err != nil {
+					return nil, err
+				}
+				switch operation.Value {
+				case "query":
+					s.queryType = p.next().Value
+				case "mutation":
+					s.mutationType = p.next().Value
+				default:
+					p.next()
+				}
+			}
+			p.next()
+		case keyword.Is("type"), keyword.Is("interface"), keyword.Is("input"):
+			name := p.next().Value
+			kind := map[string]string{"type": "OBJECT", "interface": "INTERFACE", "input": "INPUT_OBJECT"}[keyword.Value]
+			t := s.typeDef(name, kind)
+			if p.peek().Is("implements") {
+				p.next()
+				for p.peek().Is("&") || (p.peek().Kind == graphql.Name && !isDefinitionKeyword(p.peek())) {
+					p.next()
+				}
+			}
+			p.skipDirectives()
+			if !p.peek().Is("{") {
+				continue
+			}
+			p.next()
+			for !p.eof() && !p.peek().Is("}") {
+				p.skipDescription()
+				value, args, err := p.parseFieldDefinition(kind != "INPUT_OBJECT")
+				if err != nil {
+					return nil, errors.Wrapf(err, "could not parse %s", name)
+				}
+				if kind == "INPUT_OBJECT" {
+					t.InputFields = append(t.InputFields, value)
+				} else {
+					t.Fields = append(t.Fields, &field{Name: value.Name, Args: args, Type: value.Type})
+				}
+			}
+			p.next()
+		case keyword.Is("enum"):
+			t := s.typeDef(p.next().Value, "ENUM")
+			p.skipDirectives()
+			if !p.peek().Is("{") {
+				continue
+			}
+			p.next()
+			for !p.eof() && !p.peek().Is("}") {
+				p.skipDescription()
+				t.EnumValues = append(t.EnumValues, &enumValue{Name: p.next().Value})
+				p.skipDirectives()
+			}
+			p.next()
+		case keyword.Is("union"):
+			t := s.typeDef(p.next().Value, "UNION")
+			p.skipDirectives()
+			if !p.peek().Is("=") {
+				continue
+			}
+			p.next()
+			for !p.eof() && (p.peek().Is("|") || (p.peek().Kind == graphql.Name && !isDefinitionKeyword(p.peek()))) {
+				if token := p.next(); token.Kind == graphql.Name {
+					t.PossibleTypes = append(t.PossibleTypes, &typeRef{Kind: "OBJECT", Name: token.Value})
+				}
+			}
+		case keyword.Is("scalar"):
+			s.typeDef(p.next().Value, "SCALAR")
+			p.skipDirectives()
+		case keyword.Is("directive"):
+			// directive @name(args) repeatable on LOCATION | LOCATION
+			p.next()
+			p.next()
+			if p.peek().Is("(") {
+				p.skipBalanced("(", ")")
+			}
+			for !p.eof() && !isDefinitionKeyword(p.peek()) && p.peek().Kind != graphql.String && p.peek().Kind != graphql.BlockString {
+				p.next()
+			}
+		default:
+			return nil, fmt.Errorf("unexpected %q in graphql schema at %d", keyword.Value, keyword.Start)
+		}
+	}
+
+	if s.queryType == "" && s.types["Query"] != nil {
+		s.queryType = "Query"
+	}
+	if s.mutationType == "" && s.types["Mutation"] != nil {
+		s.mutationType = "Mutation"
+	}
+	return s, nil
+}
+
+// typeDef returns the type with the given name creating it if
+// it does not exist yet (types can be extended)
+func (s *schema) typeDef(name, kind string) *typeDef {
+	if t, ok := s.types[name]; ok {
+		return t
+	}
+	t := &typeDef{Name: name, Kind: kind}
+	s.types[name] = t
+	return t
+}
+
+func isDefinitionKeyword(token graphql.Token) bool {
+	switch token.Value {
+	case "schema", "type", "interface", "input", "enum", "union", "scalar", "directive", "extend":
+		return token.Kind == graphql.Name
+	}
+	return false
+}
+
+// sdlParser is a minimal recursive descent parser for the
+// type system definitions of a graphql schema
+type sdlParser struct {
+	document string
+	tokens   []graphql.Token
+	pos      int
+}
+
+func (p *sdlParser) eof() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *sdlParser) peek() graphql.Token {
+	return p.peekAt(0)
+}
+
+func (p *sdlParser) peekAt(offset int) graphql.Token {
+	if p.pos+offset >= len(p.tokens) {
+		return graphql.Token{Kind: graphql.Punctuator}
+	}
+	return p.tokens[p.pos+offset]
+}
+
+func (p *sdlParser) next() graphql.Token {
+	token := p.peek()
+	p.pos++
+	return token
+}
+
+func (p *sdlParser) expect(value string) error {
+	if token := p.next(); !token.Is(value) {
+		return fmt.Errorf("expected %q in graphql schema but got %q at %d", value, token.Value, token.Start)
+	}
+	return nil
+}
+
+func (p *sdlParser) skipDescription() {
+	if kind := p.peek().Kind; kind == graphql.String || kind == graphql.BlockString {
+		p.next()
+	}
+}
+
+// skipDirectives skips directive usages like @deprecated(reason: "")
+func (p *sdlParser) skipDirectives() {
+	for p.peek().Is("@") {
+		p.next()
+		p.next()
+		if p.peek().Is("(") {
+			p.skipBalanced("(", ")")
+		}
+	}
+}
+
+// skipBalanced skips tokens until the matching closing token
+func (p *sdlParser) skipBalanced(open, close string) {
+	depth := 0
+	for !p.eof() {
+		token := p.next()
+		if token.Is(open) {
+			depth++
+		} else if token.Is(close) {
+			depth--
+			if depth == 0 {
+				return
+			}
+		}
+	}
+}
+
+// parseFieldDefinition parses a field or input value definition
+// in the form of name(args): Type = default @directives
+func (p *sdlParser) parseFieldDefinition(withArgs bool) (*inputValue, []*inputValue, error) {
+	name := p.next()
+	if name.Kind != graphql.Name {
+		return nil, nil, fmt.Errorf("expected name but got %q at %d", name.Value, name.Start)
+	}
+	var args []*inputValue
+	if withArgs && p.peek().Is("(") {
+		p.next()
+		for !p.eof() && !p.peek().Is(")") {
+			p.skipDescription()
+			arg, _, err := p.parseFieldDefinition(false)
+			if err != nil {
+				return nil, nil, err
+			}
+			args = append(args, arg)
+		}
+		p.next()
+	}
+	if err := p.expect(":"); err != nil {
+		return nil, nil, err
+	}
+	typ, err := p.parseType()
+	if err != nil {
+		return nil, nil, err
+	}
+	value := &inputValue{Name: name.Value, Type: typ}
+	if p.peek().Is("=") {
+		p.next()
+		start := p.peek()
+		end := start
+		if start.Is("[") || start.Is("{") {
+			closing := map[string]string{"[": "]", "{": "}"}[start.Value]
+			p.skipBalanced(start.Value, closing)
+			end = p.tokens[p.pos-1]
+		} else {
+			p.next()
+		}
+		defaultValue := p.document[start.Start:end.End]
+		value.DefaultValue = &defaultValue
+	}
+	p.skipDirectives()
+	return value, args, nil
+}
+
+// parseType parses a type reference like [String!]!
+func (p *sdlParser) parseType() (*typeRef, error) {
+	var typ *typeRef
+	token := p.next()
+	switch {
+	case token.Is("["):
+		ofType, err := p.parseType()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect("]"); err != nil {
+			return nil, err
+		}
+		typ = &typeRef{Kind: "LIST", OfType: ofType}
+	case token.Kind == graphql.Name:
+		typ = &typeRef{Name: token.Value}
+	default:
+		return nil, fmt.Errorf("expected type but got %q at %d", token.Value, token.Start)
+	}
+	if p.peek().Is("!") {
+		p.next()
+		typ = &typeRef{Kind: "NON_NULL", OfType: typ}
+	}
+	return typ, nil
+}
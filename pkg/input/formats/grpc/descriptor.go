@@ -0,0 +1,151 @@
+package grpc
+
+import (
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	// well known types are bundled for imports missing from definitions
+	_ "google.golang.org/protobuf/types/known/anypb"
+	_ "google.golang.org/protobuf/types/known/apipb"
+	_ "google.golang.org/protobuf/types/known/durationpb"
+	_ "google.golang.org/protobuf/types/known/emptypb"
+	_ "google.golang.org/protobuf/types/known/fieldmaskpb"
+	_ "google.golang.org/protobuf/types/known/sourcecontextpb"
+	_ "google.golang.org/protobuf/types/known/structpb"
+	_ "google.golang.org/protobuf/types/known/timestamppb"
+	_ "google.golang.org/protobuf/types/known/typepb"
+	_ "google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// parseFileDescriptorSet loads a binary FileDescriptorSet as
+// generated by protoc --descriptor_set_out
+func parseFileDescriptorSet(data []byte, r *registry) error {
+	set := &descriptorpb.FileDescriptorSet{}
+	if err := proto.Unmarshal(data, set); err != nil {
+		return errors.Wrap(err, "could not decode file descriptor set")
+	}
+	addBundledFiles(set)
+
+	// sets generated without --include_imports miss the imported files
+	files, err := protodesc.FileOptions{AllowUnresolvable: true}.NewFiles(set)
+	if err != nil {
+		return errors.Wrap(err, "could not load file descriptor set")
+	}
+	files.RangeFiles(func(file protoreflect.FileDescriptor) bool {
+		addFileDescriptor(file, r)
+		return true
+	})
+	return nil
+}
+
+// addBundledFiles adds the bundled well known types imported by
+// the files of the set but missing from it
+func addBundledFiles(set *descriptorpb.FileDescriptorSet) {
+	included := make(map[string]struct{}, len(set.File))
+	for _, file := range set.File {
+		included[file.GetName()] = struct{}{}
+	}
+	// the set grows while iterating if bundled files import others
+	for i := 0; i < len(set.File); i++ {
+		for _, dependency := range set.File[i].Dependency {
+			if _, ok := included[dependency]; ok {
+				continue
+			}
+			file, err := protoregistry.GlobalFiles.FindFileByPath(dependency)
+			if err != nil {
+				continue
+			}
+			included[dependency] = struct{}{}
+			set.File = append(set.File, protodesc.ToFileDescriptorProto(file))
+		}
+	}
+}
+
+// bundledFile returns the bundled descriptor of an import path
+func bundledFile(path string) (protoreflect.FileDescriptor, bool) {
+	file, err := protoregistry.GlobalFiles.FindFileByPath(path)
+	if err != nil {
+		return nil, false
+	}
+	return file, true
+}
+
+// addFileDescriptor adds the messages, enums and services of a file to the registry
+func addFileDescriptor(file protoreflect.FileDescriptor, r *registry) {
+	addMessageDescriptors(file.Messages(), r)
+	addEnumDescriptors(file.Enums(), r)
+
+	services := file.Services()
+	for i := 0; i < services.Len(); i++ {
+		sd := services.Get(i)
+		service := &serviceDef{name: string(sd.FullName())}
+		methods := sd.Methods()
+		for j := 0; j < methods.Len(); j++ {
+			md := methods.Get(j)
+			service.methods = append(service.methods, &methodDef{
+				name:            string(md.Name()),
+				input:           "." + string(md.Input().FullName()),
+				output:          "." + string(md.Output().FullName()),
+				clientStreaming: md.IsStreamingClient(),
+				serverStreaming: md.IsStreamingServer(),
+				scope:           string(file.Package()),
+			})
+		}
+		r.services = append(r.services, service)
+	}
+}
+
+func addMessageDescriptors(messages protoreflect.MessageDescriptors, r *registry) {
+	for i := 0; i < messages.Len(); i++ {
+		md := messages.Get(i)
+		if md.IsPlaceholder() {
+			continue
+		}
+		message := &messageDef{name: string(md.FullName())}
+		fields := md.Fields()
+		for j := 0; j < fields.Len(); j++ {
+			fd := fields.Get(j)
+			field := &fieldDef{
+				name:     string(fd.Name()),
+				number:   int(fd.Number()),
+				kind:     fieldKind(fd.Kind()),
+				repeated: fd.Cardinality() == protoreflect.Repeated,
+				scope:    message.name,
+			}
+			switch {
+			case fd.Message() != nil:
+				field.typeName = "." + string(fd.Message().FullName())
+			case fd.Enum() != nil:
+				field.typeName = "." + string(fd.Enum().FullName())
+			}
+			// proto3 optional fields are wrapped in synthetic oneofs
+			if oneof := fd.ContainingOneof(); oneof != nil && !oneof.IsSynthetic() {
+				field.oneof = string(oneof.Name())
+			}
+			message.fields = append(message.fields, field)
+		}
+		r.messages[message.name] = message
+
+		addMessageDescriptors(md.Messages(), r)
+		addEnumDescriptors(md.Enums(), r)
+	}
+}
+
+func addEnumDescriptors(enums protoreflect.EnumDescriptors, r *registry) {
+	for i := 0; i < enums.Len(); i++ {
+		ed := enums.Get(i)
+		if ed.IsPlaceholder() {
+			continue
+		}
+		enum := &enumDef{name: string(ed.FullName())}
+		values := ed.Values()
+		for j := 0; j < values.Len(); j++ {
+			enum.values = append(enum.values, int(values.Get(j).Number()))
+		}
+		r.enums[enum.name] = enum
+	}
+}
@@ -0,0 +1,179 @@
+package grpc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"net/http"
+	"net/http/httputil"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/projectdiscovery/nuclei/v3/pkg/input/formats"
+	httpTypes "github.com/projectdiscovery/nuclei/v3/pkg/input/types"
+	"github.com/projectdiscovery/nuclei/v3/pkg/types"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// maxMessageDepth is the maximum depth of nested messages
+// generated for a request message
+const maxMessageDepth = 3
+
+// generator generates example grpc requests from a registry
+type generator struct {
+	registry *registry
+	opts     formats.InputFormatOptions
+}
+
+// generateRequests generates a request for every method of
+// every service in the registry
+func generateRequests(r *registry, endpoint string, opts formats.InputFormatOptions, callback formats.ParseReqRespCallback) error {
+	g := &generator{registry: r, opts: opts}
+	endpoint = strings.TrimSuffix(endpoint, "/")
+
+	for _, service := range r.services {
+		for _, method := range service.methods {
+			message := g.message(method.input, 0, true)
+
+			// grpc messages are prefixed with a compressed flag
+			// and the big endian length of the message
+			body := make([]byte, 5, 5+len(message))
+			binary.BigEndian.PutUint32(body[1:], uint32(len(message)))
+			body = append(body, message...)
+
+			req, err := http.NewRequest(http.MethodPost, endpoint+"/"+service.name+"/"+method.name, bytes.NewReader(body))
+			if err != nil {
+				return errors.Wrap(err, "could not create request")
+			}
+			req.Header.Set("Content-Type", "application/grpc")
+			req.Header.Set("TE", "trailers")
+
+			dumped, err := httputil.DumpRequestOut(req, true)
+			if err != nil {
+				return errors.Wrap(err, "could not dump request")
+			}
+			rr, err := httpTypes.ParseRawRequestWithURL(string(dumped), req.URL.String())
+			if err != nil {
+				return errors.Wrap(err, "could not parse raw request")
+			}
+			// parsing trims trailing newlines which are valid bytes
+			// of a binary message so set the body explicitly
+			rr.Request.Body = string(body)
+			callback(rr)
+		}
+	}
+	return nil
+}
+
+// message returns the encoded example message for a message type.
+// top level fields can be overridden using variables with the
+// same name as the field.
+func (g *generator) message(name string, depth int, topLevel bool) []byte {
+	message, ok := g.registry.messages[name]
+	if !ok {
+		// unresolved types from missing imports are sent as empty messages
+		return nil
+	}
+	var bin []byte
+	// only the first field of a oneof is set
+	oneofs := make(map[string]struct{})
+	for _, field := range message.fields {
+		if field.oneof != "" {
+			if _, ok := oneofs[field.oneof]; ok {
+				continue
+			}
+			oneofs[field.oneof] = struct{}{}
+		}
+		var value interface{}
+		if topLevel {
+			value = g.opts.Variables[field.name]
+		}
+		bin = g.appendField(bin, field, value, depth)
+	}
+	return bin
+}
+
+// appendField appends an example value for a field. repeated fields
+// and maps are generated with a single element.
+func (g *generator) appendField(bin []byte, field *fieldDef, value interface{}, depth int) []byte {
+	number := protowire.Number(field.number)
+	switch field.kind {
+	case kindMessage:
+		if depth >= maxMessageDepth {
+			return bin
+		}
+		bin = protowire.AppendTag(bin, number, protowire.BytesType)
+		return protowire.AppendBytes(bin, g.message(field.typeName, depth+1, false))
+	case kindString, kindBytes:
+		placeholder := "string"
+		if field.kind == kindBytes {
+			placeholder = "bytes"
+		}
+		if value != nil {
+			placeholder = types.ToString(value)
+		}
+		bin = protowire.AppendTag(bin, number, protowire.BytesType)
+		return protowire.AppendString(bin, placeholder)
+	case kindBool:
+		b := true
+		if parsed, err := strconv.ParseBool(types.ToString(value)); value != nil && err == nil {
+			b = parsed
+		}
+		bin = protowire.AppendTag(bin, number, protowire.VarintType)
+		return protowire.AppendVarint(bin, protowire.EncodeBool(b))
+	case kindEnum:
+		var v int64
+		if enum, ok := g.registry.enums[field.typeName]; ok && len(enum.values) > 0 {
+			v = int64(enum.values[0])
+		}
+		bin = protowire.AppendTag(bin, number, protowire.VarintType)
+		return protowire.AppendVarint(bin, uint64(intValue(value, v)))
+	case kindInt32, kindInt64, kindUint32, kindUint64:
+		bin = protowire.AppendTag(bin, number, protowire.VarintType)
+		return protowire.AppendVarint(bin, uint64(intValue(value, 1)))
+	case kindSint32, kindSint64:
+		bin = protowire.AppendTag(bin, number, protowire.VarintType)
+		return protowire.AppendVarint(bin, protowire.EncodeZigZag(intValue(value, 1)))
+	case kindFixed32, kindSfixed32:
+		bin = protowire.AppendTag(bin, number, protowire.Fixed32Type)
+		return protowire.AppendFixed32(bin, uint32(intValue(value, 1)))
+	case kindFixed64, kindSfixed64:
+		bin = protowire.AppendTag(bin, number, protowire.Fixed64Type)
+		return protowire.AppendFixed64(bin, uint64(intValue(value, 1)))
+	case kindFloat:
+		bin = protowire.AppendTag(bin, number, protowire.Fixed32Type)
+		return protowire.AppendFixed32(bin, math.Float32bits(float32(floatValue(value, 1.1))))
+	case kindDouble:
+		bin = protowire.AppendTag(bin, number, protowire.Fixed64Type)
+		return protowire.AppendFixed64(bin, math.Float64bits(floatValue(value, 1.1)))
+	}
+	// groups are not supported
+	return bin
+}
+
+// intValue returns the integer value of a variable or the
+// fallback if the variable is not set or not a number
+func intValue(value interface{}, fallback int64) int64 {
+	if value == nil {
+		return fallback
+	}
+	parsed, err := strconv.ParseInt(types.ToString(value), 0, 64)
+	if err != nil {
+		return fallback
+	}
+	return parsed
+}
+
+// floatValue returns the float value of a variable or the
+// fallback if the variable is not set or not a number
+func floatValue(value interface{}, fallback float64) float64 {
+	if value == nil {
+		return fallback
+	}
+	parsed, err := strconv.ParseFloat(types.ToString(value), 64)
+	if err != nil {
+		return fallback
+	}
+	return parsed
+}
@@ -0,0 +1,78 @@
+package grpc
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/projectdiscovery/gologger"
+	"github.com/projectdiscovery/nuclei/v3/pkg/input/formats"
+	"github.com/projectdiscovery/nuclei/v3/pkg/types"
+)
+
+// EndpointVariable is the variable containing the base url of the
+// grpc server requests are generated for
+const EndpointVariable = "grpc_endpoint"
+
+// GRPCFormat is a gRPC service definition (.proto file or
+// FileDescriptorSet) File parser
+type GRPCFormat struct {
+	opts formats.InputFormatOptions
+}
+
+// New creates a new gRPC format parser
+func New() *GRPCFormat {
+	return &GRPCFormat{}
+}
+
+var _ formats.Format = &GRPCFormat{}
+
+// Name returns the name of the format
+func (j *GRPCFormat) Name() string {
+	return "grpc"
+}
+
+func (j *GRPCFormat) SetOptions(options formats.InputFormatOptions) {
+	j.opts = options
+}
+
+// Parse parses the input and calls the provided callback
+// function for each RawRequest it discovers.
+func (j *GRPCFormat) Parse(input string, resultsCb formats.ParseReqRespCallback) error {
+	r := newRegistry()
+	if strings.EqualFold(filepath.Ext(input), ".proto") {
+		if err := parseProtoFile(input, r); err != nil {
+			return errors.Wrap(err, "could not parse proto file")
+		}
+	} else {
+		// anything else is expected to be a FileDescriptorSet as
+		// generated by protoc --descriptor_set_out or buf build
+		data, err := os.ReadFile(input)
+		if err != nil {
+			return errors.Wrap(err, "could not read descriptor set file")
+		}
+		if err := parseFileDescriptorSet(data, r); err != nil {
+			return err
+		}
+	}
+	r.link()
+	if unresolved := r.unresolvedFields(); len(unresolved) > 0 {
+		gologger.Warning().Msgf("grpc: unresolved types of fields are sent as empty values: %s\n", strings.Join(unresolved, ", "))
+	}
+
+	if len(r.services) == 0 {
+		gologger.Warning().Msgf("grpc: no services found in %s\n", input)
+		return nil
+	}
+
+	endpoint := types.ToString(j.opts.Variables[EndpointVariable])
+	if endpoint == "" {
+		if j.opts.SkipFormatValidation {
+			gologger.Verbose().Msgf("grpc: skipping all requests due to missing %s variable\n", EndpointVariable)
+			return nil
+		}
+		return errors.Errorf("no grpc endpoint found, use -var %s=https://example.com to specify it", EndpointVariable)
+	}
+	return generateRequests(r, endpoint, j.opts, resultsCb)
+}
@@ -0,0 +1,120 @@
+package grpc
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/projectdiscovery/nuclei/v3/pkg/fuzz/dataformat"
+	"github.com/projectdiscovery/nuclei/v3/pkg/input/formats"
+	"github.com/projectdiscovery/nuclei/v3/pkg/input/types"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+const endpoint = "http://localhost:8082"
+
+func parseRequests(t *testing.T, input string, opts formats.InputFormatOptions) map[string]dataformat.KV {
+	format := New()
+	format.SetOptions(opts)
+
+	requests := make(map[string]dataformat.KV)
+	err := format.Parse(input, func(rr *types.RequestResponse) bool {
+		require.Equal(t, "POST", rr.Request.Method)
+		contentType, _ := rr.Request.Headers.Get("Content-Type")
+		require.Equal(t, "application/grpc", contentType)
+		te, _ := rr.Request.Headers.Get("Te")
+		require.Equal(t, "trailers", te)
+
+		decoded, err := dataformat.NewProtobuf().Decode(rr.Request.Body)
+		require.Nil(t, err, "could not decode request body")
+		requests[rr.URL.String()] = decoded
+		return false
+	})
+	require.Nil(t, err, "could not parse grpc definition")
+	return requests
+}
+
+func TestGRPCProtoParser(t *testing.T) {
+	requests := parseRequests(t, "../testdata/grpc.proto", formats.InputFormatOptions{
+		Variables: map[string]interface{}{EndpointVariable: endpoint, "filter": "admin"},
+	})
+	require.Len(t, requests, 2, "invalid number of requests")
+
+	getUser := requests[endpoint+"/playground.UserService/GetUser"]
+	require.Equal(t, "string", getUser.Get("1"))
+
+	listUsers := requests[endpoint+"/playground.UserService/ListUsers"]
+	require.Equal(t, "admin", listUsers.Get("1"), "could not use provided variable")
+	// sint64 placeholder is zigzag encoded
+	require.Equal(t, map[string]interface{}{"1": int64(2), "2": int64(1)}, listUsers.Get("2"))
+	require.Equal(t, map[string]interface{}{"1": "string", "2": "string"}, listUsers.Get("3"))
+	require.Equal(t, int64(0), listUsers.Get("4"))
+	// only the first field of a oneof is set
+	require.Equal(t, int64(1), listUsers.Get("5"))
+	require.Nil(t, listUsers.Get("6"))
+	// well known types missing on disk use the bundled types
+	require.Equal(t, map[string]interface{}{"1": int64(1), "2": int64(1)}, listUsers.Get("7"))
+}
+
+func TestGRPCDescriptorSetParser(t *testing.T) {
+	requests := parseRequests(t, "../testdata/grpc.protoset", formats.InputFormatOptions{
+		Variables: map[string]interface{}{EndpointVariable: endpoint, "id": "1337"},
+	})
+	require.Len(t, requests, 1, "invalid number of requests")
+	getUser := requests[endpoint+"/playground.UserService/GetUser"]
+	require.Equal(t, "1337", getUser.Get("1"), "could not use provided variable")
+}
+
+func TestGRPCDescriptorSetOneofsAndImports(t *testing.T) {
+	// the set does not include the imported timestamp.proto
+	set := &descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{{
+		Name:       proto.String("search.proto"),
+		Package:    proto.String("search"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{"google/protobuf/timestamp.proto"},
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name: proto.String("SearchRequest"),
+			Field: []*descriptorpb.FieldDescriptorProto{
+				{Name: proto.String("query"), Number: proto.Int32(1), Type: descriptorpb.FieldDescriptorProto_TYPE_STRING.Enum(), OneofIndex: proto.Int32(0)},
+				{Name: proto.String("id"), Number: proto.Int32(2), Type: descriptorpb.FieldDescriptorProto_TYPE_INT32.Enum(), OneofIndex: proto.Int32(0)},
+				{Name: proto.String("limit"), Number: proto.Int32(3), Type: descriptorpb.FieldDescriptorProto_TYPE_INT32.Enum(), OneofIndex: proto.Int32(1), Proto3Optional: proto.Bool(true)},
+				{Name: proto.String("since"), Number: proto.Int32(4), Type: descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum(), TypeName: proto.String(".google.protobuf.Timestamp")},
+			},
+			OneofDecl: []*descriptorpb.OneofDescriptorProto{{Name: proto.String("by")}, {Name: proto.String("_limit")}},
+		}},
+		Service: []*descriptorpb.ServiceDescriptorProto{{
+			Name:   proto.String("SearchService"),
+			Method: []*descriptorpb.MethodDescriptorProto{{Name: proto.String("Search"), InputType: proto.String(".search.SearchRequest"), OutputType: proto.String(".google.protobuf.Timestamp")}},
+		}},
+	}}}
+	data, err := proto.Marshal(set)
+	require.Nil(t, err)
+	input := filepath.Join(t.TempDir(), "search.protoset")
+	require.Nil(t, os.WriteFile(input, data, 0600))
+
+	requests := parseRequests(t, input, formats.InputFormatOptions{
+		Variables: map[string]interface{}{EndpointVariable: endpoint},
+	})
+	search := requests[endpoint+"/search.SearchService/Search"]
+	require.Equal(t, "string", search.Get("1"))
+	require.Nil(t, search.Get("2"), "only the first field of a oneof should be set")
+	require.Equal(t, int64(1), search.Get("3"), "proto3 optional fields should be set")
+	require.Equal(t, map[string]interface{}{"1": int64(1), "2": int64(1)}, search.Get("4"))
+}
+
+func TestGRPCMissingEndpoint(t *testing.T) {
+	format := New()
+	err := format.Parse("../testdata/grpc.proto", func(rr *types.RequestResponse) bool {
+		return false
+	})
+	require.NotNil(t, err, "missing endpoint should return an error")
+
+	format.SetOptions(formats.InputFormatOptions{SkipFormatValidation: true})
+	err = format.Parse("../testdata/grpc.proto", func(rr *types.RequestResponse) bool {
+		t.Fatal("no requests should be generated without endpoint")
+		return false
+	})
+	require.Nil(t, err)
+}
@@ -0,0 +1,521 @@
+package grpc
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+	"github.com/projectdiscovery/gologger"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// protoParser is a minimal parser for .proto files which only
+// extracts the messages, enums and services used for generating
+// requests. options, extensions and reserved ranges are ignored.
+// imports of well known types missing on disk use the bundled types.
+type protoParser struct {
+	registry *registry
+	// parsed contains the absolute paths of already parsed files
+	parsed map[string]struct{}
+}
+
+// parseProtoFile parses a .proto file and all of its imports
+// relative to the directory of the file into the registry
+func parseProtoFile(file string, r *registry) error {
+	p := &protoParser{registry: r, parsed: make(map[string]struct{})}
+	return p.parseFile(file, filepath.Dir(file))
+}
+
+func (p *protoParser) parseFile(file, root string) error {
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return err
+	}
+	if _, ok := p.parsed[abs]; ok {
+		return nil
+	}
+	p.parsed[abs] = struct{}{}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return errors.Wrap(err, "could not read proto file")
+	}
+	tokens, err := lexProto(string(data))
+	if err != nil {
+		return errors.Wrapf(err, "could not lex %s", file)
+	}
+	f := &protoFile{tokens: tokens}
+	if err := f.parse(p.registry); err != nil {
+		return errors.Wrapf(err, "could not parse %s", file)
+	}
+	for _, imported := range f.imports {
+		path := filepath.Join(root, imported)
+		if _, err := os.Stat(path); err != nil {
+			if bundled, ok := bundledFile(imported); ok {
+				p.parseBundledFile(bundled)
+			} else {
+				gologger.Warning().Msgf("grpc: could not resolve import %s of %s\n", imported, file)
+			}
+			continue
+		}
+		if err := p.parseFile(path, root); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseBundledFile adds a bundled well known types file and its imports
+func (p *protoParser) parseBundledFile(file protoreflect.FileDescriptor) {
+	if _, ok := p.parsed[file.Path()]; ok {
+		return
+	}
+	p.parsed[file.Path()] = struct{}{}
+	addFileDescriptor(file, p.registry)
+
+	imports := file.Imports()
+	for i := 0; i < imports.Len(); i++ {
+		p.parseBundledFile(imports.Get(i).FileDescriptor)
+	}
+}
+
+// protoFile is the parsing state of a single .proto file
+type protoFile struct {
+	tokens  []string
+	pos     int
+	pkg     string
+	imports []string
+}
+
+func (f *protoFile) peek() string {
+	if f.pos >= len(f.tokens) {
+		return ""
+	}
+	return f.tokens[f.pos]
+}
+
+func (f *protoFile) next() string {
+	token := f.peek()
+	if f.pos < len(f.tokens) {
+		f.pos++
+	}
+	return token
+}
+
+func (f *protoFile) expect(token string) error {
+	if got := f.next(); got != token {
+		return errors.Errorf("expected %q but got %q", token, got)
+	}
+	return nil
+}
+
+// skipStatement skips tokens until the end of the current
+// statement including any nested blocks
+func (f *protoFile) skipStatement() {
+	depth := 0
+	for f.pos < len(f.tokens) {
+		switch f.next() {
+		case "{":
+			depth++
+		case "}":
+			depth--
+			if depth <= 0 {
+				if f.peek() == ";" {
+					f.pos++
+				}
+				return
+			}
+		case ";":
+			if depth == 0 {
+				return
+			}
+		}
+	}
+}
+
+// skipOptions skips a bracketed list of field options
+func (f *protoFile) skipOptions() {
+	if f.peek() != "[" {
+		return
+	}
+	for f.pos < len(f.tokens) {
+		if f.next() == "]" {
+			return
+		}
+	}
+}
+
+func (f *protoFile) parse(r *registry) error {
+	for f.pos < len(f.tokens) {
+		switch f.peek() {
+		case "package":
+			f.next()
+			f.pkg = f.next()
+			if err := f.expect(";"); err != nil {
+				return err
+			}
+		case "import":
+			f.next()
+			if next := f.peek(); next == "public" || next == "weak" {
+				f.next()
+			}
+			path, err := unquote(f.next())
+			if err != nil {
+				return err
+			}
+			f.imports = append(f.imports, path)
+			if err := f.expect(";"); err != nil {
+				return err
+			}
+		case "message":
+			f.next()
+			if err := f.parseMessage(r, f.pkg); err != nil {
+				return err
+			}
+		case "enum":
+			f.next()
+			if err := f.parseEnum(r, f.pkg); err != nil {
+				return err
+			}
+		case "service":
+			f.next()
+			if err := f.parseService(r); err != nil {
+				return err
+			}
+		case ";":
+			f.next()
+		default:
+			// syntax, edition, option and extend statements
+			f.skipStatement()
+		}
+	}
+	return nil
+}
+
+func (f *protoFile) parseMessage(r *registry, scope string) error {
+	message := &messageDef{name: joinName(scope, f.next())}
+	if err := f.expect("{"); err != nil {
+		return err
+	}
+	r.messages[message.name] = message
+
+	for {
+		switch token := f.peek(); token {
+		case "":
+			return errors.Errorf("unterminated message %s", message.name)
+		case "}":
+			f.next()
+			return nil
+		case ";":
+			f.next()
+		case "message":
+			f.next()
+			if err := f.parseMessage(r, message.name); err != nil {
+				return err
+			}
+		case "enum":
+			f.next()
+			if err := f.parseEnum(r, message.name); err != nil {
+				return err
+			}
+		case "oneof":
+			f.next()
+			oneof := f.next()
+			if err := f.expect("{"); err != nil {
+				return err
+			}
+			for f.peek() != "}" && f.peek() != "" {
+				if f.peek() == "option" {
+					f.skipStatement()
+					continue
+				}
+				count := len(message.fields)
+				if err := f.parseField(r, message); err != nil {
+					return err
+				}
+				// groups are skipped without adding a field
+				if len(message.fields) > count {
+					message.fields[count].oneof = oneof
+				}
+			}
+			f.next()
+		case "option", "reserved", "extensions", "extend":
+			f.skipStatement()
+		default:
+			if err := f.parseField(r, message); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (f *protoFile) parseField(r *registry, message *messageDef) error {
+	field := &fieldDef{scope: message.name}
+	switch f.peek() {
+	case "repeated":
+		field.repeated = true
+		f.next()
+	case "optional", "required":
+		f.next()
+	}
+
+	typeName := f.next()
+	if typeName == "group" {
+		// proto2 groups are deprecated and not supported
+		f.skipStatement()
+		return nil
+	}
+	if typeName == "map" {
+		if err := f.parseMapType(r, message, field); err != nil {
+			return err
+		}
+	} else if kind, ok := scalarKinds[typeName]; ok {
+		field.kind = kind
+	} else {
+		field.typeName = typeName
+	}
+
+	field.name = f.next()
+	if err := f.expect("="); err != nil {
+		return err
+	}
+	number, err := strconv.ParseInt(f.next(), 0, 32)
+	if err != nil {
+		return errors.Wrapf(err, "invalid number for field %s", field.name)
+	}
+	field.number = int(number)
+	f.skipOptions()
+	if err := f.expect(";"); err != nil {
+		return err
+	}
+	message.fields = append(message.fields, field)
+	return nil
+}
+
+// parseMapType parses a map<K, V> type into a synthetic repeated
+// entry message which has the same wire format as a map
+func (f *protoFile) parseMapType(r *registry, message *messageDef, field *fieldDef) error {
+	if err := f.expect("<"); err != nil {
+		return err
+	}
+	keyType := f.next()
+	if err := f.expect(","); err != nil {
+		return err
+	}
+	valueType := f.next()
+	if err := f.expect(">"); err != nil {
+		return err
+	}
+	// the field name is needed for the entry name, so peek at it
+	name := f.peek()
+	entry := &messageDef{name: joinName(message.name, mapEntryName(name))}
+	for i, typeName := range []string{keyType, valueType} {
+		entryField := &fieldDef{name: []string{"key", "value"}[i], number: i + 1, scope: message.name}
+		if kind, ok := scalarKinds[typeName]; ok {
+			entryField.kind = kind
+		} else {
+			entryField.typeName = typeName
+		}
+		entry.fields = append(entry.fields, entryField)
+	}
+	r.messages[entry.name] = entry
+	field.repeated = true
+	field.kind = kindMessage
+	field.typeName = "." + entry.name
+	return nil
+}
+
+// mapEntryName returns the name of the entry message protoc
+// generates for a map field
+func mapEntryName(field string) string {
+	var builder strings.Builder
+	upper := true
+	for _, r := range field {
+		if r == '_' {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		builder.WriteRune(r)
+	}
+	return builder.String() + "Entry"
+}
+
+func (f *protoFile) parseEnum(r *registry, scope string) error {
+	enum := &enumDef{name: joinName(scope, f.next())}
+	if err := f.expect("{"); err != nil {
+		return err
+	}
+	r.enums[enum.name] = enum
+
+	for {
+		switch token := f.peek(); token {
+		case "":
+			return errors.Errorf("unterminated enum %s", enum.name)
+		case "}":
+			f.next()
+			return nil
+		case ";":
+			f.next()
+		case "option", "reserved":
+			f.skipStatement()
+		default:
+			f.next()
+			if err := f.expect("="); err != nil {
+				return err
+			}
+			value := f.next()
+			if value == "-" {
+				value += f.next()
+			}
+			number, err := strconv.ParseInt(value, 0, 32)
+			if err != nil {
+				return errors.Wrapf(err, "invalid value for enum %s", enum.name)
+			}
+			enum.values = append(enum.values, int(number))
+			f.skipOptions()
+			if err := f.expect(";"); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (f *protoFile) parseService(r *registry) error {
+	service := &serviceDef{name: joinName(f.pkg, f.next())}
+	if err := f.expect("{"); err != nil {
+		return err
+	}
+	for {
+		switch token := f.peek(); token {
+		case "":
+			return errors.Errorf("unterminated service %s", service.name)
+		case "}":
+			f.next()
+			r.services = append(r.services, service)
+			return nil
+		case ";":
+			f.next()
+		case "rpc":
+			f.next()
+			method := &methodDef{name: f.next(), scope: f.pkg}
+			var err error
+			if method.clientStreaming, method.input, err = f.parseMethodType(); err != nil {
+				return err
+			}
+			if err := f.expect("returns"); err != nil {
+				return err
+			}
+			if method.serverStreaming, method.output, err = f.parseMethodType(); err != nil {
+				return err
+			}
+			if f.peek() == "{" {
+				f.skipStatement()
+			} else if err := f.expect(";"); err != nil {
+				return err
+			}
+			service.methods = append(service.methods, method)
+		default:
+			f.skipStatement()
+		}
+	}
+}
+
+// parseMethodType parses the ( [stream] Type ) of a rpc method
+func (f *protoFile) parseMethodType() (bool, string, error) {
+	if err := f.expect("("); err != nil {
+		return false, "", err
+	}
+	var streaming bool
+	typeName := f.next()
+	if typeName == "stream" && f.peek() != ")" {
+		streaming = true
+		typeName = f.next()
+	}
+	if err := f.expect(")"); err != nil {
+		return false, "", err
+	}
+	return streaming, typeName, nil
+}
+
+// lexProto splits a .proto file into tokens. identifiers including
+// dots, numbers and quoted strings are returned as a single token
+// and comments are removed.
+func lexProto(data string) ([]string, error) {
+	var tokens []string
+	for i := 0; i < len(data); {
+		c := data[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			i++
+		case strings.HasPrefix(data[i:], "//"):
+			end := strings.IndexByte(data[i:], '\n')
+			if end < 0 {
+				return tokens, nil
+			}
+			i += end + 1
+		case strings.HasPrefix(data[i:], "/*"):
+			end := strings.Index(data[i+2:], "*/")
+			if end < 0 {
+				return nil, errors.New("unterminated comment")
+			}
+			i += end + 4
+		case c == '"' || c == '\'':
+			j := i + 1
+			for ; j < len(data) && data[j] != c; j++ {
+				if data[j] == '\\' {
+					j++
+				}
+			}
+			if j >= len(data) {
+				return nil, errors.New("unterminated string")
+			}
+			tokens = append(tokens, data[i:j+1])
+			i = j + 1
+		case isProtoIdentChar(c):
+			j := i
+			for j < len(data) && (isProtoIdentChar(data[j]) || data[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, data[i:j])
+			i = j
+		case c == '.':
+			// fully qualified type names start with a dot
+			j := i + 1
+			for j < len(data) && (isProtoIdentChar(data[j]) || data[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, data[i:j])
+			i = j
+		default:
+			tokens = append(tokens, string(c))
+			i++
+		}
+	}
+	return tokens, nil
+}
+
+func isProtoIdentChar(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// unquote unquotes a string literal of a .proto file
+func unquote(token string) (string, error) {
+	if len(token) < 2 {
+		return "", errors.Errorf("expected string but got %q", token)
+	}
+	if token[0] == '\'' {
+		token = `"` + strings.ReplaceAll(token[1:len(token)-1], `"`, `\"`) + `"`
+	}
+	value, err := strconv.Unquote(token)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid string %s", token)
+	}
+	return value, nil
+}
@@ -0,0 +1,188 @@
+package grpc
+
+import (
+	"sort"
+	"strings"
+)
+
+// registry contains the messages, enums and services loaded
+// from .proto files or a FileDescriptorSet keyed by full name
+type registry struct {
+	messages map[string]*messageDef
+	enums    map[string]*enumDef
+	services []*serviceDef
+}
+
+func newRegistry() *registry {
+	return &registry{
+		messages: make(map[string]*messageDef),
+		enums:    make(map[string]*enumDef),
+	}
+}
+
+type messageDef struct {
+	name   string
+	fields []*fieldDef
+}
+
+type fieldDef struct {
+	name     string
+	number   int
+	kind     fieldKind
+	typeName string
+	repeated bool
+	// scope is the full name of the message the field is declared
+	// in and is used to resolve relative type names
+	scope string
+	// oneof is the name of the oneof the field is a member of
+	oneof string
+}
+
+type enumDef struct {
+	name   string
+	values []int
+}
+
+type serviceDef struct {
+	name    string
+	methods []*methodDef
+}
+
+type methodDef struct {
+	name            string
+	input           string
+	output          string
+	clientStreaming bool
+	serverStreaming bool
+	scope           string
+}
+
+// fieldKind is the type of a field as defined in descriptor.proto
+type fieldKind int
+
+const (
+	kindDouble   fieldKind = 1
+	kindFloat    fieldKind = 2
+	kindInt64    fieldKind = 3
+	kindUint64   fieldKind = 4
+	kindInt32    fieldKind = 5
+	kindFixed64  fieldKind = 6
+	kindFixed32  fieldKind = 7
+	kindBool     fieldKind = 8
+	kindString   fieldKind = 9
+	kindGroup    fieldKind = 10
+	kindMessage  fieldKind = 11
+	kindBytes    fieldKind = 12
+	kindUint32   fieldKind = 13
+	kindEnum     fieldKind = 14
+	kindSfixed32 fieldKind = 15
+	kindSfixed64 fieldKind = 16
+	kindSint32   fieldKind = 17
+	kindSint64   fieldKind = 18
+)
+
+// scalarKinds maps scalar type names used in .proto files to kinds
+var scalarKinds = map[string]fieldKind{
+	"double":   kindDouble,
+	"float":    kindFloat,
+	"int64":    kindInt64,
+	"uint64":   kindUint64,
+	"int32":    kindInt32,
+	"fixed64":  kindFixed64,
+	"fixed32":  kindFixed32,
+	"bool":     kindBool,
+	"string":   kindString,
+	"bytes":    kindBytes,
+	"uint32":   kindUint32,
+	"sfixed32": kindSfixed32,
+	"sfixed64": kindSfixed64,
+	"sint32":   kindSint32,
+	"sint64":   kindSint64,
+}
+
+// joinName joins a name with its package or parent message
+func joinName(scope, name string) string {
+	if scope == "" {
+		return name
+	}
+	return scope + "." + name
+}
+
+// resolve resolves a possibly relative type name referenced in
+// scope using protobuf scoping rules and returns the full name
+func (r *registry) resolve(name, scope string) string {
+	if strings.HasPrefix(name, ".") {
+		return strings.TrimPrefix(name, ".")
+	}
+	for {
+		candidate := name
+		if scope != "" {
+			candidate = scope + "." + name
+		}
+		if _, ok := r.messages[candidate]; ok {
+			return candidate
+		}
+		if _, ok := r.enums[candidate]; ok {
+			return candidate
+		}
+		if scope == "" {
+			return name
+		}
+		if idx := strings.LastIndex(scope, "."); idx >= 0 {
+			scope = scope[:idx]
+		} else {
+			scope = ""
+		}
+	}
+}
+
+// link resolves the type names of all fields and methods and
+// sets the kind of message and enum fields
+func (r *registry) link() {
+	for _, message := range r.messages {
+		for _, field := range message.fields {
+			if field.typeName == "" {
+				continue
+			}
+			field.typeName = r.resolve(field.typeName, field.scope)
+			if field.kind != 0 {
+				continue
+			}
+			if _, ok := r.enums[field.typeName]; ok {
+				field.kind = kindEnum
+			} else {
+				field.kind = kindMessage
+			}
+		}
+	}
+	for _, service := range r.services {
+		for _, method := range service.methods {
+			method.input = r.resolve(method.input, method.scope)
+			method.output = r.resolve(method.output, method.scope)
+		}
+	}
+}
+
+// unresolvedFields returns the full names of the message and enum
+// fields whose types were not loaded
+func (r *registry) unresolvedFields() []string {
+	var unresolved []string
+	for _, message := range r.messages {
+		for _, field := range message.fields {
+			var ok bool
+			switch field.kind {
+			case kindMessage:
+				_, ok = r.messages[field.typeName]
+			case kindEnum:
+				_, ok = r.enums[field.typeName]
+			default:
+				continue
+			}
+			if !ok {
+				unresolved = append(unresolved, joinName(message.name, field.name)+" ("+field.typeName+")")
+			}
+		}
+	}
+	sort.Strings(unresolved)
+	return unresolved
+}
@@ -0,0 +1,180 @@
+package har
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/projectdiscovery/gologger"
+	"github.com/projectdiscovery/nuclei/v3/pkg/input/formats"
+	"github.com/projectdiscovery/nuclei/v3/pkg/input/types"
+	mapsutil "github.com/projectdiscovery/utils/maps"
+	urlutil "github.com/projectdiscovery/utils/url"
+)
+
+// HARFormat is a HTTP Archive (HAR) File parser
+type HARFormat struct {
+	opts formats.InputFormatOptions
+}
+
+// New creates a new HAR format parser
+func New() *HARFormat {
+	return &HARFormat{}
+}
+
+var _ formats.Format = &HARFormat{}
+
+// Name returns the name of the format
+func (j *HARFormat) Name() string {
+	return "har"
+}
+
+func (j *HARFormat) SetOptions(options formats.InputFormatOptions) {
+	j.opts = options
+}
+
+// Parse parses the input and calls the provided callback
+// function for each RawRequest it discovers.
+func (j *HARFormat) Parse(input string, resultsCb formats.ParseReqRespCallback) error {
+	file, err := os.Open(input)
+	if err != nil {
+		return errors.Wrap(err, "could not open har file")
+	}
+	defer file.Close()
+
+	var archive harFile
+	if err := json.NewDecoder(file).Decode(&archive); err != nil {
+		return errors.Wrap(err, "could not decode har file")
+	}
+
+	for _, entry := range archive.Log.Entries {
+		rr, err := entry.toRequestResponse()
+		if err != nil {
+			gologger.Warning().Msgf("har: Could not parse request %s: %s\n", entry.Request.URL, err)
+			continue
+		}
+		resultsCb(rr)
+	}
+	return nil
+}
+
+// toRequestResponse converts a har entry to a request response
+// by building the raw request from the recorded method, url, headers,
+// cookies and post data.
+func (e *harEntry) toRequestResponse() (*types.RequestResponse, error) {
+	parsed, err := urlutil.ParseAbsoluteURL(e.Request.URL, false)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse url")
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported scheme %s", parsed.Scheme)
+	}
+
+	body := e.Request.PostData.body()
+
+	var sb strings.Builder
+	path := parsed.GetRelativePath()
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	sb.WriteString(fmt.Sprintf("%s %s HTTP/1.1\r\n", e.Request.Method, path))
+	sb.WriteString(fmt.Sprintf("Host: %s\r\n", parsed.Host))
+
+	var hasCookie, hasContentType bool
+	for _, header := range e.Request.Headers {
+		// http/2 pseudo headers and hop-by-hop headers are not
+		// part of a http/1.1 raw request
+		if strings.HasPrefix(header.Name, ":") || strings.EqualFold(header.Name, "Host") {
+			continue
+		}
+		switch {
+		case strings.EqualFold(header.Name, "Cookie"):
+			hasCookie = true
+		case strings.EqualFold(header.Name, "Content-Type"):
+			hasContentType = true
+		case strings.EqualFold(header.Name, "Content-Length"):
+			// recomputed from the body below
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("%s: %s\r\n", header.Name, header.Value))
+	}
+	if !hasCookie && len(e.Request.Cookies) > 0 {
+		cookies := make([]string, 0, len(e.Request.Cookies))
+		for _, cookie := range e.Request.Cookies {
+			cookies = append(cookies, cookie.Name+"="+cookie.Value)
+		}
+		sb.WriteString(fmt.Sprintf("Cookie: %s\r\n", strings.Join(cookies, "; ")))
+	}
+	if body != "" {
+		if !hasContentType && e.Request.PostData.MimeType != "" {
+			sb.WriteString(fmt.Sprintf("Content-Type: %s\r\n", e.Request.PostData.MimeType))
+		}
+		sb.WriteString(fmt.Sprintf("Content-Length: %d\r\n", len(body)))
+	}
+	sb.WriteString("\r\n")
+	sb.WriteString(body)
+
+	rr, err := types.ParseRawRequestWithURL(sb.String(), e.Request.URL)
+	if err != nil {
+		return nil, err
+	}
+	// ParseRawRequest trims trailing newlines which may be
+	// significant for recorded bodies
+	rr.Request.Body = body
+	rr.Response = e.Response.toHttpResponse()
+	return rr, nil
+}
+
+// toHttpResponse converts a har response to a http response
+func (r *harResponse) toHttpResponse() *types.HttpResponse {
+	if r.Status == 0 {
+		// requests that were blocked or never completed
+		// have a zero status in har files
+		return nil
+	}
+	body := r.Content.Text
+	if r.Content.Encoding == "base64" {
+		if decoded, err := base64.StdEncoding.DecodeString(body); err == nil {
+			body = string(decoded)
+		}
+	}
+
+	resp := &types.HttpResponse{
+		StatusCode: r.Status,
+		Headers:    mapsutil.NewOrderedMap[string, string](),
+		Body:       body,
+	}
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("HTTP/1.1 %d %s\r\n", r.Status, r.StatusText))
+	for _, header := range r.Headers {
+		if strings.HasPrefix(header.Name, ":") {
+			continue
+		}
+		resp.Headers.Set(header.Name, header.Value)
+		sb.WriteString(fmt.Sprintf("%s: %s\r\n", header.Name, header.Value))
+	}
+	sb.WriteString("\r\n")
+	sb.WriteString(body)
+	resp.Raw = sb.String()
+	return resp
+}
+
+// body returns the request body from post data. If no text
+// is recorded, params are encoded as url-encoded form data.
+func (p *harPostData) body() string {
+	if p == nil {
+		return ""
+	}
+	if p.Text != "" || len(p.Params) == 0 {
+		return p.Text
+	}
+	values := make(url.Values)
+	for _, param := range p.Params {
+		values.Add(param.Name, param.Value)
+	}
+	return values.Encode()
+}
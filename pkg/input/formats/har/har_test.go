@@ -0,0 +1,56 @@
+package har
+
+import (
+	"testing"
+
+	"github.com/projectdiscovery/nuclei/v3/pkg/input/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHARParse(t *testing.T) {
+	format := New()
+
+	harInputFile := "../testdata/ginandjuice.har"
+
+	var requests []*types.RequestResponse
+	err := format.Parse(harInputFile, func(request *types.RequestResponse) bool {
+		requests = append(requests, request)
+		return false
+	})
+	require.Nil(t, err, "could not parse har file")
+	require.Len(t, requests, 3, "invalid number of requests")
+
+	var gotURLs []string
+	for _, request := range requests {
+		gotURLs = append(gotURLs, request.URL.String())
+	}
+	require.Equal(t, []string{
+		"https://ginandjuice.shop/catalog/product?productId=1",
+		"https://ginandjuice.shop/catalog/product/stock",
+		"https://ginandjuice.shop/login",
+	}, gotURLs, "could not get har urls")
+
+	t.Run("cookies", func(t *testing.T) {
+		cookie, ok := requests[0].Request.Headers.Get("Cookie")
+		require.True(t, ok, "could not get cookie header")
+		require.Equal(t, "session=Zx1fVLr0rPPRm5Tm; AWSALB=abcdef", cookie)
+		_, ok = requests[0].Request.Headers.Get(":authority")
+		require.False(t, ok, "pseudo header should not be included")
+	})
+
+	t.Run("body", func(t *testing.T) {
+		require.Equal(t, "POST", requests[1].Request.Method)
+		require.Equal(t, `<?xml version="1.0" encoding="UTF-8"?><stockCheck><productId>1</productId><storeId>1</storeId></stockCheck>`, requests[1].Request.Body)
+		require.Equal(t, "password=hunter2&username=carlos", requests[2].Request.Body)
+		contentType, _ := requests[2].Request.Headers.Get("Content-Type")
+		require.Equal(t, "application/x-www-form-urlencoded", contentType)
+	})
+
+	t.Run("response", func(t *testing.T) {
+		require.NotNil(t, requests[0].Response)
+		require.Equal(t, 200, requests[0].Response.StatusCode)
+		require.Equal(t, "<html>Product page</html>\n", requests[0].Response.Body)
+		require.Equal(t, "498", requests[1].Response.Body, "could not decode base64 response")
+		require.Nil(t, requests[2].Response, "incomplete response should be skipped")
+	})
+}
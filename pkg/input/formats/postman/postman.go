@@ -0,0 +1,338 @@
+package postman
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/projectdiscovery/gologger"
+	"github.com/projectdiscovery/nuclei/v3/pkg/input/formats"
+	httpTypes "github.com/projectdiscovery/nuclei/v3/pkg/input/types"
+	"github.com/projectdiscovery/nuclei/v3/pkg/types"
+)
+
+// PostmanFormat is a Postman Collection v2.1 File parser
+type PostmanFormat struct {
+	opts formats.InputFormatOptions
+}
+
+// New creates a new Postman Collection format parser
+func New() *PostmanFormat {
+	return &PostmanFormat{}
+}
+
+var _ formats.Format = &PostmanFormat{}
+
+// Name returns the name of the format
+func (j *PostmanFormat) Name() string {
+	return "postman"
+}
+
+func (j *PostmanFormat) SetOptions(options formats.InputFormatOptions) {
+	j.opts = options
+}
+
+// Parse parses the input and calls the provided callback
+// function for each RawRequest it discovers.
+func (j *PostmanFormat) Parse(input string, resultsCb formats.ParseReqRespCallback) error {
+	file, err := os.Open(input)
+	if err != nil {
+		return errors.Wrap(err, "could not open postman collection file")
+	}
+	defer file.Close()
+
+	var coll collection
+	if err := json.NewDecoder(file).Decode(&coll); err != nil {
+		return errors.Wrap(err, "could not decode postman collection")
+	}
+	if coll.Info.Schema != "" && !strings.Contains(coll.Info.Schema, "v2.") {
+		return errors.Errorf("unsupported postman collection schema %s", coll.Info.Schema)
+	}
+
+	w := &walker{
+		opts:     j.opts,
+		callback: resultsCb,
+	}
+	return w.walk(coll.Item, coll.Auth, variablesToMap(nil, coll.Variable))
+}
+
+// walker walks the items of a collection and generates
+// requests for each of them
+type walker struct {
+	opts     formats.InputFormatOptions
+	callback formats.ParseReqRespCallback
+}
+
+// walk walks the items recursively with the inherited auth
+// and variables of the parent folder or collection
+func (w *walker) walk(items []*item, parentAuth *auth, vars map[string]interface{}) error {
+	for _, it := range items {
+		itemAuth := parentAuth
+		if it.Auth != nil && it.Auth.Type != "inherit" {
+			itemAuth = it.Auth
+		}
+		itemVars := variablesToMap(vars, it.Variable)
+
+		if len(it.Item) > 0 {
+			if err := w.walk(it.Item, itemAuth, itemVars); err != nil {
+				return err
+			}
+			continue
+		}
+		if it.Request == nil {
+			continue
+		}
+		if it.Request.Auth != nil && it.Request.Auth.Type != "inherit" {
+			itemAuth = it.Request.Auth
+		}
+		rr, err := w.generateRequest(it, itemAuth, itemVars)
+		if err != nil {
+			if w.opts.SkipFormatValidation {
+				gologger.Verbose().Msgf("postman: skipping request %s: %s\n", it.Name, err)
+				continue
+			}
+			return errors.Wrapf(err, "could not generate request %s", it.Name)
+		}
+		w.callback(rr)
+	}
+	return nil
+}
+
+// generateRequest generates a request from a postman collection item
+func (w *walker) generateRequest(it *item, itemAuth *auth, vars map[string]interface{}) (*httpTypes.RequestResponse, error) {
+	// user provided variables take precedence over
+	// the ones defined in the collection
+	r := newResolver(vars, w.opts.Variables)
+
+	req := it.Request
+	if req.URL == nil || req.URL.Raw == "" {
+		return nil, errors.New("no url found for request")
+	}
+	rawURL := r.resolve(req.URL.Raw)
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "http://" + rawURL
+	}
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse url")
+	}
+	// replace path variables (/users/:id)
+	if len(req.URL.Variable) > 0 {
+		segments := strings.Split(parsed.Path, "/")
+		for i, segment := range segments {
+			if !strings.HasPrefix(segment, ":") {
+				continue
+			}
+			for _, v := range req.URL.Variable {
+				if v.Key == segment[1:] {
+					segments[i] = r.resolve(v.Value)
+				}
+			}
+		}
+		parsed.Path = strings.Join(segments, "/")
+		parsed.RawPath = ""
+	}
+
+	method := strings.ToUpper(req.Method)
+	if method == "" {
+		method = http.MethodGet
+	}
+	httpReq, err := http.NewRequest(method, parsed.String(), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create request")
+	}
+	for _, header := range req.Header {
+		if header.Disabled {
+			continue
+		}
+		httpReq.Header.Set(r.resolve(header.Key), r.resolve(header.Value))
+	}
+	if err := setBody(httpReq, req.Body, r); err != nil {
+		return nil, err
+	}
+	if err := setAuth(httpReq, itemAuth, r); err != nil {
+		return nil, err
+	}
+	if missing := r.missing(); len(missing) > 0 {
+		return nil, errors.Errorf("missing variables %s (use -var or -postman-env to provide values)", strings.Join(missing, ", "))
+	}
+
+	dumped, err := httputil.DumpRequestOut(httpReq, true)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not dump request")
+	}
+	rr, err := httpTypes.ParseRawRequestWithURL(string(dumped), httpReq.URL.String())
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse raw request")
+	}
+	return rr, nil
+}
+
+// setBody sets the body of the request based on the body mode
+func setBody(req *http.Request, b *body, r *resolver) error {
+	if b == nil {
+		return nil
+	}
+	var contentType, data string
+
+	switch b.Mode {
+	case "raw":
+		data = r.resolve(b.Raw)
+		if b.Options != nil {
+			contentType = rawLanguageContentTypes[b.Options.Raw.Language]
+		}
+	case "urlencoded":
+		values := make(url.Values)
+		for _, param := range b.URLEncoded {
+			if param.Disabled {
+				continue
+			}
+			values.Add(r.resolve(param.Key), r.resolve(param.Value))
+		}
+		data = values.Encode()
+		contentType = "application/x-www-form-urlencoded"
+	case "formdata":
+		buffer := &bytes.Buffer{}
+		multipartWriter := multipart.NewWriter(buffer)
+		for _, field := range b.FormData {
+			if field.Disabled {
+				continue
+			}
+			key := r.resolve(field.Key)
+			if field.Type == "file" {
+				// file contents are not part of the collection
+				// so the key is used as placeholder content
+				if writer, err := multipartWriter.CreateFormFile(key, key); err == nil {
+					_, _ = writer.Write([]byte(key))
+				}
+				continue
+			}
+			_ = multipartWriter.WriteField(key, r.resolve(field.Value))
+		}
+		multipartWriter.Close()
+		data = buffer.String()
+		contentType = multipartWriter.FormDataContentType()
+	case "graphql":
+		if b.GraphQL == nil {
+			return nil
+		}
+		payload := map[string]interface{}{"query": r.resolve(b.GraphQL.Query)}
+		if variables := strings.TrimSpace(r.resolve(b.GraphQL.Variables)); variables != "" {
+			var parsed interface{}
+			if err := json.Unmarshal([]byte(variables), &parsed); err != nil {
+				return errors.Wrap(err, "could not parse graphql variables")
+			}
+			payload["variables"] = parsed
+		}
+		bin, err := json.Marshal(payload)
+		if err != nil {
+			return errors.Wrap(err, "could not marshal graphql body")
+		}
+		data = string(bin)
+		contentType = "application/json"
+	case "", "none", "file":
+		return nil
+	default:
+		gologger.Verbose().Msgf("postman: unsupported body mode %s\n", b.Mode)
+		return nil
+	}
+	if data == "" {
+		return nil
+	}
+	if req.Header.Get("Content-Type") == "" && contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	req.Body = io.NopCloser(strings.NewReader(data))
+	req.ContentLength = int64(len(data))
+	return nil
+}
+
+// rawLanguageContentTypes maps raw body languages to content types
+var rawLanguageContentTypes = map[string]string{
+	"json":       "application/json",
+	"xml":        "application/xml",
+	"html":       "text/html",
+	"text":       "text/plain",
+	"javascript": "application/javascript",
+}
+
+// setAuth sets the authentication headers on the request from
+// collection, folder or request level auth
+func setAuth(req *http.Request, a *auth, r *resolver) error {
+	if a == nil {
+		return nil
+	}
+	switch a.Type {
+	case "noauth", "":
+		return nil
+	case "basic":
+		username := r.resolve(a.param(a.Basic, "username"))
+		password := r.resolve(a.param(a.Basic, "password"))
+		credentials := base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+		req.Header.Set("Authorization", "Basic "+credentials)
+	case "bearer":
+		token := r.resolve(a.param(a.Bearer, "token"))
+		req.Header.Set("Authorization", "Bearer "+token)
+	case "apikey":
+		key := r.resolve(a.param(a.APIKey, "key"))
+		value := r.resolve(a.param(a.APIKey, "value"))
+		if key == "" {
+			return errors.New("no key found for apikey auth")
+		}
+		if a.param(a.APIKey, "in") == "query" {
+			query := req.URL.Query()
+			query.Set(key, value)
+			req.URL.RawQuery = query.Encode()
+		} else {
+			req.Header.Set(key, value)
+		}
+	default:
+		gologger.Verbose().Msgf("postman: unsupported auth type %s\n", a.Type)
+	}
+	return nil
+}
+
+// variablesToMap merges collection or folder variables on
+// top of the parent variables
+func variablesToMap(parent map[string]interface{}, vars []*variable) map[string]interface{} {
+	merged := make(map[string]interface{}, len(parent)+len(vars))
+	for k, v := range parent {
+		merged[k] = v
+	}
+	for _, v := range vars {
+		if v.Disabled {
+			continue
+		}
+		merged[v.Key] = v.Value
+	}
+	return merged
+}
+
+// ReadEnvironmentFile reads a postman environment file and
+// returns the enabled variables as a map
+func ReadEnvironmentFile(file string) (map[string]interface{}, error) {
+	bin, err := os.ReadFile(file)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read postman environment file")
+	}
+	var env environment
+	if err := json.Unmarshal(bin, &env); err != nil {
+		return nil, errors.Wrap(err, "could not decode postman environment file")
+	}
+	vars := make(map[string]interface{}, len(env.Values))
+	for _, value := range env.Values {
+		if value.Enabled != nil && !*value.Enabled {
+			continue
+		}
+		vars[value.Key] = types.ToString(value.Value)
+	}
+	return vars, nil
+}
@@ -0,0 +1,105 @@
+package postman
+
+import (
+	"testing"
+
+	"github.com/projectdiscovery/nuclei/v3/pkg/input/formats"
+	"github.com/projectdiscovery/nuclei/v3/pkg/input/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostmanParse(t *testing.T) {
+	format := New()
+
+	postmanInputFile := "../testdata/postman.json"
+
+	var gotMethodsToURLs []string
+	err := format.Parse(postmanInputFile, func(request *types.RequestResponse) bool {
+		gotMethodsToURLs = append(gotMethodsToURLs, request.Request.Method+" "+request.URL.String())
+		return false
+	})
+	require.Nil(t, err, "could not parse postman collection")
+
+	var expectedURLs = []string{
+		"GET http://127.0.0.1:8000/api/v1/search/",
+		"GET http://127.0.0.1:8000/api/v1/search/?projectId=1,2",
+		"GET http://127.0.0.1:8000/api/v1/search/?projectId=1,2&assetId=1,2",
+		"POST http://127.0.0.1:8000/api/v1/search/",
+	}
+	require.ElementsMatch(t, expectedURLs, gotMethodsToURLs, "could not get postman urls")
+}
+
+func TestPostmanParseVariablesAndAuth(t *testing.T) {
+	envVars, err := ReadEnvironmentFile("../testdata/postman_environment.json")
+	require.Nil(t, err, "could not read environment file")
+
+	format := New()
+	format.SetOptions(formats.InputFormatOptions{
+		Variables: envVars,
+	})
+
+	requests := make(map[string]*types.RequestResponse)
+	err = format.Parse("../testdata/postman_nested.json", func(request *types.RequestResponse) bool {
+		requests[request.Request.Method+" "+request.URL.String()] = request
+		return false
+	})
+	require.Nil(t, err, "could not parse postman collection")
+	require.Len(t, requests, 4, "invalid number of requests")
+
+	header := func(rr *types.RequestResponse, key string) string {
+		value, _ := rr.Request.Headers.Get(key)
+		return value
+	}
+
+	getUser := requests["GET http://localhost:5000/api/users/admin?verbose=true"]
+	require.NotNil(t, getUser, "could not resolve path variables")
+	require.Equal(t, "Bearer staging-token", header(getUser, "Authorization"))
+	require.Len(t, header(getUser, "X-Request-Id"), 36, "could not resolve dynamic variable")
+	require.Empty(t, header(getUser, "X-Disabled"))
+
+	createUser := requests["POST http://localhost:5000/api/users"]
+	require.NotNil(t, createUser)
+	require.Equal(t, "Basic YWRtaW46c3RhZ2luZy1wYXNzd29yZA==", header(createUser, "Authorization"), "could not get folder auth")
+	require.Equal(t, "application/json", header(createUser, "Content-Type"))
+	require.Equal(t, `{"username": "admin", "email": "admin@example.com"}`, createUser.Request.Body)
+
+	deleteUser := requests["DELETE http://localhost:5000/api/users/1"]
+	require.NotNil(t, deleteUser)
+	require.Equal(t, "staging-api-key", header(deleteUser, "X-Api-Key"), "could not get request auth")
+	require.Empty(t, header(deleteUser, "Authorization"))
+
+	login := requests["POST http://localhost:5000/api/login"]
+	require.NotNil(t, login)
+	require.Empty(t, header(login, "Authorization"), "noauth should not set auth")
+	require.Equal(t, "password=staging-password&username=admin", login.Request.Body)
+
+	t.Run("user-vars-override", func(t *testing.T) {
+		format.SetOptions(formats.InputFormatOptions{
+			Variables: map[string]interface{}{"host": "override.local", "token": "x", "password": "y", "apiKey": "z"},
+		})
+		var urls []string
+		err := format.Parse("../testdata/postman_nested.json", func(request *types.RequestResponse) bool {
+			urls = append(urls, request.URL.Host)
+			return false
+		})
+		require.Nil(t, err)
+		require.Equal(t, []string{"override.local", "override.local", "override.local", "override.local"}, urls)
+	})
+
+	t.Run("missing-vars", func(t *testing.T) {
+		format.SetOptions(formats.InputFormatOptions{})
+		err := format.Parse("../testdata/postman_nested.json", func(request *types.RequestResponse) bool {
+			return false
+		})
+		require.NotNil(t, err, "missing variables should return error")
+
+		format.SetOptions(formats.InputFormatOptions{SkipFormatValidation: true})
+		count := 0
+		err = format.Parse("../testdata/postman_nested.json", func(request *types.RequestResponse) bool {
+			count++
+			return false
+		})
+		require.Nil(t, err)
+		require.Equal(t, 0, count, "requests with missing variables should be skipped")
+	})
+}
@@ -0,0 +1,150 @@
+package postman
+
+import (
+	"encoding/json"
+)
+
+// collection is a Postman Collection v2.1 document.
+//
+// Only the fields required for generating requests are decoded.
+// Reference: https://schema.postman.com/collection/json/v2.1.0/draft-07/docs/index.html
+type collection struct {
+	Info     collectionInfo `json:"info"`
+	Item     []*item        `json:"item"`
+	Auth     *auth          `json:"auth,omitempty"`
+	Variable []*variable    `json:"variable,omitempty"`
+}
+
+type collectionInfo struct {
+	Name   string `json:"name"`
+	Schema string `json:"schema"`
+}
+
+// item is either a request or a folder containing other items
+type item struct {
+	Name     string      `json:"name"`
+	Request  *request    `json:"request,omitempty"`
+	Item     []*item     `json:"item,omitempty"`
+	Auth     *auth       `json:"auth,omitempty"`
+	Variable []*variable `json:"variable,omitempty"`
+}
+
+type request struct {
+	Method string      `json:"method"`
+	Header []*keyValue `json:"header"`
+	Body   *body       `json:"body,omitempty"`
+	URL    *requestURL `json:"url"`
+	Auth   *auth       `json:"auth,omitempty"`
+}
+
+// UnmarshalJSON unmarshals a request which can either be
+// a string containing the url or a request object
+func (r *request) UnmarshalJSON(data []byte) error {
+	var rawURL string
+	if err := json.Unmarshal(data, &rawURL); err == nil {
+		r.Method = "GET"
+		r.URL = &requestURL{Raw: rawURL}
+		return nil
+	}
+	type requestAlias request
+	var alias requestAlias
+	if err := json.Unmarshal(data, &alias); err != nil {
+		return err
+	}
+	*r = request(alias)
+	return nil
+}
+
+type requestURL struct {
+	Raw   string      `json:"raw"`
+	Query []*keyValue `json:"query,omitempty"`
+	// Variable contains path variables (:name) of the url
+	Variable []*keyValue `json:"variable,omitempty"`
+}
+
+// UnmarshalJSON unmarshals a url which can either be
+// a string or a url object
+func (u *requestURL) UnmarshalJSON(data []byte) error {
+	var rawURL string
+	if err := json.Unmarshal(data, &rawURL); err == nil {
+		u.Raw = rawURL
+		return nil
+	}
+	type urlAlias requestURL
+	var alias urlAlias
+	if err := json.Unmarshal(data, &alias); err != nil {
+		return err
+	}
+	*u = requestURL(alias)
+	return nil
+}
+
+type body struct {
+	Mode       string       `json:"mode"`
+	Raw        string       `json:"raw,omitempty"`
+	URLEncoded []*keyValue  `json:"urlencoded,omitempty"`
+	FormData   []*formData  `json:"formdata,omitempty"`
+	GraphQL    *graphqlBody `json:"graphql,omitempty"`
+	Options    *bodyOptions `json:"options,omitempty"`
+}
+
+type bodyOptions struct {
+	Raw struct {
+		Language string `json:"language"`
+	} `json:"raw"`
+}
+
+type graphqlBody struct {
+	Query     string `json:"query"`
+	Variables string `json:"variables"`
+}
+
+type formData struct {
+	Key         string `json:"key"`
+	Value       string `json:"value"`
+	Type        string `json:"type"`
+	ContentType string `json:"contentType,omitempty"`
+	Disabled    bool   `json:"disabled,omitempty"`
+}
+
+type keyValue struct {
+	Key      string `json:"key"`
+	Value    string `json:"value"`
+	Disabled bool   `json:"disabled,omitempty"`
+}
+
+type variable struct {
+	Key      string      `json:"key"`
+	Value    interface{} `json:"value"`
+	Disabled bool        `json:"disabled,omitempty"`
+}
+
+// auth is the authentication helper of a collection, folder or request
+type auth struct {
+	Type   string      `json:"type"`
+	Basic  []*variable `json:"basic,omitempty"`
+	Bearer []*variable `json:"bearer,omitempty"`
+	APIKey []*variable `json:"apikey,omitempty"`
+}
+
+// param returns the value of auth parameter for the given key
+func (a *auth) param(params []*variable, key string) string {
+	for _, p := range params {
+		if p.Key == key {
+			if v, ok := p.Value.(string); ok {
+				return v
+			}
+		}
+	}
+	return ""
+}
+
+// environment is a Postman environment file
+type environment struct {
+	Name   string `json:"name"`
+	Values []struct {
+		Key     string      `json:"key"`
+		Value   interface{} `json:"value"`
+		Enabled *bool       `json:"enabled,omitempty"`
+	} `json:"values"`
+}
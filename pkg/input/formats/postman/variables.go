@@ -0,0 +1,83 @@
+package postman
+
+import (
+	"math/rand"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/projectdiscovery/nuclei/v3/pkg/types"
+)
+
+// maxResolveDepth is the maximum depth of nested variable references
+const maxResolveDepth = 5
+
+var variableRegex = regexp.MustCompile(`{{\s*([^{}\s]+)\s*}}`)
+
+// resolver resolves {{variable}} references in collection values
+// and keeps track of variables that could not be resolved
+type resolver struct {
+	vars       map[string]interface{}
+	unresolved map[string]struct{}
+}
+
+// newResolver creates a new resolver where values from the later
+// maps take precedence over the earlier ones
+func newResolver(maps ...map[string]interface{}) *resolver {
+	r := &resolver{
+		vars:       make(map[string]interface{}),
+		unresolved: make(map[string]struct{}),
+	}
+	for _, m := range maps {
+		for k, v := range m {
+			r.vars[k] = v
+		}
+	}
+	return r
+}
+
+// resolve replaces all variable references in the given value
+func (r *resolver) resolve(value string) string {
+	for i := 0; i < maxResolveDepth && variableRegex.MatchString(value); i++ {
+		value = variableRegex.ReplaceAllStringFunc(value, func(match string) string {
+			name := variableRegex.FindStringSubmatch(match)[1]
+			if v, ok := r.vars[name]; ok {
+				return types.ToString(v)
+			}
+			if v, ok := dynamicVariable(name); ok {
+				return v
+			}
+			r.unresolved[name] = struct{}{}
+			return match
+		})
+	}
+	return value
+}
+
+// missing returns the sorted list of unresolved variables
+func (r *resolver) missing() []string {
+	missing := make([]string, 0, len(r.unresolved))
+	for name := range r.unresolved {
+		missing = append(missing, name)
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+// dynamicVariable returns the value of commonly used postman
+// dynamic variables like {{$guid}} or {{$timestamp}}
+func dynamicVariable(name string) (string, bool) {
+	switch name {
+	case "$guid", "$randomUUID":
+		return uuid.New().String(), true
+	case "$timestamp":
+		return strconv.FormatInt(time.Now().Unix(), 10), true
+	case "$isoTimestamp":
+		return time.Now().UTC().Format(time.RFC3339), true
+	case "$randomInt":
+		return strconv.Itoa(rand.Intn(1001)), true
+	}
+	return "", false
+}
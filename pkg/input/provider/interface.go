@@ -7,6 +7,7 @@ import (
 
 	"github.com/projectdiscovery/gologger"
 	"github.com/projectdiscovery/nuclei/v3/pkg/input/formats"
+	"github.com/projectdiscovery/nuclei/v3/pkg/input/formats/postman"
 	"github.com/projectdiscovery/nuclei/v3/pkg/input/provider/http"
 	"github.com/projectdiscovery/nuclei/v3/pkg/input/provider/list"
 	"github.com/projectdiscovery/nuclei/v3/pkg/input/types"
@@ -99,6 +100,13 @@ func NewInputProvider(opts InputOptions) (InputProvider, error) {
 			}
 		}
 	}
+	if opts.Options.PostmanEnvironmentFile != "" {
+		envVars, err := postman.ReadEnvironmentFile(opts.Options.PostmanEnvironmentFile)
+		if err != nil {
+			return nil, err
+		}
+		extraVars = generators.MergeMaps(extraVars, envVars)
+	}
 
 	// check if input provider is supported
 	if strings.EqualFold(opts.Options.InputFileMode, "list") {
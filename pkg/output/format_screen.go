@@ -126,6 +126,12 @@ func (w *StandardWriter) formatScreen(output *ResultEvent) []byte {
 		builder.WriteString(" [")
 		builder.WriteString(output.FuzzingMethod)
 		builder.WriteString("]")
+
+		if output.AnalyzerDetails != "" {
+			builder.WriteString(" [")
+			builder.WriteString(w.aurora.BrightYellow(output.AnalyzerDetails).String())
+			builder.WriteString("]")
+		}
 	}
 	return builder.Bytes()
 }
@@ -201,6 +201,9 @@ type ResultEvent struct {
 	FuzzingMethod    string `json:"fuzzing_method,omitempty"`
 	FuzzingParameter string `json:"fuzzing_parameter,omitempty"`
 	FuzzingPosition  string `json:"fuzzing_position,omitempty"`
+	// AnalyzerDetails contains the data collected by the analyzer of
+	// the fuzzing rule (ex: response times) when it found the issue
+	AnalyzerDetails string `json:"analyzer_details,omitempty"`
 
 	FileToIndexPosition map[string]int `json:"-"`
 	TemplateVerifier    string         `json:"-"`
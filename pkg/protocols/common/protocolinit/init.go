@@ -38,5 +38,6 @@ func Init(options *types.Options) error {
 }
 
 func Close() {
+	httpclientpool.Close()
 	protocolstate.Close()
 }
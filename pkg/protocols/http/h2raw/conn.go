@@ -0,0 +1,399 @@
+package h2raw
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/hpack"
+)
+
+// drainTimeout is the time to wait for connection level frames once all
+// frames were written and no stream is awaiting a response
+var drainTimeout = 500 * time.Millisecond
+
+// Response contains the frames received on a connection
+type Response struct {
+	// Streams contains the responses of the streams by identifier
+	Streams map[uint32]*StreamResponse
+	// GoAway is the last GOAWAY frame received if any
+	GoAway *GoAway
+	// RSTStreamCount is the number of RST_STREAM frames received
+	RSTStreamCount int
+	// ConnectionClosed is true if the server closed the connection
+	ConnectionClosed bool
+
+	order []uint32
+	log   strings.Builder
+}
+
+// StreamResponse is the response received on a single stream
+type StreamResponse struct {
+	ID      uint32
+	Status  string
+	Headers []hpack.HeaderField
+	Body    bytes.Buffer
+	// Reset is true if the server reset the stream with ErrorCode
+	Reset     bool
+	ErrorCode http2.ErrCode
+}
+
+// GoAway is a GOAWAY frame received from the server
+type GoAway struct {
+	LastStreamID uint32
+	ErrorCode    http2.ErrCode
+	DebugData    string
+}
+
+// StreamIDs returns the identifiers of the streams in the order of their first frame
+func (r *Response) StreamIDs() []uint32 {
+	return r.order
+}
+
+// String returns the received frames in a human readable format
+func (r *Response) String() string {
+	return r.log.String()
+}
+
+// HeadersString returns the headers of the stream in `name: value` format
+func (s *StreamResponse) HeadersString() string {
+	builder := &strings.Builder{}
+	for _, header := range s.Headers {
+		builder.WriteString(header.Name + ": " + header.Value + "\n")
+	}
+	return builder.String()
+}
+
+func (r *Response) stream(id uint32) *StreamResponse {
+	if stream, ok := r.Streams[id]; ok {
+		return stream
+	}
+	stream := &StreamResponse{ID: id}
+	r.Streams[id] = stream
+	r.order = append(r.order, id)
+	return stream
+}
+
+// conn is a raw http2 client connection
+type conn struct {
+	conn   net.Conn
+	framer *http2.Framer
+
+	// writeMu serializes writes of the request frames and the acknowledgements
+	writeMu sync.Mutex
+	encBuf  bytes.Buffer
+	encoder *hpack.Encoder
+
+	// mu protects the streams awaiting a response
+	mu       sync.Mutex
+	pending  map[uint32]struct{}
+	reset    map[uint32]struct{}
+	finished bool
+	notify   chan struct{}
+}
+
+// Do sends the frames of the request on an established connection and
+// reads the frames sent by the server until all streams are finished,
+// the connection is closed or the timeout expires.
+func (r *Request) Do(netConn net.Conn, timeout time.Duration) (*Response, error) {
+	if r.readTimeout > 0 {
+		timeout = r.readTimeout
+	}
+	c := &conn{
+		conn:    netConn,
+		framer:  http2.NewFramer(netConn, netConn),
+		pending: make(map[uint32]struct{}),
+		reset:   make(map[uint32]struct{}),
+		notify:  make(chan struct{}, 1),
+	}
+	c.framer.AllowIllegalWrites = true
+	c.framer.AllowIllegalReads = true
+	c.encoder = hpack.NewEncoder(&c.encBuf)
+
+	deadline := time.Now().Add(timeout)
+	_ = netConn.SetDeadline(deadline)
+	if _, err := io.WriteString(netConn, http2.ClientPreface); err != nil {
+		return nil, fmt.Errorf("could not write client preface: %w", err)
+	}
+	if err := c.framer.WriteSettings(); err != nil {
+		return nil, fmt.Errorf("could not write settings: %w", err)
+	}
+
+	go c.writeFrames(r)
+	response := &Response{Streams: make(map[uint32]*StreamResponse)}
+	c.readFrames(response, deadline)
+	return response, nil
+}
+
+// writeFrames writes the frames of the request. Write errors are ignored
+// as the server closing the connection is part of the response.
+func (c *conn) writeFrames(r *Request) {
+	defer func() {
+		c.mu.Lock()
+		c.finished = true
+		c.mu.Unlock()
+		c.wake()
+	}()
+
+	for repetition := 0; repetition < repeat(r.Repeat); repetition++ {
+		for i, frame := range r.Frames {
+			streamID := frame.streamID(repetition)
+			for j := 0; j < repeat(frame.Repeat); j++ {
+				if err := c.writeFrame(frame, streamID, r.endHeaders(i)); err != nil {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (c *conn) writeFrame(frame *Frame, streamID uint32, endHeaders bool) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	switch frame.Type {
+	case FrameHeaders:
+		c.mu.Lock()
+		if _, ok := c.reset[streamID]; !ok && streamID != 0 {
+			c.pending[streamID] = struct{}{}
+		}
+		c.mu.Unlock()
+		return c.framer.WriteHeaders(http2.HeadersFrameParam{
+			StreamID:      streamID,
+			BlockFragment: c.encodeHeaders(frame.Headers),
+			EndStream:     frame.EndStream,
+			EndHeaders:    endHeaders,
+		})
+	case FrameContinuation:
+		return c.framer.WriteContinuation(streamID, endHeaders, c.encodeHeaders(frame.Headers))
+	case FrameData:
+		return c.framer.WriteData(streamID, frame.EndStream, []byte(frame.Data))
+	case FrameRSTStream:
+		c.mu.Lock()
+		delete(c.pending, streamID)
+		c.reset[streamID] = struct{}{}
+		c.mu.Unlock()
+		return c.framer.WriteRSTStream(streamID, frame.errCode)
+	case FrameSettings:
+		payload := make([]byte, 0, 6*len(frame.settings))
+		for _, setting := range frame.settings {
+			payload = binary.BigEndian.AppendUint16(payload, uint16(setting.ID))
+			payload = binary.BigEndian.AppendUint32(payload, setting.Val)
+		}
+		return c.framer.WriteRawFrame(http2.FrameSettings, 0, streamID, payload)
+	case FramePing:
+		payload := make([]byte, 8)
+		copy(payload, frame.Data)
+		return c.framer.WriteRawFrame(http2.FramePing, 0, streamID, payload)
+	case FrameWindowUpdate:
+		return c.framer.WriteWindowUpdate(streamID, frame.Increment)
+	case FramePriority:
+		return c.framer.WritePriority(streamID, http2.PriorityParam{Weight: 15})
+	case FrameGoAway:
+		payload := binary.BigEndian.AppendUint32(nil, frame.LastStream)
+		payload = binary.BigEndian.AppendUint32(payload, uint32(frame.errCode))
+		payload = append(payload, frame.Data...)
+		return c.framer.WriteRawFrame(http2.FrameGoAway, 0, streamID, payload)
+	}
+	return fmt.Errorf("unknown frame type %q", frame.Type)
+}
+
+// encodeHeaders hpack encodes headers without any normalization
+func (c *conn) encodeHeaders(headers []string) []byte {
+	c.encBuf.Reset()
+	for _, header := range headers {
+		name, value, _ := splitHeader(header)
+		_ = c.encoder.WriteField(hpack.HeaderField{Name: name, Value: value})
+	}
+	return append([]byte(nil), c.encBuf.Bytes()...)
+}
+
+// wake unblocks the reader once all frames were written
+func (c *conn) wake() {
+	select {
+	case c.notify <- struct{}{}:
+	default:
+	}
+}
+
+// done returns true if all frames were written and no stream awaits a response
+func (c *conn) done() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.finished && len(c.pending) == 0
+}
+
+func (c *conn) finishStream(id uint32) {
+	c.mu.Lock()
+	delete(c.pending, id)
+	c.mu.Unlock()
+}
+
+// readFrames reads the frames sent by the server into the response
+func (c *conn) readFrames(response *Response, deadline time.Time) {
+	var current *StreamResponse
+	decoder := hpack.NewDecoder(4096, func(field hpack.HeaderField) {
+		if current == nil {
+			return
+		}
+		if field.Name == ":status" {
+			current.Status = field.Value
+		}
+		current.Headers = append(current.Headers, field)
+		fmt.Fprintf(&response.log, "%s: %s\n", field.Name, field.Value)
+	})
+
+	// the reader is unblocked by shortening the deadline once
+	// all frames were written and no response is pending
+	go func() {
+		<-c.notify
+		if c.done() {
+			_ = c.conn.SetReadDeadline(earliest(deadline, time.Now().Add(drainTimeout)))
+		}
+	}()
+
+	for {
+		frame, err := c.framer.ReadFrame()
+		if err != nil {
+			if isClosed(err) {
+				response.ConnectionClosed = true
+				response.log.WriteString("connection closed\n")
+			} else if !isTimeout(err) {
+				fmt.Fprintf(&response.log, "error: %s\n", err)
+			}
+			return
+		}
+		header := frame.Header()
+		fmt.Fprintf(&response.log, "%s stream=%d%s\n", header.Type, header.StreamID, flagsString(header))
+
+		switch f := frame.(type) {
+		case *http2.SettingsFrame:
+			if !f.IsAck() {
+				_ = f.ForeachSetting(func(setting http2.Setting) error {
+					fmt.Fprintf(&response.log, "%s=%d\n", setting.ID, setting.Val)
+					return nil
+				})
+				c.writeMu.Lock()
+				_ = c.framer.WriteSettingsAck()
+				c.writeMu.Unlock()
+			}
+		case *http2.PingFrame:
+			if !f.IsAck() {
+				c.writeMu.Lock()
+				_ = c.framer.WritePing(true, f.Data)
+				c.writeMu.Unlock()
+			}
+		case *http2.HeadersFrame:
+			current = response.stream(f.StreamID)
+			_, _ = decoder.Write(f.HeaderBlockFragment())
+			if f.HeadersEnded() {
+				_ = decoder.Close()
+			}
+			if f.StreamEnded() {
+				c.finishStream(f.StreamID)
+			}
+		case *http2.ContinuationFrame:
+			current = response.stream(f.StreamID)
+			_, _ = decoder.Write(f.HeaderBlockFragment())
+			if f.HeadersEnded() {
+				_ = decoder.Close()
+			}
+		case *http2.DataFrame:
+			stream := response.stream(f.StreamID)
+			data := f.Data()
+			stream.Body.Write(data)
+			if len(data) > 0 {
+				response.log.Write(data)
+				response.log.WriteString("\n")
+				c.writeMu.Lock()
+				_ = c.framer.WriteWindowUpdate(0, uint32(len(data)))
+				_ = c.framer.WriteWindowUpdate(f.StreamID, uint32(len(data)))
+				c.writeMu.Unlock()
+			}
+			if f.StreamEnded() {
+				c.finishStream(f.StreamID)
+			}
+		case *http2.WindowUpdateFrame:
+			fmt.Fprintf(&response.log, "increment=%d\n", f.Increment)
+		case *http2.RSTStreamFrame:
+			response.RSTStreamCount++
+			stream := response.stream(f.StreamID)
+			stream.Reset = true
+			stream.ErrorCode = f.ErrCode
+			fmt.Fprintf(&response.log, "code=%s\n", f.ErrCode)
+			c.finishStream(f.StreamID)
+		case *http2.GoAwayFrame:
+			response.GoAway = &GoAway{
+				LastStreamID: f.LastStreamID,
+				ErrorCode:    f.ErrCode,
+				DebugData:    string(f.DebugData()),
+			}
+			fmt.Fprintf(&response.log, "last_stream=%d code=%s\n", f.LastStreamID, f.ErrCode)
+			if debug := f.DebugData(); len(debug) > 0 {
+				response.log.Write(debug)
+				response.log.WriteString("\n")
+			}
+			c.mu.Lock()
+			for id := range c.pending {
+				if id > f.LastStreamID {
+					delete(c.pending, id)
+				}
+			}
+			c.mu.Unlock()
+		}
+		if c.done() {
+			_ = c.conn.SetReadDeadline(earliest(deadline, time.Now().Add(drainTimeout)))
+		}
+	}
+}
+
+// flagNames contains the names of the flags by frame type
+var flagNames = map[http2.FrameType]map[http2.Flags]string{
+	http2.FrameHeaders:      {http2.FlagHeadersEndStream: "END_STREAM", http2.FlagHeadersEndHeaders: "END_HEADERS", http2.FlagHeadersPadded: "PADDED", http2.FlagHeadersPriority: "PRIORITY"},
+	http2.FrameData:         {http2.FlagDataEndStream: "END_STREAM", http2.FlagDataPadded: "PADDED"},
+	http2.FrameContinuation: {http2.FlagContinuationEndHeaders: "END_HEADERS"},
+	http2.FrameSettings:     {http2.FlagSettingsAck: "ACK"},
+	http2.FramePing:         {http2.FlagPingAck: "ACK"},
+}
+
+func flagsString(header http2.FrameHeader) string {
+	if header.Flags == 0 {
+		return ""
+	}
+	var flags []string
+	for flag, name := range flagNames[header.Type] {
+		if header.Flags.Has(flag) {
+			flags = append(flags, name)
+		}
+	}
+	if len(flags) == 0 {
+		return fmt.Sprintf(" flags=0x%x", uint8(header.Flags))
+	}
+	sort.Strings(flags)
+	return " flags=" + strings.Join(flags, "|")
+}
+
+func earliest(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+func isTimeout(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+func isClosed(err error) bool {
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, net.ErrClosed) || errors.Is(err, syscall.ECONNRESET)
+}
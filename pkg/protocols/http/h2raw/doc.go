@@ -0,0 +1,6 @@
+// Package h2raw provides raw http2 frame level requests for nuclei.
+//
+// Frames are written as described in the template without any validation
+// of the http2 specification which allows sending frames with arbitrary
+// pseudo-headers, flags and stream identifiers.
+package h2raw
@@ -0,0 +1,321 @@
+package h2raw
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/http2"
+)
+
+// Frame types supported in templates
+const (
+	FrameHeaders      = "headers"
+	FrameContinuation = "continuation"
+	FrameData         = "data"
+	FrameRSTStream    = "rst_stream"
+	FrameSettings     = "settings"
+	FramePing         = "ping"
+	FrameWindowUpdate = "window_update"
+	FramePriority     = "priority"
+	FrameGoAway       = "goaway"
+)
+
+// Request is a sequence of raw http2 frames sent on a single connection
+type Request struct {
+	// description: |
+	//   Frames is the list of frames to send on the connection.
+	//
+	//   The connection preface and an empty SETTINGS frame are always sent first.
+	Frames []*Frame `yaml:"frames,omitempty" json:"frames,omitempty" jsonschema:"title=frames to send,description=Frames is the list of frames to send on the connection"`
+	// description: |
+	//   Repeat sends the frames the given number of times. The stream identifiers
+	//   of the frames are advanced by 2 on each repetition (e.g. for rapid reset checks).
+	Repeat int `yaml:"repeat,omitempty" json:"repeat,omitempty" jsonschema:"title=number of repetitions of the frames,description=Repeat sends the frames the given number of times advancing the stream identifiers"`
+	// description: |
+	//   ReadTimeout is the maximum time to wait for the responses of the server (default http timeout).
+	// examples:
+	//   - value: "\"5s\""
+	ReadTimeout string `yaml:"read-timeout,omitempty" json:"read-timeout,omitempty" jsonschema:"title=read timeout for responses,description=ReadTimeout is the maximum time to wait for the responses of the server"`
+
+	readTimeout time.Duration
+}
+
+// Frame is a single raw http2 frame
+type Frame struct {
+	// description: |
+	//   Type is the type of the frame.
+	// values:
+	//   - "headers"
+	//   - "continuation"
+	//   - "data"
+	//   - "rst_stream"
+	//   - "settings"
+	//   - "ping"
+	//   - "window_update"
+	//   - "priority"
+	//   - "goaway"
+	Type string `yaml:"type" json:"type" jsonschema:"title=type of the frame,description=Type is the type of the frame,enum=headers,enum=continuation,enum=data,enum=rst_stream,enum=settings,enum=ping,enum=window_update,enum=priority,enum=goaway"`
+	// description: |
+	//   Stream is the stream identifier of the frame.
+	//
+	//   It defaults to 1 for stream level frames and to 0 for connection level frames.
+	Stream *uint32 `yaml:"stream,omitempty" json:"stream,omitempty" jsonschema:"title=stream identifier,description=Stream is the stream identifier of the frame"`
+	// description: |
+	//   Headers is the list of headers of a HEADERS or CONTINUATION frame in `name: value` format.
+	//
+	//   Pseudo-headers are not validated and can be repeated or sent in any order.
+	// examples:
+	//   - value: >
+	//       []string{":method: POST", ":path: /", ":scheme: https", ":authority: {{Hostname}}", "content-length: 0"}
+	Headers []string `yaml:"headers,omitempty" json:"headers,omitempty" jsonschema:"title=headers of the frame,description=Headers is the list of headers of a HEADERS or CONTINUATION frame"`
+	// description: |
+	//   Data is the payload of a DATA frame or the debug data of a GOAWAY frame.
+	Data string `yaml:"data,omitempty" json:"data,omitempty" jsonschema:"title=data of the frame,description=Data is the payload of a DATA frame or the debug data of a GOAWAY frame"`
+	// description: |
+	//   EndStream sets the END_STREAM flag of HEADERS and DATA frames.
+	EndStream bool `yaml:"end-stream,omitempty" json:"end-stream,omitempty" jsonschema:"title=set end stream flag,description=EndStream sets the END_STREAM flag of HEADERS and DATA frames"`
+	// description: |
+	//   EndHeaders sets the END_HEADERS flag of HEADERS and CONTINUATION frames.
+	//
+	//   By default the flag is set unless the frame is followed by a CONTINUATION frame.
+	EndHeaders *bool `yaml:"end-headers,omitempty" json:"end-headers,omitempty" jsonschema:"title=set end headers flag,description=EndHeaders sets the END_HEADERS flag of HEADERS and CONTINUATION frames"`
+	// description: |
+	//   ErrorCode is the error code of RST_STREAM and GOAWAY frames (default NO_ERROR for GOAWAY and CANCEL for RST_STREAM).
+	// examples:
+	//   - value: "\"CANCEL\""
+	ErrorCode string `yaml:"error-code,omitempty" json:"error-code,omitempty" jsonschema:"title=error code,description=ErrorCode is the error code of RST_STREAM and GOAWAY frames"`
+	// description: |
+	//   Settings is the list of parameters of a SETTINGS frame in `NAME=value` format.
+	// examples:
+	//   - value: >
+	//       []string{"MAX_CONCURRENT_STREAMS=100", "INITIAL_WINDOW_SIZE=65535"}
+	Settings []string `yaml:"settings,omitempty" json:"settings,omitempty" jsonschema:"title=settings parameters,description=Settings is the list of parameters of a SETTINGS frame"`
+	// description: |
+	//   Increment is the window size increment of a WINDOW_UPDATE frame.
+	Increment uint32 `yaml:"increment,omitempty" json:"increment,omitempty" jsonschema:"title=window size increment,description=Increment is the window size increment of a WINDOW_UPDATE frame"`
+	// description: |
+	//   LastStream is the last stream identifier of a GOAWAY frame.
+	LastStream uint32 `yaml:"last-stream,omitempty" json:"last-stream,omitempty" jsonschema:"title=last stream identifier,description=LastStream is the last stream identifier of a GOAWAY frame"`
+	// description: |
+	//   Repeat sends the frame the given number of times (e.g. for CONTINUATION floods).
+	Repeat int `yaml:"repeat,omitempty" json:"repeat,omitempty" jsonschema:"title=number of repetitions of the frame,description=Repeat sends the frame the given number of times"`
+
+	errCode  http2.ErrCode
+	settings []http2.Setting
+}
+
+// Compile validates the frames of the request
+func (r *Request) Compile() error {
+	if len(r.Frames) == 0 {
+		return fmt.Errorf("no frames specified for h2 request")
+	}
+	if r.ReadTimeout != "" {
+		timeout, err := time.ParseDuration(r.ReadTimeout)
+		if err != nil {
+			return fmt.Errorf("could not parse read-timeout %q: %w", r.ReadTimeout, err)
+		}
+		r.readTimeout = timeout
+	}
+	for i, frame := range r.Frames {
+		if err := frame.compile(); err != nil {
+			return fmt.Errorf("invalid frame %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+func (f *Frame) compile() error {
+	f.Type = strings.ToLower(f.Type)
+	switch f.Type {
+	case FrameHeaders, FrameContinuation, FrameData, FramePing, FrameWindowUpdate, FramePriority:
+	case FrameRSTStream:
+		code, err := parseErrCode(f.ErrorCode, http2.ErrCodeCancel)
+		if err != nil {
+			return err
+		}
+		f.errCode = code
+	case FrameGoAway:
+		code, err := parseErrCode(f.ErrorCode, http2.ErrCodeNo)
+		if err != nil {
+			return err
+		}
+		f.errCode = code
+	case FrameSettings:
+		for _, setting := range f.Settings {
+			parsed, err := parseSetting(setting)
+			if err != nil {
+				return err
+			}
+			f.settings = append(f.settings, parsed)
+		}
+	default:
+		return fmt.Errorf("unknown frame type %q", f.Type)
+	}
+	for _, header := range f.Headers {
+		if _, _, err := splitHeader(header); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Evaluate returns a copy of the request with the headers and data
+// of the frames replaced by the evaluate function
+func (r *Request) Evaluate(evaluate func(string) (string, error)) (*Request, error) {
+	evaluated := *r
+	evaluated.Frames = make([]*Frame, 0, len(r.Frames))
+	for _, frame := range r.Frames {
+		copied := *frame
+		copied.Headers = make([]string, 0, len(frame.Headers))
+		for _, header := range frame.Headers {
+			value, err := evaluate(header)
+			if err != nil {
+				return nil, err
+			}
+			copied.Headers = append(copied.Headers, value)
+		}
+		data, err := evaluate(frame.Data)
+		if err != nil {
+			return nil, err
+		}
+		copied.Data = data
+		evaluated.Frames = append(evaluated.Frames, &copied)
+	}
+	return &evaluated, nil
+}
+
+// streamID returns the stream identifier of the frame for a repetition of the request
+func (f *Frame) streamID(repetition int) uint32 {
+	if f.Stream != nil {
+		if *f.Stream == 0 {
+			return 0
+		}
+		return *f.Stream + uint32(2*repetition)
+	}
+	switch f.Type {
+	case FrameSettings, FramePing, FrameGoAway, FrameWindowUpdate:
+		return 0
+	}
+	return 1 + uint32(2*repetition)
+}
+
+// endHeaders returns whether the END_HEADERS flag should be set on a frame
+func (r *Request) endHeaders(index int) bool {
+	frame := r.Frames[index]
+	if frame.EndHeaders != nil {
+		return *frame.EndHeaders
+	}
+	if frame.Type == FrameContinuation && frame.Repeat > 1 {
+		return false
+	}
+	if index+1 < len(r.Frames) && r.Frames[index+1].Type == FrameContinuation {
+		return false
+	}
+	return true
+}
+
+// repeat returns the number of repetitions
+func repeat(value int) int {
+	if value < 1 {
+		return 1
+	}
+	return value
+}
+
+// String returns the frames of the request in a human readable format
+func (r *Request) String() string {
+	builder := &strings.Builder{}
+	if repetitions := repeat(r.Repeat); repetitions > 1 {
+		fmt.Fprintf(builder, "# frames repeated %d times\n", repetitions)
+	}
+	for i, frame := range r.Frames {
+		if i > 0 {
+			builder.WriteString("\n")
+		}
+		builder.WriteString(strings.ToUpper(frame.Type))
+		fmt.Fprintf(builder, " stream=%d", frame.streamID(0))
+		var flags []string
+		if frame.EndStream && (frame.Type == FrameHeaders || frame.Type == FrameData) {
+			flags = append(flags, "END_STREAM")
+		}
+		if (frame.Type == FrameHeaders || frame.Type == FrameContinuation) && r.endHeaders(i) {
+			flags = append(flags, "END_HEADERS")
+		}
+		if len(flags) > 0 {
+			builder.WriteString(" flags=" + strings.Join(flags, "|"))
+		}
+		switch frame.Type {
+		case FrameRSTStream:
+			builder.WriteString(" code=" + frame.errCode.String())
+		case FrameGoAway:
+			fmt.Fprintf(builder, " last_stream=%d code=%s", frame.LastStream, frame.errCode.String())
+		case FrameWindowUpdate:
+			fmt.Fprintf(builder, " increment=%d", frame.Increment)
+		}
+		if frame.Repeat > 1 {
+			fmt.Fprintf(builder, " repeat=%d", frame.Repeat)
+		}
+		builder.WriteString("\n")
+		for _, header := range frame.Headers {
+			builder.WriteString(header + "\n")
+		}
+		for _, setting := range frame.settings {
+			fmt.Fprintf(builder, "%s=%d\n", setting.ID, setting.Val)
+		}
+		if frame.Data != "" {
+			builder.WriteString(frame.Data + "\n")
+		}
+	}
+	return builder.String()
+}
+
+// splitHeader splits a header in `name: value` format. The colon
+// of pseudo-headers is not considered as separator.
+func splitHeader(header string) (string, string, error) {
+	index := strings.Index(header[min(1, len(header)):], ":")
+	if index == -1 {
+		return "", "", fmt.Errorf("invalid header %q, expected name: value", header)
+	}
+	index += min(1, len(header))
+	return header[:index], strings.TrimPrefix(header[index+1:], " "), nil
+}
+
+// parseErrCode parses an error code by name (e.g. CANCEL) or number
+func parseErrCode(value string, defaultCode http2.ErrCode) (http2.ErrCode, error) {
+	if value == "" {
+		return defaultCode, nil
+	}
+	if number, err := strconv.ParseUint(value, 0, 32); err == nil {
+		return http2.ErrCode(number), nil
+	}
+	for code := http2.ErrCodeNo; code <= http2.ErrCodeHTTP11Required; code++ {
+		if strings.EqualFold(code.String(), value) {
+			return code, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown error code %q", value)
+}
+
+// parseSetting parses a setting in NAME=value format
+func parseSetting(value string) (http2.Setting, error) {
+	name, number, ok := strings.Cut(value, "=")
+	if !ok {
+		return http2.Setting{}, fmt.Errorf("invalid setting %q, expected NAME=value", value)
+	}
+	val, err := strconv.ParseUint(strings.TrimSpace(number), 0, 32)
+	if err != nil {
+		return http2.Setting{}, fmt.Errorf("invalid value of setting %q", value)
+	}
+	name = strings.TrimSpace(name)
+	if id, err := strconv.ParseUint(name, 0, 16); err == nil {
+		return http2.Setting{ID: http2.SettingID(id), Val: uint32(val)}, nil
+	}
+	for id := http2.SettingHeaderTableSize; id <= http2.SettingMaxHeaderListSize; id++ {
+		if strings.EqualFold(id.String(), name) {
+			return http2.Setting{ID: id, Val: uint32(val)}, nil
+		}
+	}
+	return http2.Setting{}, fmt.Errorf("unknown setting %q", name)
+}
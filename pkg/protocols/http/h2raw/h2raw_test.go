@@ -0,0 +1,113 @@
+package h2raw
+
+import (
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/http2"
+)
+
+// serveH2C serves a single prior knowledge http2 connection and returns the client side
+func serveH2C(t *testing.T, handler http.HandlerFunc) net.Conn {
+	client, server := net.Pipe()
+	go (&http2.Server{}).ServeConn(server, &http2.ServeConnOpts{Handler: handler})
+	t.Cleanup(func() { _ = client.Close() })
+	return client
+}
+
+func uint32Ptr(value uint32) *uint32 {
+	return &value
+}
+
+func TestRequestCompile(t *testing.T) {
+	request := &Request{Frames: []*Frame{
+		{Type: "HEADERS", Headers: []string{":method: GET", ":path: /"}},
+		{Type: "rst_stream"},
+		{Type: "goaway", ErrorCode: "ENHANCE_YOUR_CALM"},
+		{Type: "settings", Settings: []string{"MAX_CONCURRENT_STREAMS=100", "0x4=65535"}},
+	}}
+	require.Nil(t, request.Compile(), "could not compile valid request")
+	require.Equal(t, FrameHeaders, request.Frames[0].Type, "could not normalize frame type")
+	require.Equal(t, http2.ErrCodeCancel, request.Frames[1].errCode, "could not set default rst_stream code")
+	require.Equal(t, http2.ErrCodeEnhanceYourCalm, request.Frames[2].errCode, "could not parse error code")
+	require.Equal(t, []http2.Setting{{ID: http2.SettingMaxConcurrentStreams, Val: 100}, {ID: http2.SettingInitialWindowSize, Val: 65535}}, request.Frames[3].settings, "could not parse settings")
+
+	for _, frame := range []*Frame{
+		{Type: "unknown"},
+		{Type: "rst_stream", ErrorCode: "NOT_A_CODE"},
+		{Type: "settings", Settings: []string{"MAX_CONCURRENT_STREAMS"}},
+		{Type: "headers", Headers: []string{"no-separator"}},
+	} {
+		require.NotNil(t, (&Request{Frames: []*Frame{frame}}).Compile(), "could compile invalid frame %v", frame.Type)
+	}
+	require.NotNil(t, (&Request{}).Compile(), "could compile request without frames")
+}
+
+func TestSplitHeader(t *testing.T) {
+	name, value, err := splitHeader(":authority: example.com:8080")
+	require.Nil(t, err, "could not split pseudo-header")
+	require.Equal(t, ":authority", name)
+	require.Equal(t, "example.com:8080", value)
+
+	name, value, err = splitHeader("Transfer-Encoding:chunked")
+	require.Nil(t, err, "could not split header")
+	require.Equal(t, "Transfer-Encoding", name)
+	require.Equal(t, "chunked", value)
+}
+
+func TestRequestDo(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Path", r.URL.Path)
+		_, _ = w.Write([]byte("hello " + r.Method))
+	}
+
+	t.Run("streams", func(t *testing.T) {
+		request := &Request{Frames: []*Frame{
+			{Type: "headers", Headers: []string{":method: GET", ":scheme: http", ":authority: localhost", ":path: /first"}, EndStream: true},
+			{Type: "headers", Stream: uint32Ptr(3), Headers: []string{":method: POST", ":scheme: http", ":authority: localhost"}},
+			{Type: "continuation", Stream: uint32Ptr(3), Headers: []string{":path: /second"}},
+			{Type: "data", Stream: uint32Ptr(3), Data: "body", EndStream: true},
+		}}
+		require.Nil(t, request.Compile(), "could not compile request")
+		require.False(t, request.endHeaders(1), "could set end headers before continuation")
+
+		response, err := request.Do(serveH2C(t, handler), 5*time.Second)
+		require.Nil(t, err, "could not send frames")
+		require.Equal(t, []uint32{1, 3}, response.StreamIDs(), "could not get stream responses")
+		require.Equal(t, "200", response.Streams[1].Status)
+		require.Equal(t, "hello GET", response.Streams[1].Body.String())
+		require.Contains(t, response.Streams[1].HeadersString(), "x-path: /first\n")
+		require.Equal(t, "hello POST", response.Streams[3].Body.String())
+		require.Contains(t, response.Streams[3].HeadersString(), "x-path: /second\n")
+		require.Nil(t, response.GoAway, "could get unexpected goaway")
+	})
+
+	t.Run("rapid-reset", func(t *testing.T) {
+		request := &Request{Repeat: 5, Frames: []*Frame{
+			{Type: "headers", Headers: []string{":method: GET", ":scheme: http", ":authority: localhost", ":path: /"}, EndStream: true},
+			{Type: "rst_stream"},
+		}}
+		require.Nil(t, request.Compile(), "could not compile request")
+
+		response, err := request.Do(serveH2C(t, handler), 5*time.Second)
+		require.Nil(t, err, "could not send frames")
+		require.Nil(t, response.GoAway, "could get unexpected goaway")
+		require.False(t, response.ConnectionClosed, "could get closed connection")
+	})
+
+	t.Run("goaway", func(t *testing.T) {
+		request := &Request{Frames: []*Frame{
+			{Type: "headers", Stream: uint32Ptr(2), Headers: []string{":method: GET", ":scheme: http", ":authority: localhost", ":path: /"}, EndStream: true},
+		}}
+		require.Nil(t, request.Compile(), "could not compile request")
+
+		response, err := request.Do(serveH2C(t, handler), 5*time.Second)
+		require.Nil(t, err, "could not send frames")
+		require.NotNil(t, response.GoAway, "could not get goaway for even stream identifier")
+		require.Equal(t, http2.ErrCodeProtocol, response.GoAway.ErrorCode)
+		require.Contains(t, response.String(), "GOAWAY stream=0", "could not log goaway frame")
+	})
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/expressions"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/generators"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/protocolstate"
+	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/http/h2raw"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/http/httpclientpool"
 	httputil "github.com/projectdiscovery/nuclei/v3/pkg/protocols/utils/http"
 	"github.com/projectdiscovery/nuclei/v3/pkg/utils/stats"
@@ -145,7 +146,11 @@ type Request struct {
 	//   Signature is the request signature method
 	// values:
 	//   - "AWS"
-	Signature SignatureTypeHolder `yaml:"signature,omitempty" json:"signature,omitempty" jsonschema:"title=signature is the http request signature method,description=Signature is the HTTP Request signature Method,enum=AWS"`
+	//   - "HTTPSIG"
+	//   - "OAUTH1"
+	//   - "AZURE-SHARED-KEY"
+	//   - "GCP-HMAC"
+	Signature SignatureTypeHolder `yaml:"signature,omitempty" json:"signature,omitempty" jsonschema:"title=signature is the http request signature method,description=Signature is the HTTP Request signature Method,enum=AWS,enum=HTTPSIG,enum=OAUTH1,enum=AZURE-SHARED-KEY,enum=GCP-HMAC"`
 
 	// description: |
 	//   SkipSecretFile skips the authentication or authorization configured in the secret file.
@@ -187,6 +192,23 @@ type Request struct {
 	//   control over the request, with no normalization performed by the client.
 	Unsafe bool `yaml:"unsafe,omitempty" json:"unsafe,omitempty" jsonschema:"title=use rawhttp non-strict-rfc client,description=Unsafe specifies whether to use rawhttp engine for sending Non RFC-Compliant requests"`
 	// description: |
+	//   HTTP3 sends the requests over QUIC (HTTP/3).
+	//
+	//   Only https targets are supported and the requests can not be sent through a proxy.
+	//   The negotiated protocol of the response is available as `proto` in the DSL.
+	HTTP3 bool `yaml:"http3,omitempty" json:"http3,omitempty" jsonschema:"title=send requests over http3,description=HTTP3 sends the requests over QUIC (HTTP/3)"`
+	// description: |
+	//   H2 contains raw HTTP/2 requests described as a sequence of frames.
+	//
+	//   Each request is sent on a new connection (TLS with h2 ALPN for https, prior knowledge for http)
+	//   without any validation of the frames, pseudo-headers or stream identifiers. It can be used for
+	//   HTTP/2 request smuggling, CONTINUATION flood and rapid reset checks.
+	//
+	//   The responses of the streams are available as `stream_<id>_status_code`, `stream_<id>_body`,
+	//   `stream_<id>_header` and `stream_<id>_error_code` in the DSL, while `goaway`, `goaway_error_code`,
+	//   `goaway_last_stream_id`, `rst_stream_count` and `connection_closed` describe the connection.
+	H2 []*h2raw.Request `yaml:"h2,omitempty" json:"h2,omitempty" jsonschema:"title=raw http2 frame requests,description=H2 contains raw HTTP/2 requests described as a sequence of frames"`
+	// description: |
 	//   Race determines if all the request have to be attempted at the same time (Race Condition)
 	//
 	//   The actual number of requests that will be sent is determined by the `race_count`  field.
@@ -223,6 +245,8 @@ type Request struct {
 	//  FuzzPreConditionOperator is the operator between multiple PreConditions for fuzzing Default is OR
 	FuzzPreConditionOperator string                 `yaml:"pre-condition-operator,omitempty" json:"pre-condition-operator,omitempty" jsonschema:"title=condition between the filters,description=Operator to use between multiple per-conditions,enum=and,enum=or"`
 	fuzzPreConditionOperator matchers.ConditionType `yaml:"-" json:"-"`
+	// fuzzingBaseline is true if operators compare fuzzing responses with the baseline
+	fuzzingBaseline bool
 	// description: |
 	//   GlobalMatchers marks matchers as static and applies globally to all result events from other templates
 	GlobalMatchers bool `yaml:"global-matchers,omitempty" json:"global-matchers,omitempty" jsonschema:"title=global matchers,description=marks matchers as static and applies globally to all result events from other templates"`
@@ -260,22 +284,39 @@ func (r *Request) Options() *protocols.ExecutorOptions {
 // description. Multiple definitions are separated by commas.
 // Definitions not having a name (generated on runtime) are prefixed & suffixed by <>.
 var RequestPartDefinitions = map[string]string{
-	"template-id":           "ID of the template executed",
-	"template-info":         "Info Block of the template executed",
-	"template-path":         "Path of the template executed",
-	"host":                  "Host is the input to the template",
-	"matched":               "Matched is the input which was matched upon",
-	"type":                  "Type is the type of request made",
-	"request":               "HTTP request made from the client",
-	"response":              "HTTP response received from server",
-	"status_code":           "Status Code received from the Server",
-	"body":                  "HTTP response body received from server (default)",
-	"content_length":        "HTTP Response content length",
-	"header,all_headers":    "HTTP response headers",
-	"duration":              "HTTP request time duration",
-	"all":                   "HTTP response body + headers",
-	"cookies_from_response": "HTTP response cookies in name:value format",
-	"headers_from_response": "HTTP response headers in name:value format",
+	"template-id":             "ID of the template executed",
+	"template-info":           "Info Block of the template executed",
+	"template-path":           "Path of the template executed",
+	"host":                    "Host is the input to the template",
+	"matched":                 "Matched is the input which was matched upon",
+	"type":                    "Type is the type of request made",
+	"request":                 "HTTP request made from the client",
+	"response":                "HTTP response received from server",
+	"status_code":             "Status Code received from the Server",
+	"proto":                   "Protocol of the HTTP response (e.g. HTTP/3.0)",
+	"body":                    "HTTP response body received from server (default)",
+	"content_length":          "HTTP Response content length",
+	"header,all_headers":      "HTTP response headers",
+	"duration":                "HTTP request time duration",
+	"all":                     "HTTP response body + headers",
+	"cookies_from_response":   "HTTP response cookies in name:value format",
+	"headers_from_response":   "HTTP response headers in name:value format",
+	"baseline_status_code":    "Status Code of the baseline response (fuzzing only)",
+	"baseline_content_length": "Content length of the baseline response (fuzzing only)",
+	"baseline_status_changed": "True if the Status Code differs from the baseline response (fuzzing only)",
+	"baseline_length_delta":   "Difference of the content length to the baseline response (fuzzing only)",
+	"baseline_words_delta":    "Difference of the word count to the baseline response (fuzzing only)",
+	"baseline_similarity":     "Structural similarity between 0 and 1 with the baseline response (fuzzing only)",
+	"stream_<id>_status_code": "Status Code received on the stream with the given id (h2 only)",
+	"stream_<id>_body":        "Response body received on the stream with the given id (h2 only)",
+	"stream_<id>_header":      "Response headers received on the stream with the given id (h2 only)",
+	"stream_<id>_error_code":  "Error code of the RST_STREAM frame received on the stream with the given id (h2 only)",
+	"goaway":                  "True if a GOAWAY frame was received (h2 only)",
+	"goaway_error_code":       "Error code of the received GOAWAY frame (h2 only)",
+	"goaway_last_stream_id":   "Last stream id of the received GOAWAY frame (h2 only)",
+	"goaway_debug":            "Debug data of the received GOAWAY frame (h2 only)",
+	"rst_stream_count":        "Number of RST_STREAM frames received (h2 only)",
+	"connection_closed":       "True if the server closed the connection (h2 only)",
 }
 
 // GetID returns the unique ID of the request if any.
@@ -302,6 +343,7 @@ func (request *Request) Compile(options *protocols.ExecutorOptions) error {
 			DisableKeepAlive: httputil.ShouldDisableKeepAlive(options.Options),
 		},
 		RedirectFlow: httpclientpool.DontFollowRedirect,
+		HTTP3:        request.HTTP3 || options.Options.ForceHTTP3,
 	}
 
 	if request.Redirects || options.Options.FollowRedirects {
@@ -345,6 +387,11 @@ func (request *Request) Compile(options *protocols.ExecutorOptions) error {
 		}
 		request.rawhttpClient = httpclientpool.GetRawHTTP(options)
 	}
+	for i, h2Request := range request.H2 {
+		if err := h2Request.Compile(); err != nil {
+			return errors.Wrapf(err, "could not compile h2 request %d", i+1)
+		}
+	}
 	if len(request.Matchers) > 0 || len(request.Extractors) > 0 {
 		compiled := &request.Operators
 		compiled.ExcludeMatchers = options.ExcludeMatchers
@@ -403,7 +450,7 @@ func (request *Request) Compile(options *protocols.ExecutorOptions) error {
 	unusedPayloads := make(map[string]struct{})
 	requestSectionsToCheck := []interface{}{
 		request.customHeaders, request.Headers, request.Matchers,
-		request.Extractors, request.Body, request.Path, request.Raw, request.Fuzzing,
+		request.Extractors, request.Body, request.Path, request.Raw, request.Fuzzing, request.H2,
 	}
 	if requestSectionsToCheckData, err := json.Marshal(requestSectionsToCheck); err == nil {
 		for payload := range request.Payloads {
@@ -441,6 +488,7 @@ func (request *Request) Compile(options *protocols.ExecutorOptions) error {
 				return errors.Wrap(err, "could not compile fuzzing rule")
 			}
 		}
+		request.fuzzingBaseline = request.needsFuzzingBaseline()
 	}
 	if len(request.Payloads) > 0 {
 		// Due to a known issue (https://github.com/projectdiscovery/nuclei/issues/5015),
@@ -486,6 +534,9 @@ func (request *Request) Requests() int {
 		if len(request.Path) > 0 {
 			payloadRequests = payloadRequests * len(request.Path)
 		}
+		if len(request.H2) > 0 {
+			payloadRequests = payloadRequests * len(request.H2)
+		}
 		return payloadRequests
 	}
 	if len(request.Raw) > 0 {
@@ -495,6 +546,9 @@ func (request *Request) Requests() int {
 		}
 		return requests
 	}
+	if len(request.H2) > 0 {
+		return len(request.H2)
+	}
 	return len(request.Path)
 }
 
@@ -56,6 +56,11 @@ func Init(options *types.Options) error {
 	return nil
 }
 
+// Close closes the shared udp socket of http3 clients
+func Close() {
+	closeHTTP3()
+}
+
 // ConnectionConfiguration contains the custom configuration options for a connection
 type ConnectionConfiguration struct {
 	// DisableKeepAlive of the connection
@@ -101,6 +106,8 @@ type Configuration struct {
 	Connection *ConnectionConfiguration
 	// ResponseHeaderTimeout is the timeout for response body to be read from the server
 	ResponseHeaderTimeout time.Duration
+	// HTTP3 sends the requests over QUIC
+	HTTP3 bool
 }
 
 func (c *Configuration) Clone() *Configuration {
@@ -137,13 +144,15 @@ func (c *Configuration) Hash() string {
 	builder.WriteString(strconv.FormatBool(c.Connection != nil))
 	builder.WriteString("r")
 	builder.WriteString(strconv.FormatInt(int64(c.ResponseHeaderTimeout.Seconds()), 10))
+	builder.WriteString("h")
+	builder.WriteString(strconv.FormatBool(c.HTTP3))
 	hash := builder.String()
 	return hash
 }
 
 // HasStandardOptions checks whether the configuration requires custom settings
 func (c *Configuration) HasStandardOptions() bool {
-	return c.Threads == 0 && c.MaxRedirects == 0 && c.RedirectFlow == DontFollowRedirect && c.DisableCookie && c.Connection == nil && !c.NoTimeout && c.ResponseHeaderTimeout == 0
+	return c.Threads == 0 && c.MaxRedirects == 0 && c.RedirectFlow == DontFollowRedirect && c.DisableCookie && c.Connection == nil && !c.NoTimeout && c.ResponseHeaderTimeout == 0 && !c.HTTP3
 }
 
 // GetRawHTTP returns the rawhttp request client
@@ -307,8 +316,13 @@ func wrappedGet(options *types.Options, configuration *Configuration) (*retryabl
 		}
 	}
 
+	var roundTripper http.RoundTripper = transport
+	if configuration.HTTP3 {
+		roundTripper = newHTTP3RoundTripper(options, tlsConfig)
+	}
+
 	httpclient := &http.Client{
-		Transport:     transport,
+		Transport:     roundTripper,
 		CheckRedirect: makeCheckRedirectFunc(redirectFlow, maxRedirects),
 	}
 	if !configuration.NoTimeout {
@@ -0,0 +1,139 @@
+package httpclientpool
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/quic-go/quic-go"
+	"github.com/quic-go/quic-go/http3"
+
+	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/protocolstate"
+	"github.com/projectdiscovery/nuclei/v3/pkg/types"
+)
+
+// errHTTP3Proxy is returned when a http3 request is sent while a proxy is configured
+var errHTTP3Proxy = errors.New("http3 requests can not be sent through http or socks proxies")
+
+var (
+	http3DialerMu sync.Mutex
+	// sharedHTTP3Dialer is the dialer of all http3 clients
+	sharedHTTP3Dialer *http3Dialer
+)
+
+// newHTTP3RoundTripper creates a round tripper sending requests over QUIC
+func newHTTP3RoundTripper(options *types.Options, tlsConfig *tls.Config) *http3.RoundTripper {
+	http3DialerMu.Lock()
+	if sharedHTTP3Dialer == nil {
+		sharedHTTP3Dialer = &http3Dialer{}
+		if options.SourceIP != "" {
+			sharedHTTP3Dialer.localAddr = &net.UDPAddr{IP: net.ParseIP(options.SourceIP)}
+		}
+	}
+	dialer := sharedHTTP3Dialer
+	http3DialerMu.Unlock()
+
+	timeouts := options.GetTimeouts()
+	return &http3.RoundTripper{
+		// quic enforces tls 1.3 and the h3 alpn on its own
+		TLSClientConfig: tlsConfig.Clone(),
+		QuicConfig: &quic.Config{
+			HandshakeIdleTimeout: timeouts.DialTimeout,
+			MaxIdleTimeout:       timeouts.HttpTimeout,
+		},
+		Dial: dialer.Dial,
+	}
+}
+
+// http3Dialer dials QUIC connections using the resolvers and the
+// network policy of nuclei. All connections share a single udp socket.
+type http3Dialer struct {
+	localAddr *net.UDPAddr
+
+	once      sync.Once
+	conn      *net.UDPConn
+	transport *quic.Transport
+	err       error
+}
+
+// Dial dials a QUIC connection to addr
+func (d *http3Dialer) Dial(ctx context.Context, addr string, tlsConfig *tls.Config, quicConfig *quic.Config) (quic.EarlyConnection, error) {
+	if types.ProxyURL != "" || types.ProxySocksURL != "" {
+		return nil, errHTTP3Proxy
+	}
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+	ip, err := resolveHTTP3Host(host)
+	if err != nil {
+		return nil, err
+	}
+	udpAddr, err := net.ResolveUDPAddr("udp", net.JoinHostPort(ip, port))
+	if err != nil {
+		return nil, err
+	}
+
+	d.once.Do(func() {
+		conn, err := net.ListenUDP("udp", d.localAddr)
+		if err != nil {
+			d.err = errors.Wrap(err, "could not create udp socket for http3")
+			return
+		}
+		d.conn = conn
+		d.transport = &quic.Transport{Conn: conn}
+	})
+	if d.err != nil {
+		return nil, d.err
+	}
+	return d.transport.DialEarly(ctx, udpAddr, tlsConfig, quicConfig)
+}
+
+// Close closes the QUIC connections and the udp socket of the dialer
+func (d *http3Dialer) Close() error {
+	// prevents creating a socket after closing
+	d.once.Do(func() {
+		d.err = net.ErrClosed
+	})
+	if d.transport == nil {
+		return nil
+	}
+	_ = d.transport.Close()
+	// the transport does not close sockets it did not create
+	return d.conn.Close()
+}
+
+// closeHTTP3 closes the shared http3 dialer
+func closeHTTP3() {
+	http3DialerMu.Lock()
+	defer http3DialerMu.Unlock()
+
+	if sharedHTTP3Dialer != nil {
+		_ = sharedHTTP3Dialer.Close()
+		sharedHTTP3Dialer = nil
+	}
+}
+
+// resolveHTTP3Host resolves a host with the nuclei dialer as
+// quic connections can't be dialed through fastdialer
+func resolveHTTP3Host(host string) (string, error) {
+	dialer := protocolstate.GetDialer()
+	if dialer == nil {
+		return host, nil
+	}
+	dnsData, err := dialer.GetDNSData(host)
+	if err != nil {
+		return "", err
+	}
+	ips := append(append([]string{}, dnsData.A...), dnsData.AAAA...)
+	if len(ips) == 0 {
+		return "", fmt.Errorf("could not resolve host %s", host)
+	}
+	if protocolstate.NetworkPolicy != nil && !protocolstate.NetworkPolicy.Validate(ips[0]) {
+		return "", fmt.Errorf("host %s is not allowed by network policy", host)
+	}
+	return ips[0], nil
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/protocolstate"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/http/httpclientpool"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/http/httputils"
+	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/http/raw"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/http/signer"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/http/signerpool"
 	templateTypes "github.com/projectdiscovery/nuclei/v3/pkg/templates/types"
@@ -460,6 +461,11 @@ func (request *Request) ExecuteWithResults(input *contextargs.Context, dynamicVa
 		return request.executeRaceRequest(input, dynamicValues, callback)
 	}
 
+	// verify if raw http2 frames were requested
+	if len(request.H2) > 0 {
+		return request.executeH2(input, dynamicValues, previous, callback)
+	}
+
 	// verify if fuzz elaboration was requested
 	if len(request.Fuzzing) > 0 {
 		return request.executeFuzzingRule(input, dynamicValues, callback)
@@ -725,7 +731,6 @@ func (request *Request) executeRequest(input *contextargs.Context, generatedRequ
 		}
 		options := *generatedRequest.original.rawhttpClient.Options
 		options.FollowRedirects = request.Redirects
-		options.CustomRawBytes = generatedRequest.rawRequest.UnsafeRawBytes
 		options.ForceReadAllBody = request.ForceReadAllBody
 		options.SNI = request.options.Options.SNI
 		inputUrl := input.MetaInput.Input
@@ -736,6 +741,11 @@ func (request *Request) executeRequest(input *contextargs.Context, generatedRequ
 			inputUrl = url.String()
 		}
 		formedURL = fmt.Sprintf("%s%s", inputUrl, generatedRequest.rawRequest.Path)
+		// signing adds headers to the raw bytes of the request
+		if errSignature := request.handleSignature(generatedRequest, formedURL); errSignature != nil {
+			return errSignature
+		}
+		options.CustomRawBytes = generatedRequest.rawRequest.UnsafeRawBytes
 
 		// send rawhttp request and get response
 		resp, err = httpclientpool.SendRawRequest(generatedRequest.original.rawhttpClient, &httpclientpool.RawHttpRequestOpts{
@@ -760,7 +770,7 @@ func (request *Request) executeRequest(input *contextargs.Context, generatedRequ
 			}
 		}
 		if resp == nil {
-			if errSignature := request.handleSignature(generatedRequest); errSignature != nil {
+			if errSignature := request.handleSignature(generatedRequest, ""); errSignature != nil {
 				return errSignature
 			}
 			httpclient := request.httpClient
@@ -953,6 +963,11 @@ func (request *Request) executeRequest(input *contextargs.Context, generatedRequ
 		if request.options.Interactsh != nil {
 			request.options.Interactsh.MakePlaceholders(generatedRequest.interactshURLs, outputEvent)
 		}
+		if generatedRequest.baseline != nil {
+			for k, v := range generatedRequest.baseline.Compare(respChain.Response().StatusCode, respChain.Body().String()) {
+				outputEvent[k] = v
+			}
+		}
 		for k, v := range previousEvent {
 			finalEvent[k] = v
 		}
@@ -1070,27 +1085,104 @@ func (request *Request) addCNameIfAvailable(hostname string, outputEvent map[str
 	}
 }
 
-// handleSignature of the http request
-func (request *Request) handleSignature(generatedRequest *generatedRequest) error {
+// handleSignature of the http request. Unsafe requests are signed
+// using the url they are sent to as their headers are sent as is.
+func (request *Request) handleSignature(generatedRequest *generatedRequest, unsafeURL string) error {
+	allvars := generators.MergeMaps(request.options.Options.Vars.AsMap(), generatedRequest.dynamicValues)
+
+	var signerArgs signer.SignerArgs
 	switch request.Signature.Value {
 	case AWSSignature:
-		var awsSigner signer.Signer
-		allvars := generators.MergeMaps(request.options.Options.Vars.AsMap(), generatedRequest.dynamicValues)
-		awsopts := signer.AWSOptions{
+		signerArgs = &signer.AWSOptions{
 			AwsID:          types.ToString(allvars["aws-id"]),
 			AwsSecretToken: types.ToString(allvars["aws-secret"]),
 		}
-		awsSigner, err := signerpool.Get(request.options.Options, &signerpool.Configuration{SignerArgs: &awsopts})
-		if err != nil {
-			return err
+	case HTTPMessageSignature:
+		var components []string
+		if value := signer.GetArg(allvars, "httpsig-components"); value != "" {
+			components = strings.Split(value, ",")
+		}
+		signerArgs = &signer.HTTPSignatureOptions{
+			KeyID:      signer.GetArg(allvars, "httpsig-key-id"),
+			Key:        signer.GetArg(allvars, "httpsig-key"),
+			Algorithm:  signer.GetArg(allvars, "httpsig-algorithm"),
+			Components: components,
+			Label:      signer.GetArg(allvars, "httpsig-label"),
+			Tag:        signer.GetArg(allvars, "httpsig-tag"),
+		}
+	case OAuth1Signature:
+		signerArgs = &signer.OAuth1Options{
+			ConsumerKey:     signer.GetArg(allvars, "oauth-consumer-key"),
+			ConsumerSecret:  signer.GetArg(allvars, "oauth-consumer-secret"),
+			Token:           signer.GetArg(allvars, "oauth-token"),
+			TokenSecret:     signer.GetArg(allvars, "oauth-token-secret"),
+			SignatureMethod: signer.GetArg(allvars, "oauth-signature-method"),
+			Realm:           signer.GetArg(allvars, "oauth-realm"),
+		}
+	case AzureSharedKeySignature:
+		signerArgs = &signer.AzureSharedKeyOptions{
+			Account: signer.GetArg(allvars, "azure-account"),
+			Key:     signer.GetArg(allvars, "azure-key"),
+		}
+	case GCPHMACSignature:
+		signerArgs = &signer.GCPHMACOptions{
+			AccessID: signer.GetArg(allvars, "gcp-access-id"),
+			Secret:   signer.GetArg(allvars, "gcp-secret"),
 		}
-		ctx := signer.GetCtxWithArgs(allvars, signer.AwsDefaultVars)
-		err = awsSigner.SignHTTP(ctx, generatedRequest.request.Request)
-		if err != nil {
-			return err
+	default:
+		return nil
+	}
+
+	requestSigner, err := signerpool.Get(request.options.Options, &signerpool.Configuration{SignerArgs: signerArgs})
+	if err != nil {
+		return err
+	}
+	ctx := signer.GetCtxWithArgs(allvars, GetDefaultSignerVars(request.Signature.Value))
+	if generatedRequest.request != nil {
+		return requestSigner.SignHTTP(ctx, generatedRequest.request.Request)
+	}
+	if generatedRequest.rawRequest != nil {
+		return signUnsafeRequest(ctx, requestSigner, generatedRequest.rawRequest, unsafeURL)
+	}
+	return nil
+}
+
+// signUnsafeRequest signs an unsafe raw request and adds the
+// headers set by the signer after the request line.
+func signUnsafeRequest(ctx context.Context, requestSigner signer.Signer, rawRequest *raw.Request, unsafeURL string) error {
+	httpRequest, err := http.NewRequestWithContext(ctx, rawRequest.Method, unsafeURL, strings.NewReader(rawRequest.Data))
+	if err != nil {
+		return errors.Wrap(err, "could not create unsafe request for signing")
+	}
+	for key, value := range rawRequest.Headers {
+		if strings.EqualFold(key, "Host") {
+			httpRequest.Host = value
+			continue
 		}
+		httpRequest.Header.Set(key, value)
+	}
+	signedHeaders := httpRequest.Header.Clone()
+	if err := requestSigner.SignHTTP(ctx, httpRequest); err != nil {
+		return err
 	}
 
+	var added strings.Builder
+	for key, values := range httpRequest.Header {
+		if strings.Join(signedHeaders.Values(key), ",") == strings.Join(values, ",") {
+			continue
+		}
+		value := strings.Join(values, ", ")
+		rawRequest.Headers[key] = value
+		added.WriteString(key + ": " + value + "\r\n")
+	}
+	if len(rawRequest.UnsafeRawBytes) > 0 && added.Len() > 0 {
+		if index := bytes.IndexByte(rawRequest.UnsafeRawBytes, '\n'); index != -1 {
+			unsafeRawBytes := make([]byte, 0, len(rawRequest.UnsafeRawBytes)+added.Len())
+			unsafeRawBytes = append(unsafeRawBytes, rawRequest.UnsafeRawBytes[:index+1]...)
+			unsafeRawBytes = append(unsafeRawBytes, added.String()...)
+			rawRequest.UnsafeRawBytes = append(unsafeRawBytes, rawRequest.UnsafeRawBytes[index+1:]...)
+		}
+	}
 	return nil
 }
 
@@ -1162,6 +1254,14 @@ func (request *Request) pruneSignatureInternalValues(maps ...map[string]interfac
 	switch request.Signature.Value {
 	case AWSSignature:
 		signatureFieldsToSkip = signer.AwsInternalOnlyVars
+	case HTTPMessageSignature:
+		signatureFieldsToSkip = signer.HTTPSigInternalOnlyVars
+	case OAuth1Signature:
+		signatureFieldsToSkip = signer.OAuth1InternalOnlyVars
+	case AzureSharedKeySignature:
+		signatureFieldsToSkip = signer.AzureInternalOnlyVars
+	case GCPHMACSignature:
+		signatureFieldsToSkip = signer.GcpInternalOnlyVars
 	default:
 		return
 	}
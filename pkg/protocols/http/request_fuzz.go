@@ -9,10 +9,12 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/projectdiscovery/gologger"
 	"github.com/projectdiscovery/nuclei/v3/pkg/fuzz"
+	"github.com/projectdiscovery/nuclei/v3/pkg/fuzz/analyzers"
 	"github.com/projectdiscovery/nuclei/v3/pkg/operators"
 	"github.com/projectdiscovery/nuclei/v3/pkg/operators/matchers"
 	"github.com/projectdiscovery/nuclei/v3/pkg/output"
@@ -114,6 +116,10 @@ func (request *Request) executeFuzzingRule(input *contextargs.Context, previous
 func (request *Request) executeAllFuzzingRules(input *contextargs.Context, values map[string]interface{}, baseRequest *retryablehttp.Request, callback protocols.OutputEventCallback) error {
 	applicable := false
 	values = generators.MergeMaps(request.filterDataMap(input), values)
+	var baseline *fuzz.Baseline
+	if request.fuzzingBaseline {
+		baseline = request.getFuzzingBaseline(input, baseRequest)
+	}
 	for _, rule := range request.Fuzzing {
 		select {
 		case <-input.Context().Done():
@@ -136,6 +142,7 @@ func (request *Request) executeAllFuzzingRules(input *contextargs.Context, value
 			},
 			Values:      values,
 			BaseRequest: baseRequest.Clone(input.Context()),
+			Baseline:    baseline,
 		})
 		if err == nil {
 			applicable = true
@@ -170,9 +177,13 @@ func (request *Request) executeGeneratedFuzzingRequest(gr fuzz.GeneratedRequest,
 		dynamicValues:  gr.DynamicValues,
 		interactshURLs: gr.InteractURLs,
 		original:       request,
+		baseline:       gr.Baseline,
 	}
 	var gotMatches bool
 	requestErr := request.executeRequest(input, req, gr.DynamicValues, hasInteractMatchers, func(event *output.InternalWrappedEvent) {
+		if gr.Analyzer != nil && !event.HasResults() {
+			request.analyzeFuzzingResponse(gr, event)
+		}
 		for _, result := range event.Results {
 			result.IsFuzzingResult = true
 			result.FuzzingMethod = gr.Request.Method
@@ -229,6 +240,86 @@ func (request *Request) executeGeneratedFuzzingRequest(gr fuzz.GeneratedRequest,
 	return true
 }
 
+// analyzeFuzzingResponse runs the analyzer of the fuzzing rule on the response
+// of a generated request and adds a result to the event if an issue was found
+func (request *Request) analyzeFuzzingResponse(gr fuzz.GeneratedRequest, event *output.InternalWrappedEvent) {
+	analyzer := analyzers.GetAnalyzer(gr.Analyzer.Name)
+	if analyzer == nil {
+		return
+	}
+	duration, ok := event.InternalEvent["duration"].(float64)
+	if !ok {
+		return
+	}
+	matched, details, err := analyzer.Analyze(&analyzers.Options{
+		BaseRequest:        gr.BaseRequest,
+		Component:          gr.Component,
+		Key:                gr.Key,
+		Value:              gr.OriginalValue,
+		Payload:            gr.OriginalPayload,
+		ResponseTimeDelay:  time.Duration(duration * float64(time.Second)),
+		HttpClient:         request.httpClient,
+		RateLimitTake:      request.options.RateLimitTake,
+		AnalyzerParameters: gr.Analyzer.Parameters,
+	})
+	if err != nil {
+		gologger.Verbose().Msgf("[%s] fuzz: could not run %s analyzer: %s\n", request.options.TemplateID, gr.Analyzer.Name, err)
+		return
+	}
+	if !matched {
+		return
+	}
+	if event.OperatorsResult == nil {
+		event.OperatorsResult = &operators.Result{}
+	}
+	event.OperatorsResult.Matched = true
+	event.InternalEvent["analyzer_details"] = details
+	results := request.MakeResultEvent(event)
+	for _, result := range results {
+		result.AnalyzerDetails = details
+	}
+	event.Results = append(event.Results, results...)
+}
+
+// getFuzzingBaseline returns the baseline for a base request. The response
+// from the target file is used if available, otherwise the base request is sent.
+func (request *Request) getFuzzingBaseline(input *contextargs.Context, baseRequest *retryablehttp.Request) *fuzz.Baseline {
+	if reqResp := input.MetaInput.ReqResp; reqResp != nil && reqResp.Response != nil && reqResp.Response.StatusCode != 0 {
+		return fuzz.NewBaseline(reqResp.Response.StatusCode, reqResp.Response.Body)
+	}
+	request.options.RateLimitTake()
+	baseline, err := fuzz.CaptureBaseline(request.httpClient, baseRequest.Clone(input.Context()), request.MaxSize)
+	if err != nil {
+		gologger.Verbose().Msgf("[%s] fuzz: could not capture baseline for %s: %s\n", request.options.TemplateID, input.MetaInput.Input, err)
+		return nil
+	}
+	return baseline
+}
+
+// needsFuzzingBaseline determines if operators use baseline comparison variables
+func (request *Request) needsFuzzingBaseline() bool {
+	for _, matcher := range request.Matchers {
+		if checkFuzzingBaselineExpressions(matcher.DSL...) || checkFuzzingBaselineExpressions(matcher.Part) {
+			return true
+		}
+	}
+	for _, extractor := range request.Extractors {
+		if checkFuzzingBaselineExpressions(extractor.DSL...) || checkFuzzingBaselineExpressions(extractor.Part) {
+			return true
+		}
+	}
+	return false
+}
+
+func checkFuzzingBaselineExpressions(expressions ...string) bool {
+	for _, expression := range expressions {
+		if strings.Contains(expression, "baseline_") {
+			return true
+		}
+	}
+	return false
+}
+
 // ShouldFuzzTarget checks if given target should be fuzzed or not using `filter` field in template
 func (request *Request) ShouldFuzzTarget(input *contextargs.Context) bool {
 	if len(request.FuzzPreCondition) == 0 {
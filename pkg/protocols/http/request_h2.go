@@ -0,0 +1,283 @@
+package http
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/projectdiscovery/gologger"
+	"github.com/projectdiscovery/nuclei/v3/pkg/output"
+	"github.com/projectdiscovery/nuclei/v3/pkg/protocols"
+	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/contextargs"
+	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/expressions"
+	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/generators"
+	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/helpers/eventcreator"
+	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/helpers/responsehighlighter"
+	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/interactsh"
+	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/protocolstate"
+	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/utils/vardump"
+	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/http/h2raw"
+	protocolutils "github.com/projectdiscovery/nuclei/v3/pkg/protocols/utils"
+	urlutil "github.com/projectdiscovery/utils/url"
+)
+
+// errH2NotNegotiated is returned when a tls server does not negotiate http2 with alpn
+var errH2NotNegotiated = errors.New("server did not negotiate http2")
+
+// executeH2 executes the raw http2 frame requests for a URL
+func (request *Request) executeH2(input *contextargs.Context, dynamicValues, previous output.InternalEvent, callback protocols.OutputEventCallback) error {
+	parsed, err := urlutil.ParseAbsoluteURL(input.MetaInput.Input, false)
+	if err != nil {
+		return errors.Wrap(err, "could not parse input url")
+	}
+	if request.options.HasTemplateCtx(input.MetaInput) {
+		dynamicValues = generators.MergeMaps(dynamicValues, request.options.GetTemplateCtx(input.MetaInput).GetAll())
+	}
+	defaultReqVars := protocolutils.GenerateVariables(parsed, false, contextargs.GenerateVariables(input))
+	optionVars := generators.BuildPayloadFromOptions(request.options.Options)
+	variablesMap, interactshURLs := request.options.Variables.EvaluateWithInteractsh(generators.MergeMaps(defaultReqVars, optionVars), request.options.Interactsh)
+	allVars := generators.MergeMaps(dynamicValues, defaultReqVars, optionVars, variablesMap, request.options.Constants)
+	shouldStop := request.options.Options.StopAtFirstMatch || request.options.StopAtFirstMatch || request.StopAtFirstMatch
+
+	// executeRequests returns true if further requests should be skipped
+	executeRequests := func(payloads map[string]interface{}) (bool, error) {
+		for _, h2Request := range request.H2 {
+			gotMatches, err := request.executeH2Request(input, parsed, h2Request, allVars, payloads, interactshURLs, previous, callback)
+			if err != nil {
+				return true, err
+			}
+			if shouldStop && gotMatches {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	if request.generator == nil {
+		_, err := executeRequests(nil)
+		return err
+	}
+	iterator := request.generator.NewIterator()
+	for {
+		payloads, ok := iterator.Value()
+		if !ok {
+			break
+		}
+		if stop, err := executeRequests(payloads); stop {
+			return err
+		}
+	}
+	return nil
+}
+
+// executeH2Request sends the frames of a single raw http2 request on a new connection
+// and returns true if the response matched
+func (request *Request) executeH2Request(input *contextargs.Context, parsed *urlutil.URL, h2Request *h2raw.Request, variables, payloads map[string]interface{}, interactshURLs []string, previous output.InternalEvent, callback protocols.OutputEventCallback) (bool, error) {
+	if request.isUnresponsiveAddress(input) {
+		return false, nil
+	}
+	finalVars := generators.MergeMaps(variables, payloads)
+	if vardump.EnableVarDump {
+		gologger.Debug().Msgf("HTTP/2 Protocol request variables: %s\n", vardump.DumpVariables(finalVars))
+	}
+	evaluated, err := h2Request.Evaluate(func(data string) (string, error) {
+		if request.options.Interactsh != nil {
+			data, interactshURLs = request.options.Interactsh.Replace(data, interactshURLs)
+		}
+		data, err := expressions.Evaluate(data, finalVars)
+		if err != nil {
+			return "", err
+		}
+		if !request.SkipVariablesCheck {
+			if err := expressions.ContainsUnresolvedVariables(data); err != nil {
+				return "", errors.Wrap(ErrMissingVars, err.Error())
+			}
+		}
+		return data, nil
+	})
+	if errors.Is(err, ErrMissingVars) {
+		gologger.Warning().Msgf("[%s] Could not make http2 request for %s: %v\n", request.options.TemplateID, input.MetaInput.Input, err)
+		return false, nil
+	}
+	if err != nil {
+		request.options.Progress.IncrementFailedRequestsBy(1)
+		return false, errors.Wrap(err, "could not evaluate template expressions")
+	}
+
+	hostname := parsed.Hostname()
+	port := parsed.Port()
+	if port == "" {
+		port = "80"
+		if parsed.Scheme == "https" {
+			port = "443"
+		}
+	}
+	address := net.JoinHostPort(hostname, port)
+	timeout := request.options.Options.GetTimeouts().HttpTimeout
+
+	request.options.RateLimitTake()
+	start := time.Now()
+	conn, err := dialH2(request.newContext(input), address, parsed.Scheme == "https")
+	if err != nil {
+		request.markUnresponsiveAddress(input, err)
+		request.options.Output.Request(request.options.TemplatePath, input.MetaInput.Input, request.Type().String(), err)
+		request.options.Progress.IncrementFailedRequestsBy(1)
+		return false, errors.Wrap(err, "could not connect to server")
+	}
+	defer conn.Close()
+
+	dumpedRequest := evaluated.String()
+	if request.options.Options.Debug || request.options.Options.DebugRequests || request.options.Options.StoreResponse {
+		msg := fmt.Sprintf("[%s] Dumped HTTP/2 frames for %s\n\n", request.options.TemplateID, input.MetaInput.Input)
+		if request.options.Options.Debug || request.options.Options.DebugRequests {
+			gologger.Info().Msg(msg)
+			gologger.Print().Msgf("%s", dumpedRequest)
+		}
+		if request.options.Options.StoreResponse {
+			request.options.Output.WriteStoreDebugData(input.MetaInput.Input, request.options.TemplateID, request.Type().String(), fmt.Sprintf("%s\n%s", msg, dumpedRequest))
+		}
+	}
+
+	response, err := evaluated.Do(conn, timeout)
+	request.options.Output.Request(request.options.TemplatePath, input.MetaInput.Input, request.Type().String(), err)
+	if err != nil {
+		request.options.Progress.IncrementFailedRequestsBy(1)
+		return false, errors.Wrap(err, "could not send http2 frames")
+	}
+	request.options.Progress.IncrementRequests()
+	gologger.Verbose().Msgf("[%s] Sent HTTP/2 frames to %s", request.options.TemplateID, input.MetaInput.Input)
+
+	outputEvent := request.h2ResponseToDSLMap(response, input.MetaInput.Input, input.MetaInput.Input, dumpedRequest, time.Since(start))
+	request.options.AddTemplateVars(input.MetaInput, request.Type(), request.ID, outputEvent)
+	if request.options.HasTemplateCtx(input.MetaInput) {
+		outputEvent = generators.MergeMaps(outputEvent, request.options.GetTemplateCtx(input.MetaInput).GetAll())
+	}
+	if input.MetaInput.CustomIP != "" {
+		outputEvent["ip"] = input.MetaInput.CustomIP
+	} else if dialer := protocolstate.GetDialer(); dialer != nil {
+		outputEvent["ip"] = dialer.GetDialedIP(hostname)
+	}
+	if request.options.Interactsh != nil {
+		request.options.Interactsh.MakePlaceholders(interactshURLs, outputEvent)
+	}
+	finalEvent := generators.MergeMaps(previous, payloads, outputEvent)
+
+	var event *output.InternalWrappedEvent
+	var gotMatches bool
+	isDebug := request.options.Options.Debug || request.options.Options.DebugResponse
+	if len(interactshURLs) == 0 || request.options.Interactsh == nil {
+		event = eventcreator.CreateEventWithAdditionalOptions(request, finalEvent, isDebug, func(wrappedEvent *output.InternalWrappedEvent) {
+			wrappedEvent.OperatorsResult.PayloadValues = payloads
+		})
+		gotMatches = event.OperatorsResult != nil && event.OperatorsResult.Matched
+		callback(event)
+	} else {
+		event = &output.InternalWrappedEvent{InternalEvent: finalEvent, UsesInteractsh: true}
+		requestData := &interactsh.RequestData{
+			MakeResultFunc: request.MakeResultEvent,
+			Event:          event,
+			Operators:      request.CompiledOperators,
+			MatchFunc:      request.Match,
+			ExtractFunc:    request.Extract,
+		}
+		request.options.Interactsh.RequestEvent(interactshURLs, requestData)
+		gotMatches = request.options.Interactsh.AlreadyMatched(requestData)
+	}
+
+	cliOptions := request.options.Options
+	if cliOptions.Debug || cliOptions.DebugResponse || cliOptions.StoreResponse {
+		fMsg := fmt.Sprintf("[%s] Dumped HTTP/2 frames received from %s\n\n%s", request.options.TemplateID, input.MetaInput.Input, responsehighlighter.Highlight(event.OperatorsResult, response.String(), cliOptions.NoColor, false))
+		if cliOptions.Debug || cliOptions.DebugResponse {
+			gologger.Debug().Msg(fMsg)
+		}
+		if cliOptions.StoreResponse {
+			request.options.Output.WriteStoreDebugData(input.MetaInput.Input, request.options.TemplateID, request.Type().String(), fMsg)
+		}
+	}
+	return gotMatches, nil
+}
+
+// dialH2 dials a connection for raw http2 frames. TLS connections must
+// negotiate h2 with alpn while plain connections use prior knowledge (h2c).
+func dialH2(ctx context.Context, address string, useTLS bool) (net.Conn, error) {
+	dialer := protocolstate.GetDialer()
+	if dialer == nil {
+		return nil, errors.New("dialer not initialized")
+	}
+	if !useTLS {
+		return dialer.Dial(ctx, "tcp", address)
+	}
+	conn, err := dialer.DialTLSWithConfig(ctx, "tcp", address, &tls.Config{
+		InsecureSkipVerify: true,
+		MinVersion:         tls.VersionTLS12,
+		NextProtos:         []string{"h2"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if tlsConn, ok := conn.(*tls.Conn); ok && tlsConn.ConnectionState().NegotiatedProtocol != "h2" {
+		_ = conn.Close()
+		return nil, errH2NotNegotiated
+	}
+	return conn, nil
+}
+
+// h2ResponseToDSLMap converts the frames received on a http2 connection to a map for use in DSL matching.
+// The fields of the first stream with a response are available without prefix, the fields
+// of all streams are available with the stream_<id>_ prefix.
+func (request *Request) h2ResponseToDSLMap(response *h2raw.Response, host, matched, rawReq string, duration time.Duration) output.InternalEvent {
+	data := make(output.InternalEvent)
+	data["host"] = host
+	data["type"] = request.Type().String()
+	data["matched"] = matched
+	data["proto"] = "HTTP/2.0"
+	request.setHashOrDefault(data, "request", rawReq)
+	request.setHashOrDefault(data, "response", response.String())
+	data["duration"] = duration.Seconds()
+	data["template-id"] = request.options.TemplateID
+	data["template-info"] = request.options.TemplateInfo
+	data["template-path"] = request.options.TemplatePath
+
+	var first *h2raw.StreamResponse
+	for _, id := range response.StreamIDs() {
+		stream := response.Streams[id]
+		prefix := "stream_" + strconv.FormatUint(uint64(id), 10) + "_"
+		statusCode, _ := strconv.Atoi(stream.Status)
+		data[prefix+"status_code"] = statusCode
+		request.setHashOrDefault(data, prefix+"body", stream.Body.String())
+		request.setHashOrDefault(data, prefix+"header", stream.HeadersString())
+		if stream.Reset {
+			data[prefix+"error_code"] = stream.ErrorCode.String()
+		}
+		if first == nil && stream.Status != "" {
+			first = stream
+		}
+	}
+	if first != nil {
+		data["status_code"], _ = strconv.Atoi(first.Status)
+		request.setHashOrDefault(data, "body", first.Body.String())
+		request.setHashOrDefault(data, "all_headers", first.HeadersString())
+		request.setHashOrDefault(data, "header", first.HeadersString())
+		data["content_length"] = first.Body.Len()
+	} else {
+		data["status_code"] = 0
+	}
+
+	data["goaway"] = response.GoAway != nil
+	if response.GoAway != nil {
+		data["goaway_error_code"] = response.GoAway.ErrorCode.String()
+		data["goaway_last_stream_id"] = int(response.GoAway.LastStreamID)
+		data["goaway_debug"] = response.GoAway.DebugData
+	}
+	data["rst_stream_count"] = response.RSTStreamCount
+	data["connection_closed"] = response.ConnectionClosed
+
+	if request.StopAtFirstMatch || request.options.StopAtFirstMatch {
+		data["stop-at-first-match"] = true
+	}
+	return data
+}
@@ -3,10 +3,13 @@ package http
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 
+	"github.com/quic-go/quic-go/http3"
 	"github.com/stretchr/testify/require"
 
 	"github.com/projectdiscovery/nuclei/v3/pkg/model"
@@ -17,6 +20,7 @@ import (
 	"github.com/projectdiscovery/nuclei/v3/pkg/output"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/contextargs"
 	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/common/interactsh"
+	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/http/h2raw"
 	"github.com/projectdiscovery/nuclei/v3/pkg/testutils"
 )
 
@@ -257,3 +261,152 @@ func TestReqURLPattern(t *testing.T) {
 	require.NotEmpty(t, finalEvent.Results[0].ReqURLPattern, "could not get req url pattern")
 	require.Equal(t, `/{{rand_char("abc")}}/{{interactsh-url}}/123?query={{rand_int(1, 10)}}&data={{randstr}}`, finalEvent.Results[0].ReqURLPattern)
 }
+
+func TestH2RawFrames(t *testing.T) {
+	options := testutils.DefaultOptions
+
+	testutils.Init(options)
+	templateID := "http-h2-raw-frames"
+
+	stream := func(id uint32) *uint32 { return &id }
+	request := &Request{
+		ID: templateID,
+		H2: []*h2raw.Request{{Frames: []*h2raw.Frame{
+			{Type: "headers", Headers: []string{":method: GET", ":scheme: https", ":authority: {{Hostname}}", ":path: /first"}, EndStream: true},
+			{Type: "headers", Stream: stream(3), Headers: []string{":method: GET", ":scheme: https", ":authority: {{Hostname}}", ":path: /second"}, EndStream: true},
+		}}},
+		Operators: operators.Operators{
+			Matchers: []*matchers.Matcher{{
+				Type: matchers.MatcherTypeHolder{MatcherType: matchers.DSLMatcher},
+				DSL:  []string{"status_code == 200 && body == 'hello /first' && stream_3_body == 'hello /second' && !goaway"},
+			}},
+		},
+	}
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, "hello %s", r.URL.Path)
+	}))
+	ts.EnableHTTP2 = true
+	ts.StartTLS()
+	defer ts.Close()
+
+	executerOpts := testutils.NewMockExecuterOptions(options, &testutils.TemplateInfo{
+		ID:   templateID,
+		Info: model.Info{SeverityHolder: severity.Holder{Severity: severity.Low}, Name: "test"},
+	})
+	err := request.Compile(executerOpts)
+	require.Nil(t, err, "could not compile http h2 request")
+	require.Equal(t, 1, request.Requests(), "could not get correct number of requests")
+
+	var finalEvent *output.InternalWrappedEvent
+	ctxArgs := contextargs.NewWithInput(context.Background(), ts.URL)
+	err = request.ExecuteWithResults(ctxArgs, make(output.InternalEvent), make(output.InternalEvent), func(event *output.InternalWrappedEvent) {
+		finalEvent = event
+	})
+	require.Nil(t, err, "could not execute http h2 request")
+	require.NotNil(t, finalEvent, "could not get event output from request")
+	require.True(t, finalEvent.OperatorsResult != nil && finalEvent.OperatorsResult.Matched, "could not match h2 response")
+
+	request.Raw = []string{"GET / HTTP/1.1\r\nHost: {{Hostname}}\r\n\r\n"}
+	require.NotNil(t, request.validate(), "could use h2 with raw requests")
+}
+
+func TestH2StopAtFirstMatch(t *testing.T) {
+	options := testutils.DefaultOptions
+
+	testutils.Init(options)
+	templateID := "http-h2-stop-at-first-match"
+
+	frames := func(path string) *h2raw.Request {
+		return &h2raw.Request{Frames: []*h2raw.Frame{
+			{Type: "headers", Headers: []string{":method: GET", ":scheme: https", ":authority: {{Hostname}}", ":path: " + path}, EndStream: true},
+		}}
+	}
+	request := &Request{
+		ID:               templateID,
+		H2:               []*h2raw.Request{frames("/first"), frames("/second")},
+		StopAtFirstMatch: true,
+		Operators: operators.Operators{
+			Matchers: []*matchers.Matcher{{
+				Type:   matchers.MatcherTypeHolder{MatcherType: matchers.StatusMatcher},
+				Status: []int{200},
+			}},
+		},
+	}
+	var requests atomic.Int32
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+	}))
+	ts.EnableHTTP2 = true
+	ts.StartTLS()
+	defer ts.Close()
+
+	executerOpts := testutils.NewMockExecuterOptions(options, &testutils.TemplateInfo{
+		ID:   templateID,
+		Info: model.Info{SeverityHolder: severity.Holder{Severity: severity.Low}, Name: "test"},
+	})
+	err := request.Compile(executerOpts)
+	require.Nil(t, err, "could not compile http h2 request")
+
+	var matches int
+	ctxArgs := contextargs.NewWithInput(context.Background(), ts.URL)
+	err = request.ExecuteWithResults(ctxArgs, make(output.InternalEvent), make(output.InternalEvent), func(event *output.InternalWrappedEvent) {
+		if event.OperatorsResult != nil && event.OperatorsResult.Matched {
+			matches++
+		}
+	})
+	require.Nil(t, err, "could not execute http h2 request")
+	require.Equal(t, 1, matches, "could not get correct match count")
+	require.Equal(t, int32(1), requests.Load(), "requests after the first match should be skipped")
+}
+
+func TestHTTP3Request(t *testing.T) {
+	options := testutils.DefaultOptions
+
+	testutils.Init(options)
+	templateID := "testing-http3"
+	request := &Request{
+		ID:     templateID,
+		Method: HTTPMethodTypeHolder{MethodType: HTTPGet},
+		Path:   []string{"{{BaseURL}}"},
+		HTTP3:  true,
+		Operators: operators.Operators{
+			Matchers: []*matchers.Matcher{{
+				Type: matchers.MatcherTypeHolder{MatcherType: matchers.DSLMatcher},
+				DSL:  []string{`proto == "HTTP/3.0" && body == "quic"`},
+			}},
+		},
+	}
+
+	// the certificate of the tls test server is reused for the quic server
+	tlsServer := httptest.NewTLSServer(http.NotFoundHandler())
+	tlsServer.Close()
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.Nil(t, err, "could not create udp socket")
+	server := &http3.Server{
+		TLSConfig: http3.ConfigureTLSConfig(tlsServer.TLS),
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("quic"))
+		}),
+	}
+	go func() {
+		_ = server.Serve(conn)
+	}()
+	defer server.Close()
+
+	executerOpts := testutils.NewMockExecuterOptions(options, &testutils.TemplateInfo{
+		ID:   templateID,
+		Info: model.Info{SeverityHolder: severity.Holder{Severity: severity.Low}, Name: "test"},
+	})
+	err = request.Compile(executerOpts)
+	require.Nil(t, err, "could not compile http request")
+
+	var matched bool
+	ctxArgs := contextargs.NewWithInput(context.Background(), "https://"+conn.LocalAddr().String())
+	err = request.ExecuteWithResults(ctxArgs, make(output.InternalEvent), make(output.InternalEvent), func(event *output.InternalWrappedEvent) {
+		if event.OperatorsResult != nil && event.OperatorsResult.Matched {
+			matched = true
+		}
+	})
+	require.Nil(t, err, "could not execute http3 request")
+	require.True(t, matched, "could not match http3 response")
+}
@@ -16,12 +16,20 @@ type SignatureType int
 // Supported values for the SignatureType
 const (
 	AWSSignature SignatureType = iota + 1
+	HTTPMessageSignature
+	OAuth1Signature
+	AzureSharedKeySignature
+	GCPHMACSignature
 	signatureLimit
 )
 
 // signatureTypeMappings is a table for conversion of signature type from string.
 var signatureTypeMappings = map[SignatureType]string{
-	AWSSignature: "AWS",
+	AWSSignature:            "AWS",
+	HTTPMessageSignature:    "HTTPSIG",
+	OAuth1Signature:         "OAUTH1",
+	AzureSharedKeySignature: "AZURE-SHARED-KEY",
+	GCPHMACSignature:        "GCP-HMAC",
 }
 
 func GetSupportedSignaturesTypes() []SignatureType {
@@ -107,6 +115,8 @@ func GetVariablesNamesSkipList(signature SignatureType) map[string]interface{} {
 	switch signature {
 	case AWSSignature:
 		return signer.AwsSkipList
+	case GCPHMACSignature:
+		return signer.GcpSkipList
 	default:
 		return nil
 	}
@@ -114,8 +124,11 @@ func GetVariablesNamesSkipList(signature SignatureType) map[string]interface{} {
 
 // GetDefaultSignerVars returns the default signer variables
 func GetDefaultSignerVars(signatureType SignatureType) map[string]interface{} {
-	if signatureType == AWSSignature {
+	switch signatureType {
+	case AWSSignature:
 		return signer.AwsDefaultVars
+	case GCPHMACSignature:
+		return signer.GcpDefaultVars
 	}
 	return map[string]interface{}{}
 }
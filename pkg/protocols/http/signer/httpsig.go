@@ -0,0 +1,278 @@
+package signer
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	errorutil "github.com/projectdiscovery/utils/errors"
+)
+
+// Supported algorithms of HTTP message signatures
+const (
+	HTTPSigHMACSHA256      = "hmac-sha256"
+	HTTPSigRSAPSSSHA512    = "rsa-pss-sha512"
+	HTTPSigRSAv15SHA256    = "rsa-v1_5-sha256"
+	HTTPSigECDSAP256SHA256 = "ecdsa-p256-sha256"
+	HTTPSigECDSAP384SHA384 = "ecdsa-p384-sha384"
+	HTTPSigEd25519         = "ed25519"
+)
+
+const defaultHTTPSigLabel = "sig1"
+
+// HTTPSignatureOptions are the options of HTTP message signatures (RFC 9421)
+type HTTPSignatureOptions struct {
+	// KeyID is the identifier of the key sent in the signature parameters
+	KeyID string
+	// Key is the shared secret of hmac-sha256 or the pem encoded
+	// private key (or path of the file containing it) of other algorithms
+	Key string
+	// Algorithm is the signature algorithm (default hmac-sha256)
+	Algorithm string
+	// Components are the covered components of the signature. By default
+	// @method, @authority, @path and @query are covered, as well as
+	// content-digest and content-type for requests with a body.
+	Components []string
+	// Label is the label of the signature (default sig1)
+	Label string
+	// Tag is the optional application specific tag of the signature
+	Tag string
+}
+
+// Validate Signature Arguments
+func (o *HTTPSignatureOptions) Validate() error {
+	if o.KeyID == "" {
+		return errors.New("http signature key id cannot be empty")
+	}
+	if o.Key == "" {
+		return errors.New("http signature key cannot be empty")
+	}
+	switch o.Algorithm {
+	case HTTPSigHMACSHA256, HTTPSigRSAPSSSHA512, HTTPSigRSAv15SHA256, HTTPSigECDSAP256SHA256, HTTPSigECDSAP384SHA384, HTTPSigEd25519:
+	default:
+		return fmt.Errorf("unsupported http signature algorithm %q", o.Algorithm)
+	}
+	return nil
+}
+
+// HTTPSigner signs requests with HTTP message signatures
+type HTTPSigner struct {
+	options *HTTPSignatureOptions
+	key     interface{}
+	now     func() time.Time
+}
+
+// NewHTTPSignatureSigner creates a new HTTP message signature signer
+func NewHTTPSignatureSigner(opts *HTTPSignatureOptions) (*HTTPSigner, error) {
+	if opts.Algorithm == "" {
+		opts.Algorithm = HTTPSigHMACSHA256
+	}
+	opts.Algorithm = strings.ToLower(opts.Algorithm)
+	if opts.Label == "" {
+		opts.Label = defaultHTTPSigLabel
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	key, err := parseHTTPSigKey(opts.Algorithm, opts.Key)
+	if err != nil {
+		return nil, errorutil.NewWithErr(err).Msgf("could not parse http signature key")
+	}
+	return &HTTPSigner{options: opts, key: key, now: time.Now}, nil
+}
+
+// SignHTTP adds the Signature-Input and Signature headers to the request
+func (s *HTTPSigner) SignHTTP(ctx context.Context, request *http.Request) error {
+	body, err := readBody(request)
+	if err != nil {
+		return err
+	}
+	components := s.options.Components
+	if len(components) == 0 {
+		components = []string{"@method", "@authority", "@path", "@query"}
+		if len(body) > 0 {
+			components = append(components, "content-digest")
+			if request.Header.Get("Content-Type") != "" {
+				components = append(components, "content-type")
+			}
+		}
+	}
+	for _, component := range components {
+		if strings.EqualFold(component, "content-digest") && request.Header.Get("Content-Digest") == "" {
+			digest := sha256.Sum256(body)
+			request.Header.Set("Content-Digest", "sha-256=:"+base64.StdEncoding.EncodeToString(digest[:])+":")
+		}
+	}
+
+	params := fmt.Sprintf(";created=%d;keyid=%s;alg=%s", s.now().Unix(), strconv.Quote(s.options.KeyID), strconv.Quote(s.options.Algorithm))
+	if s.options.Tag != "" {
+		params += ";tag=" + strconv.Quote(s.options.Tag)
+	}
+	signatureInput, base, err := httpSignatureBase(request, components, params)
+	if err != nil {
+		return err
+	}
+	signature, err := s.sign([]byte(base))
+	if err != nil {
+		return errorutil.NewWithErr(err).Msgf("failed to sign http request using http message signature")
+	}
+	request.Header.Set("Signature-Input", s.options.Label+"="+signatureInput)
+	request.Header.Set("Signature", s.options.Label+"=:"+base64.StdEncoding.EncodeToString(signature)+":")
+	return nil
+}
+
+// sign signs the signature base with the key of the signer
+func (s *HTTPSigner) sign(base []byte) ([]byte, error) {
+	switch s.options.Algorithm {
+	case HTTPSigHMACSHA256:
+		mac := hmac.New(sha256.New, s.key.([]byte))
+		mac.Write(base)
+		return mac.Sum(nil), nil
+	case HTTPSigRSAPSSSHA512:
+		digest := sha512.Sum512(base)
+		return rsa.SignPSS(rand.Reader, s.key.(*rsa.PrivateKey), crypto.SHA512, digest[:], &rsa.PSSOptions{SaltLength: 64})
+	case HTTPSigRSAv15SHA256:
+		digest := sha256.Sum256(base)
+		return rsa.SignPKCS1v15(rand.Reader, s.key.(*rsa.PrivateKey), crypto.SHA256, digest[:])
+	case HTTPSigECDSAP256SHA256:
+		digest := sha256.Sum256(base)
+		return signECDSA(s.key.(*ecdsa.PrivateKey), digest[:], 32)
+	case HTTPSigECDSAP384SHA384:
+		digest := sha512.Sum384(base)
+		return signECDSA(s.key.(*ecdsa.PrivateKey), digest[:], 48)
+	case HTTPSigEd25519:
+		return ed25519.Sign(s.key.(ed25519.PrivateKey), base), nil
+	}
+	return nil, fmt.Errorf("unsupported http signature algorithm %q", s.options.Algorithm)
+}
+
+// signECDSA returns the signature as the concatenation of fixed size r and s values
+func signECDSA(key *ecdsa.PrivateKey, digest []byte, size int) ([]byte, error) {
+	r, sv, err := ecdsa.Sign(rand.Reader, key, digest)
+	if err != nil {
+		return nil, err
+	}
+	signature := make([]byte, 2*size)
+	r.FillBytes(signature[:size])
+	sv.FillBytes(signature[size:])
+	return signature, nil
+}
+
+// httpSignatureBase returns the serialized signature parameters and the signature base of a request
+func httpSignatureBase(request *http.Request, components []string, params string) (string, string, error) {
+	builder := &strings.Builder{}
+	quoted := make([]string, 0, len(components))
+	for _, component := range components {
+		name := strings.ToLower(strings.TrimSpace(component))
+		value, err := httpSignatureComponent(request, name)
+		if err != nil {
+			return "", "", err
+		}
+		quoted = append(quoted, strconv.Quote(name))
+		builder.WriteString(strconv.Quote(name) + ": " + value + "\n")
+	}
+	signatureInput := "(" + strings.Join(quoted, " ") + ")" + params
+	builder.WriteString(`"@signature-params": ` + signatureInput)
+	return signatureInput, builder.String(), nil
+}
+
+// httpSignatureComponent returns the value of a derived component or header of a request
+func httpSignatureComponent(request *http.Request, name string) (string, error) {
+	path, query := splitRequestURI(request)
+	switch name {
+	case "@method":
+		return strings.ToUpper(request.Method), nil
+	case "@target-uri":
+		return strings.ToLower(request.URL.Scheme) + "://" + requestAuthority(request) + request.URL.RequestURI(), nil
+	case "@authority", "host":
+		return requestAuthority(request), nil
+	case "@scheme":
+		return strings.ToLower(request.URL.Scheme), nil
+	case "@request-target":
+		return request.URL.RequestURI(), nil
+	case "@path":
+		return path, nil
+	case "@query":
+		return "?" + query, nil
+	}
+	if strings.HasPrefix(name, "@") {
+		return "", fmt.Errorf("unsupported http signature component %q", name)
+	}
+	values := request.Header.Values(name)
+	if len(values) == 0 {
+		return "", fmt.Errorf("http signature component %q is not present in request", name)
+	}
+	for i, value := range values {
+		values[i] = strings.TrimSpace(value)
+	}
+	return strings.Join(values, ", "), nil
+}
+
+// parseHTTPSigKey parses the key of an algorithm
+func parseHTTPSigKey(algorithm, value string) (interface{}, error) {
+	if algorithm == HTTPSigHMACSHA256 {
+		return []byte(value), nil
+	}
+	data := []byte(value)
+	if !strings.Contains(value, "-----BEGIN") {
+		fileData, err := os.ReadFile(value)
+		if err != nil {
+			return nil, err
+		}
+		data = fileData
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no pem encoded key found")
+	}
+	var key interface{}
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch key := key.(type) {
+	case *rsa.PrivateKey:
+		if algorithm == HTTPSigRSAPSSSHA512 || algorithm == HTTPSigRSAv15SHA256 {
+			return key, nil
+		}
+	case *ecdsa.PrivateKey:
+		if (algorithm == HTTPSigECDSAP256SHA256 && key.Curve.Params().BitSize == 256) || (algorithm == HTTPSigECDSAP384SHA384 && key.Curve.Params().BitSize == 384) {
+			return key, nil
+		}
+	case ed25519.PrivateKey:
+		if algorithm == HTTPSigEd25519 {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("key of type %T can not be used with %s", key, algorithm)
+}
+
+var HTTPSigInternalOnlyVars = map[string]interface{}{
+	"httpsig-key-id": struct{}{},
+	"httpsig-key":    struct{}{},
+}
@@ -0,0 +1,188 @@
+package signer
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"mime"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Supported signature methods of OAuth 1.0a
+const (
+	OAuth1HMACSHA1   = "HMAC-SHA1"
+	OAuth1HMACSHA256 = "HMAC-SHA256"
+	OAuth1Plaintext  = "PLAINTEXT"
+)
+
+// OAuth1Options are the options of OAuth 1.0a signatures (RFC 5849)
+type OAuth1Options struct {
+	ConsumerKey    string
+	ConsumerSecret string
+	// Token and TokenSecret (optional) are the credentials of the resource owner
+	Token       string
+	TokenSecret string
+	// SignatureMethod is the signature method (default HMAC-SHA1)
+	SignatureMethod string
+	// Realm (optional) is the realm of the authorization header
+	Realm string
+}
+
+// Validate Signature Arguments
+func (o *OAuth1Options) Validate() error {
+	if o.ConsumerKey == "" {
+		return errors.New("oauth consumer key cannot be empty")
+	}
+	if o.ConsumerSecret == "" {
+		return errors.New("oauth consumer secret cannot be empty")
+	}
+	switch o.SignatureMethod {
+	case OAuth1HMACSHA1, OAuth1HMACSHA256, OAuth1Plaintext:
+	default:
+		return fmt.Errorf("unsupported oauth signature method %q", o.SignatureMethod)
+	}
+	return nil
+}
+
+// OAuth1Signer signs requests with OAuth 1.0a authorization headers
+type OAuth1Signer struct {
+	options *OAuth1Options
+	now     func() time.Time
+	nonce   func() string
+}
+
+// NewOAuth1Signer creates a new OAuth 1.0a signer
+func NewOAuth1Signer(opts *OAuth1Options) (*OAuth1Signer, error) {
+	if opts.SignatureMethod == "" {
+		opts.SignatureMethod = OAuth1HMACSHA1
+	}
+	opts.SignatureMethod = strings.ToUpper(opts.SignatureMethod)
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	return &OAuth1Signer{options: opts, now: time.Now, nonce: oauthNonce}, nil
+}
+
+// SignHTTP sets the OAuth authorization header of the request
+func (s *OAuth1Signer) SignHTTP(ctx context.Context, request *http.Request) error {
+	oauthParams := map[string]string{
+		"oauth_consumer_key":     s.options.ConsumerKey,
+		"oauth_nonce":            s.nonce(),
+		"oauth_signature_method": s.options.SignatureMethod,
+		"oauth_timestamp":        strconv.FormatInt(s.now().Unix(), 10),
+		"oauth_version":          "1.0",
+	}
+	if s.options.Token != "" {
+		oauthParams["oauth_token"] = s.options.Token
+	}
+
+	key := oauthEscape(s.options.ConsumerSecret) + "&" + oauthEscape(s.options.TokenSecret)
+	var signature string
+	if s.options.SignatureMethod == OAuth1Plaintext {
+		signature = key
+	} else {
+		base, err := s.signatureBase(request, oauthParams)
+		if err != nil {
+			return err
+		}
+		var hashFunc func() hash.Hash = sha1.New
+		if s.options.SignatureMethod == OAuth1HMACSHA256 {
+			hashFunc = sha256.New
+		}
+		mac := hmac.New(hashFunc, []byte(key))
+		mac.Write([]byte(base))
+		signature = base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	}
+	oauthParams["oauth_signature"] = signature
+
+	names := make([]string, 0, len(oauthParams))
+	for name := range oauthParams {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	parts := make([]string, 0, len(names)+1)
+	if s.options.Realm != "" {
+		parts = append(parts, fmt.Sprintf(`realm="%s"`, oauthEscape(s.options.Realm)))
+	}
+	for _, name := range names {
+		parts = append(parts, fmt.Sprintf(`%s="%s"`, name, oauthEscape(oauthParams[name])))
+	}
+	request.Header.Set("Authorization", "OAuth "+strings.Join(parts, ", "))
+	return nil
+}
+
+// signatureBase returns the signature base string of a request (RFC 5849 3.4.1)
+func (s *OAuth1Signer) signatureBase(request *http.Request, oauthParams map[string]string) (string, error) {
+	path, query := splitRequestURI(request)
+	params, err := url.ParseQuery(query)
+	if err != nil {
+		return "", err
+	}
+	// form encoded bodies are part of the signed parameters
+	if mediaType, _, _ := mime.ParseMediaType(request.Header.Get("Content-Type")); mediaType == "application/x-www-form-urlencoded" {
+		body, err := readBody(request)
+		if err != nil {
+			return "", err
+		}
+		bodyParams, err := url.ParseQuery(string(body))
+		if err != nil {
+			return "", err
+		}
+		for name, values := range bodyParams {
+			params[name] = append(params[name], values...)
+		}
+	}
+	for name, value := range oauthParams {
+		params.Set(name, value)
+	}
+
+	pairs := make([]string, 0, len(params))
+	for name, values := range params {
+		for _, value := range values {
+			pairs = append(pairs, oauthEscape(name)+"="+oauthEscape(value))
+		}
+	}
+	sort.Strings(pairs)
+
+	scheme := strings.ToLower(request.URL.Scheme)
+	baseURI := scheme + "://" + requestAuthority(request) + path
+	return strings.ToUpper(request.Method) + "&" + oauthEscape(baseURI) + "&" + oauthEscape(strings.Join(pairs, "&")), nil
+}
+
+// oauthEscape percent encodes all characters except the unreserved ones (RFC 5849 3.6)
+func oauthEscape(value string) string {
+	builder := &strings.Builder{}
+	for _, b := range []byte(value) {
+		if ('A' <= b && b <= 'Z') || ('a' <= b && b <= 'z') || ('0' <= b && b <= '9') || b == '-' || b == '.' || b == '_' || b == '~' {
+			builder.WriteByte(b)
+		} else {
+			fmt.Fprintf(builder, "%%%02X", b)
+		}
+	}
+	return builder.String()
+}
+
+func oauthNonce() string {
+	data := make([]byte, 16)
+	_, _ = rand.Read(data)
+	return hex.EncodeToString(data)
+}
+
+var OAuth1InternalOnlyVars = map[string]interface{}{
+	"oauth-consumer-key":    struct{}{},
+	"oauth-consumer-secret": struct{}{},
+	"oauth-token":           struct{}{},
+	"oauth-token-secret":    struct{}{},
+}
@@ -1,11 +1,16 @@
 package signer
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"io"
 	"net/http"
+	"os"
+	"strings"
 
 	"github.com/projectdiscovery/nuclei/v3/pkg/types"
+	"github.com/projectdiscovery/utils/reader"
 )
 
 // An Argument that can be passed to Signer
@@ -30,6 +35,30 @@ func NewSigner(args SignerArgs) (signer Signer, err error) {
 			}
 		}
 		return awsSigner, err
+	case *HTTPSignatureOptions:
+		httpSigner, err := NewHTTPSignatureSigner(signerArgs)
+		if err != nil {
+			return nil, err
+		}
+		return httpSigner, nil
+	case *OAuth1Options:
+		oauthSigner, err := NewOAuth1Signer(signerArgs)
+		if err != nil {
+			return nil, err
+		}
+		return oauthSigner, nil
+	case *AzureSharedKeyOptions:
+		azureSigner, err := NewAzureSharedKeySigner(signerArgs)
+		if err != nil {
+			return nil, err
+		}
+		return azureSigner, nil
+	case *GCPHMACOptions:
+		gcpSigner, err := NewGCPHMACSigner(signerArgs)
+		if err != nil {
+			return nil, err
+		}
+		return gcpSigner, nil
 	default:
 		return nil, errors.New("unknown signature arguments type")
 	}
@@ -52,3 +81,54 @@ func GetCtxWithArgs(maps ...map[string]interface{}) context.Context {
 	ctx := context.WithValue(context.Background(), SignerArg("service"), service)
 	return context.WithValue(ctx, SignerArg("region"), region)
 }
+
+// GetArg returns a signer argument from the variables (-var) or from the
+// environment variable with the uppercase name (e.g. oauth-token => OAUTH_TOKEN)
+func GetArg(vars map[string]interface{}, name string) string {
+	if value := types.ToString(vars[name]); value != "" {
+		return value
+	}
+	return os.Getenv(strings.ToUpper(strings.ReplaceAll(name, "-", "_")))
+}
+
+// readBody returns the body of a request and replaces it
+// with a new reader if it can not be read again
+func readBody(request *http.Request) ([]byte, error) {
+	if request.Body == nil || request.Body == http.NoBody {
+		return nil, nil
+	}
+	data, err := io.ReadAll(request.Body)
+	if err != nil {
+		return nil, err
+	}
+	// reusable bodies are rewound once read completely
+	if _, ok := request.Body.(*reader.ReusableReadCloser); !ok {
+		request.Body = io.NopCloser(bytes.NewReader(data))
+	}
+	return data, nil
+}
+
+// requestAuthority returns the lowercase host of a request without the default port of the scheme
+func requestAuthority(request *http.Request) string {
+	host := request.Host
+	if host == "" {
+		host = request.URL.Host
+	}
+	host = strings.ToLower(host)
+	switch {
+	case strings.EqualFold(request.URL.Scheme, "https"):
+		host = strings.TrimSuffix(host, ":443")
+	case strings.EqualFold(request.URL.Scheme, "http"):
+		host = strings.TrimSuffix(host, ":80")
+	}
+	return host
+}
+
+// splitRequestURI returns the escaped path and the raw query of a request as sent
+func splitRequestURI(request *http.Request) (string, string) {
+	path, query, _ := strings.Cut(request.URL.RequestURI(), "?")
+	if path == "" {
+		path = "/"
+	}
+	return path, query
+}
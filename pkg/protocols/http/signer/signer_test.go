@@ -0,0 +1,206 @@
+package signer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPSignatureBase(t *testing.T) {
+	// test case B.2.5 of RFC 9421
+	request, err := http.NewRequest(http.MethodPost, "https://example.com/foo?param=Value&Pet=dog", strings.NewReader(`{"hello": "world"}`))
+	require.NoError(t, err)
+	request.Header.Set("Date", "Tue, 20 Apr 2021 02:07:55 GMT")
+	request.Header.Set("Content-Type", "application/json")
+
+	key, err := base64.StdEncoding.DecodeString("uzvJfB4u3N0Jy4T7NZ75MDVcr8zSTInedJtkgcu46YW4XByzNJjxBdtjUkdJPBtbmHhIDi6pcl8jsasjlTMtDQ==")
+	require.NoError(t, err)
+	signer, err := NewHTTPSignatureSigner(&HTTPSignatureOptions{KeyID: "test-shared-secret", Key: string(key)})
+	require.NoError(t, err)
+
+	signatureInput, base, err := httpSignatureBase(request, []string{"date", "@authority", "content-type"}, `;created=1618884473;keyid="test-shared-secret"`)
+	require.NoError(t, err)
+	require.Equal(t, `("date" "@authority" "content-type");created=1618884473;keyid="test-shared-secret"`, signatureInput)
+	require.Equal(t, "\"date\": Tue, 20 Apr 2021 02:07:55 GMT\n\"@authority\": example.com\n\"content-type\": application/json\n\"@signature-params\": "+signatureInput, base)
+
+	signature, err := signer.sign([]byte(base))
+	require.NoError(t, err)
+	require.Equal(t, "pxcQw6G3AjtMBQjwo8XzkZf/bws5LelbaMk5rGIGtE8=", base64.StdEncoding.EncodeToString(signature))
+}
+
+func TestHTTPSignatureSigner(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err)
+
+	signer, err := NewHTTPSignatureSigner(&HTTPSignatureOptions{
+		KeyID:     "test-key-ed25519",
+		Key:       string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+		Algorithm: "ed25519",
+	})
+	require.NoError(t, err)
+	signer.now = func() time.Time { return time.Unix(1618884473, 0) }
+
+	body := `{"hello": "world"}`
+	request, err := http.NewRequest(http.MethodPost, "https://Example.com:443/foo?param=Value", strings.NewReader(body))
+	require.NoError(t, err)
+	request.Header.Set("Content-Type", "application/json")
+	require.NoError(t, signer.SignHTTP(context.Background(), request))
+
+	digest := sha256.Sum256([]byte(body))
+	require.Equal(t, "sha-256=:"+base64.StdEncoding.EncodeToString(digest[:])+":", request.Header.Get("Content-Digest"))
+	signatureInput := `("@method" "@authority" "@path" "@query" "content-digest" "content-type");created=1618884473;keyid="test-key-ed25519";alg="ed25519"`
+	require.Equal(t, "sig1="+signatureInput, request.Header.Get("Signature-Input"))
+
+	base := strings.Join([]string{
+		`"@method": POST`,
+		`"@authority": example.com`,
+		`"@path": /foo`,
+		`"@query": ?param=Value`,
+		`"content-digest": ` + request.Header.Get("Content-Digest"),
+		`"content-type": application/json`,
+		`"@signature-params": ` + signatureInput,
+	}, "\n")
+	signature, err := base64.StdEncoding.DecodeString(strings.TrimSuffix(strings.TrimPrefix(request.Header.Get("Signature"), "sig1=:"), ":"))
+	require.NoError(t, err)
+	require.True(t, ed25519.Verify(publicKey, []byte(base), signature), "could not verify signature")
+
+	// the body can still be read after signing
+	data := make([]byte, len(body))
+	_, err = request.Body.Read(data)
+	require.NoError(t, err)
+	require.Equal(t, body, string(data))
+}
+
+func TestHTTPSignatureECDSA(t *testing.T) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalECPrivateKey(privateKey)
+	require.NoError(t, err)
+
+	signer, err := NewHTTPSignatureSigner(&HTTPSignatureOptions{
+		KeyID:      "test-key-ecc-p256",
+		Key:        string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})),
+		Algorithm:  "ecdsa-p256-sha256",
+		Components: []string{"@method", "@target-uri"},
+	})
+	require.NoError(t, err)
+
+	request, err := http.NewRequest(http.MethodGet, "https://example.com/foo", nil)
+	require.NoError(t, err)
+	require.NoError(t, signer.SignHTTP(context.Background(), request))
+
+	signatureInput := strings.TrimPrefix(request.Header.Get("Signature-Input"), "sig1=")
+	base := "\"@method\": GET\n\"@target-uri\": https://example.com/foo\n\"@signature-params\": " + signatureInput
+	signature, err := base64.StdEncoding.DecodeString(strings.TrimSuffix(strings.TrimPrefix(request.Header.Get("Signature"), "sig1=:"), ":"))
+	require.NoError(t, err)
+	require.Len(t, signature, 64)
+	digest := sha256.Sum256([]byte(base))
+	r, s := new(big.Int).SetBytes(signature[:32]), new(big.Int).SetBytes(signature[32:])
+	require.True(t, ecdsa.Verify(&privateKey.PublicKey, digest[:], r, s), "could not verify signature")
+
+	_, err = NewHTTPSignatureSigner(&HTTPSignatureOptions{KeyID: "test", Key: string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})), Algorithm: "ed25519"})
+	require.Error(t, err, "key type is not checked")
+}
+
+func TestOAuth1Signer(t *testing.T) {
+	// example of the "creating a signature" twitter api documentation
+	signer, err := NewOAuth1Signer(&OAuth1Options{
+		ConsumerKey:    "xvz1evFS4wEEPTGEFPHBog",
+		ConsumerSecret: "kAcSOqF21Fu85e7zjz7ZN2U4ZRhfV3WpwPAoE3Z7kBw",
+		Token:          "370773112-GmHxMAgYyLbNEtIKZeRNFsMKPR9EyMZeS9weJAEb",
+		TokenSecret:    "LswwdoUaIvS8ltyTt5jkRh4J50vUPVVHtR2YPi5kE",
+	})
+	require.NoError(t, err)
+	signer.now = func() time.Time { return time.Unix(1318622958, 0) }
+	signer.nonce = func() string { return "kYjzVBB8Y0ZFabxSWbWovY3uYSQ2pTgmZeNu2VS4cg" }
+
+	body := "status=Hello%20Ladies%20%2b%20Gentlemen%2c%20a%20signed%20OAuth%20request%21"
+	request, err := http.NewRequest(http.MethodPost, "https://api.twitter.com/1.1/statuses/update.json?include_entities=true", strings.NewReader(body))
+	require.NoError(t, err)
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	require.NoError(t, signer.SignHTTP(context.Background(), request))
+
+	authorization := request.Header.Get("Authorization")
+	require.True(t, strings.HasPrefix(authorization, "OAuth "))
+	require.Contains(t, authorization, `oauth_signature="hCtSmYh%2BiHYCEqBWrE7C7hYmtUk%3D"`)
+	require.Contains(t, authorization, `oauth_token="370773112-GmHxMAgYyLbNEtIKZeRNFsMKPR9EyMZeS9weJAEb"`)
+}
+
+func TestAzureSharedKeySigner(t *testing.T) {
+	key := base64.StdEncoding.EncodeToString([]byte("secret"))
+	signer, err := NewAzureSharedKeySigner(&AzureSharedKeyOptions{Account: "myaccount", Key: key})
+	require.NoError(t, err)
+	signer.now = func() time.Time { return time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC) }
+
+	request, err := http.NewRequest(http.MethodGet, "https://myaccount.blob.core.windows.net/mycontainer?restype=container&comp=list&include=metadata&include=snapshots", nil)
+	require.NoError(t, err)
+	request.Header.Set("x-ms-client-request-id", "  a   b ")
+
+	stringToSign := strings.Join([]string{
+		"GET", "", "", "", "", "", "", "", "", "", "", "",
+		"x-ms-client-request-id:a b",
+		"x-ms-date:Tue, 02 Jan 2024 03:04:05 GMT",
+		"x-ms-version:" + defaultAzureStorageVersion,
+		"/myaccount/mycontainer\ncomp:list\ninclude:metadata,snapshots\nrestype:container",
+	}, "\n")
+	require.NoError(t, signer.SignHTTP(context.Background(), request))
+	got, err := signer.stringToSign(request)
+	require.NoError(t, err)
+	require.Equal(t, stringToSign, got)
+	require.Equal(t, "SharedKey myaccount:"+base64.StdEncoding.EncodeToString(hmacSHA256([]byte("secret"), stringToSign)), request.Header.Get("Authorization"))
+
+	// tables use the short string to sign with the comp parameter only
+	request, err = http.NewRequest(http.MethodGet, "https://myaccount.table.core.windows.net/Tables?comp=acl&timeout=5", nil)
+	require.NoError(t, err)
+	require.NoError(t, signer.SignHTTP(context.Background(), request))
+	got, err = signer.stringToSign(request)
+	require.NoError(t, err)
+	require.Equal(t, "GET\n\n\nTue, 02 Jan 2024 03:04:05 GMT\n/myaccount/Tables?comp=acl", got)
+}
+
+func TestGCPHMACSigner(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	signer, err := NewGCPHMACSigner(&GCPHMACOptions{AccessID: "GOOGTS7C7FUP3AIRVJTE2BCD", Secret: "bGoa+V7g/yqDXvKRqq+JTFn4uQZbPiQJo4pf9RzJ"})
+	require.NoError(t, err)
+	signer.now = func() time.Time { return now }
+
+	request, err := http.NewRequest(http.MethodGet, "https://storage.googleapis.com/bucket/object?b=2&a=1", nil)
+	require.NoError(t, err)
+	ctx := GetCtxWithArgs(GcpDefaultVars)
+	require.NoError(t, signer.SignHTTP(ctx, request))
+	require.Equal(t, "20240102T030405Z", request.Header.Get("x-goog-date"))
+	require.True(t, strings.HasPrefix(request.Header.Get("Authorization"), "GOOG4-HMAC-SHA256 Credential=GOOGTS7C7FUP3AIRVJTE2BCD/20240102/auto/storage/goog4_request, SignedHeaders=host;x-goog-content-sha256;x-goog-date, Signature="))
+
+	// the signature is the same as aws sigv4 apart from the provider specific names
+	signer.scheme = v4Scheme{algorithm: "AWS4-HMAC-SHA256", keyPrefix: "AWS4", terminator: "aws4_request", headerPrefix: "x-amz-"}
+	ctx = GetCtxWithArgs(map[string]interface{}{"region": "us-east-1", "service": "s3"})
+	request, err = http.NewRequest(http.MethodGet, "https://s3.amazonaws.com/bucket/object?b=2&a=1", nil)
+	require.NoError(t, err)
+	request.Header.Set("Content-Type", "text/plain")
+	require.NoError(t, signer.SignHTTP(ctx, request))
+
+	expected, err := http.NewRequest(http.MethodGet, "https://s3.amazonaws.com/bucket/object?b=2&a=1", nil)
+	require.NoError(t, err)
+	expected.Header.Set("Content-Type", "text/plain")
+	expected.Header.Set("X-Amz-Content-Sha256", request.Header.Get("x-amz-content-sha256"))
+	creds := aws.Credentials{AccessKeyID: signer.options.AccessID, SecretAccessKey: signer.options.Secret}
+	require.NoError(t, v4.NewSigner().SignHTTP(context.Background(), creds, expected, request.Header.Get("x-amz-content-sha256"), "s3", "us-east-1", now))
+	require.Equal(t, expected.Header.Get("Authorization"), request.Header.Get("Authorization"))
+}
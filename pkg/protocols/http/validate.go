@@ -11,5 +11,17 @@ func (request *Request) validate() error {
 		return errors.New("'redirects' and 'host-redirects' can't be used together")
 	}
 
+	if request.HTTP3 && (request.Unsafe || request.Pipeline) {
+		return errors.New("'http3' can't be used with 'unsafe' or 'pipeline'")
+	}
+
+	if len(request.H2) > 0 && (len(request.Raw) > 0 || len(request.Path) > 0) {
+		return errors.New("'h2' can't be used with 'raw' or 'path'")
+	}
+
+	if len(request.H2) > 0 && (request.Unsafe || request.Pipeline || request.Race || request.HTTP3 || len(request.Fuzzing) > 0) {
+		return errors.New("'h2' can't be used with 'unsafe', 'pipeline', 'race', 'http3' or 'fuzzing'")
+	}
+
 	return nil
 }
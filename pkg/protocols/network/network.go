@@ -26,6 +26,10 @@ type Request struct {
 	//
 	//   Usually it's set to `{{Hostname}}`. If you want to enable TLS for
 	//   TCP Connection, you can use `tls://{{Hostname}}`.
+	//
+	//   UDP can be used with `udp://{{Hostname}}` and DTLS with `dtls://{{Hostname}}`.
+	//   Over UDP every input is sent as a single datagram and every read returns
+	//   a single datagram truncated to the read size.
 	// examples:
 	//   - value: |
 	//       []string{"{{Hostname}}"}
@@ -121,6 +125,22 @@ var RequestPartDefinitions = map[string]string{
 type addressKV struct {
 	address string
 	tls     bool
+	udp     bool
+}
+
+// network returns the name of the transport of the address
+func (kv addressKV) network() string {
+	if kv.udp {
+		return "UDP"
+	}
+	return "TCP"
+}
+
+// addressSchemes contains the transports of the supported address schemes
+var addressSchemes = map[string]addressKV{
+	"tls://":  {tls: true},
+	"udp://":  {udp: true},
+	"dtls://": {tls: true, udp: true},
 }
 
 // Input is the input to send on the network
@@ -166,17 +186,20 @@ func (request *Request) GetID() string {
 
 // Compile compiles the protocol request for further execution.
 func (request *Request) Compile(options *protocols.ExecutorOptions) error {
-	var shouldUseTLS bool
 	var err error
 
 	request.options = options
 	for _, address := range request.Address {
-		// check if the connection should be encrypted
-		if strings.HasPrefix(address, "tls://") {
-			shouldUseTLS = true
-			address = strings.TrimPrefix(address, "tls://")
+		kv := addressKV{address: address}
+		// check the transport and the encryption of the connection
+		for scheme, transport := range addressSchemes {
+			if strings.HasPrefix(address, scheme) {
+				kv = transport
+				kv.address = strings.TrimPrefix(address, scheme)
+				break
+			}
 		}
-		request.addresses = append(request.addresses, addressKV{address: address, tls: shouldUseTLS})
+		request.addresses = append(request.addresses, kv)
 	}
 	// Pre-compile any input dsl functions before executing the request.
 	for _, input := range request.Inputs {
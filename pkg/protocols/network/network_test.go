@@ -32,4 +32,17 @@ func TestNetworkCompileMake(t *testing.T) {
 	t.Run("check-tls-with-port", func(t *testing.T) {
 		require.True(t, request.addresses[0].tls, "could not get correct port for host")
 	})
+
+	request = &Request{
+		ID:      templateID,
+		Address: []string{"udp://{{Host}}:161", "dtls://{{Host}}:5684", "{{Host}}:80"},
+		Inputs:  []*Input{{Data: "test-data"}},
+	}
+	err = request.Compile(executerOpts)
+	require.Nil(t, err, "could not compile network request")
+	t.Run("check-udp-and-dtls", func(t *testing.T) {
+		require.Equal(t, addressKV{address: "{{Host}}:161", udp: true}, request.addresses[0], "could not get udp address")
+		require.Equal(t, addressKV{address: "{{Host}}:5684", udp: true, tls: true}, request.addresses[1], "could not get dtls address")
+		require.Equal(t, addressKV{address: "{{Host}}:80"}, request.addresses[2], "could not get tcp address")
+	})
 }
@@ -1,6 +1,7 @@
 package network
 
 import (
+	"context"
 	"encoding/hex"
 	"fmt"
 	"net"
@@ -11,6 +12,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/pion/dtls/v2"
 	"github.com/pkg/errors"
 	"go.uber.org/multierr"
 	"golang.org/x/exp/maps"
@@ -50,6 +52,10 @@ func (request *Request) getOpenPorts(target *contextargs.Context) ([]string, err
 		// no need to check if port is open or not
 		return request.ports, nil
 	}
+	if request.usesOnlyUDP() {
+		// udp ports can't be checked with a connection attempt
+		return request.ports, nil
+	}
 	errs := []error{}
 	// if more than 1 port is provided, check if port is open or not
 	openPorts := make([]string, 0)
@@ -178,7 +184,7 @@ func (request *Request) executeOnTarget(input *contextargs.Context, visited maps
 			continue
 		}
 		visited.Set(actualAddress, struct{}{})
-		if err = request.executeAddress(variables, actualAddress, address, input, kv.tls, previous, wrappedCallback); err != nil {
+		if err = request.executeAddress(variables, actualAddress, address, input, kv, previous, wrappedCallback); err != nil {
 			outputEvent := request.responseToDSLMap("", "", "", address, "")
 			callback(&output.InternalWrappedEvent{InternalEvent: outputEvent})
 			gologger.Warning().Msgf("[%v] Could not make network request for (%s) : %s\n", request.options.TemplateID, actualAddress, err)
@@ -191,7 +197,7 @@ func (request *Request) executeOnTarget(input *contextargs.Context, visited maps
 }
 
 // executeAddress executes the request for an address
-func (request *Request) executeAddress(variables map[string]interface{}, actualAddress, address string, input *contextargs.Context, shouldUseTLS bool, previous output.InternalEvent, callback protocols.OutputEventCallback) error {
+func (request *Request) executeAddress(variables map[string]interface{}, actualAddress, address string, input *contextargs.Context, kv addressKV, previous output.InternalEvent, callback protocols.OutputEventCallback) error {
 	variables = generators.MergeMaps(variables, map[string]interface{}{"Hostname": address})
 	payloads := generators.BuildPayloadFromOptions(request.options.Options)
 
@@ -249,7 +255,7 @@ func (request *Request) executeAddress(variables map[string]interface{}, actualA
 					// skip on unresponsive address no need to continue
 					return
 				}
-				if err := request.executeRequestWithPayloads(variables, actualAddress, address, input, shouldUseTLS, vars, previous, callback); err != nil {
+				if err := request.executeRequestWithPayloads(variables, actualAddress, address, input, kv, vars, previous, callback); err != nil {
 					m.Lock()
 					multiErr = multierr.Append(multiErr, err)
 					m.Unlock()
@@ -262,14 +268,14 @@ func (request *Request) executeAddress(variables map[string]interface{}, actualA
 		}
 	} else {
 		value := maps.Clone(payloads)
-		if err := request.executeRequestWithPayloads(variables, actualAddress, address, input, shouldUseTLS, value, previous, callback); err != nil {
+		if err := request.executeRequestWithPayloads(variables, actualAddress, address, input, kv, value, previous, callback); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func (request *Request) executeRequestWithPayloads(variables map[string]interface{}, actualAddress, address string, input *contextargs.Context, shouldUseTLS bool, payloads map[string]interface{}, previous output.InternalEvent, callback protocols.OutputEventCallback) error {
+func (request *Request) executeRequestWithPayloads(variables map[string]interface{}, actualAddress, address string, input *contextargs.Context, kv addressKV, payloads map[string]interface{}, previous output.InternalEvent, callback protocols.OutputEventCallback) error {
 	var (
 		hostname string
 		conn     net.Conn
@@ -286,11 +292,7 @@ func (request *Request) executeRequestWithPayloads(variables map[string]interfac
 		return nil
 	}
 
-	if shouldUseTLS {
-		conn, err = request.dialer.DialTLS(input.Context(), "tcp", actualAddress)
-	} else {
-		conn, err = request.dialer.Dial(input.Context(), "tcp", actualAddress)
-	}
+	conn, err = request.dial(input.Context(), kv, actualAddress)
 	if err != nil {
 		// adds it to unresponsive address list if applicable
 		request.markUnresponsiveAddress(updatedTarget, err)
@@ -393,7 +395,7 @@ func (request *Request) executeRequestWithPayloads(variables map[string]interfac
 	}
 
 	request.options.Output.Request(request.options.TemplatePath, actualAddress, request.Type().String(), err)
-	gologger.Verbose().Msgf("Sent TCP request to %s", actualAddress)
+	gologger.Verbose().Msgf("Sent %s request to %s", kv.network(), actualAddress)
 
 	bufferSize := 1024
 	if request.ReadSize != 0 {
@@ -524,6 +526,54 @@ func ConnReadNWithTimeout(conn net.Conn, n int64, timeout time.Duration) ([]byte
 	return b[:count], nil
 }
 
+// dial connects to the address with the transport of the address
+func (request *Request) dial(ctx context.Context, kv addressKV, address string) (net.Conn, error) {
+	switch {
+	case kv.udp && kv.tls:
+		return request.dialDTLS(ctx, address)
+	case kv.udp:
+		return request.dialer.Dial(ctx, "udp", address)
+	case kv.tls:
+		return request.dialer.DialTLS(ctx, "tcp", address)
+	default:
+		return request.dialer.Dial(ctx, "tcp", address)
+	}
+}
+
+// dialDTLS performs a DTLS handshake over a udp connection
+func (request *Request) dialDTLS(ctx context.Context, address string) (net.Conn, error) {
+	conn, err := request.dialer.Dial(ctx, "udp", address)
+	if err != nil {
+		return nil, err
+	}
+	serverName := request.options.Options.SNI
+	if serverName == "" {
+		serverName, _, _ = net.SplitHostPort(address)
+	}
+	handshakeCtx, cancel := context.WithTimeout(ctx, request.options.Options.GetTimeouts().DialTimeout)
+	defer cancel()
+	dtlsConn, err := dtls.ClientWithContext(handshakeCtx, conn, &dtls.Config{
+		InsecureSkipVerify:   true,
+		ServerName:           serverName,
+		ExtendedMasterSecret: dtls.RequestExtendedMasterSecret,
+	})
+	if err != nil {
+		_ = conn.Close()
+		return nil, errors.Wrap(err, "could not perform dtls handshake")
+	}
+	return dtlsConn, nil
+}
+
+// usesOnlyUDP returns true if all the addresses of the request use udp
+func (request *Request) usesOnlyUDP() bool {
+	for _, kv := range request.addresses {
+		if !kv.udp {
+			return false
+		}
+	}
+	return len(request.addresses) > 0
+}
+
 // markUnresponsiveAddress checks if the error is a unreponsive host error and marks it
 func (request *Request) markUnresponsiveAddress(input *contextargs.Context, err error) {
 	if err == nil {
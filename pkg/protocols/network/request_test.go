@@ -2,13 +2,17 @@ package network
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/hex"
 	"fmt"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"testing"
 
+	"github.com/pion/dtls/v2"
+	"github.com/pion/dtls/v2/pkg/crypto/selfsign"
 	"github.com/stretchr/testify/require"
 
 	"github.com/projectdiscovery/nuclei/v3/pkg/model"
@@ -109,6 +113,99 @@ func TestNetworkExecuteWithResults(t *testing.T) {
 	require.Equal(t, "<h1>Example Domain</h1>", finalEvent.Results[0].ExtractedResults[0], "could not get correct extracted results")
 }
 
+func TestNetworkExecuteUDP(t *testing.T) {
+	options := testutils.DefaultOptions
+
+	testutils.Init(options)
+	templateID := "testing-network-udp"
+
+	// echoes the datagram and sends a second one to check datagram reads
+	serve := func(conn net.PacketConn) {
+		buffer := make([]byte, 1024)
+		for {
+			n, addr, err := conn.ReadFrom(buffer)
+			if err != nil {
+				return
+			}
+			_, _ = conn.WriteTo(append([]byte("echo:"), buffer[:n]...), addr)
+			_, _ = conn.WriteTo([]byte("done"), addr)
+		}
+	}
+	udpConn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.Nil(t, err, "could not listen on udp")
+	defer udpConn.Close()
+	go serve(udpConn)
+
+	executerOpts := testutils.NewMockExecuterOptions(options, &testutils.TemplateInfo{
+		ID:   templateID,
+		Info: model.Info{SeverityHolder: severity.Holder{Severity: severity.Low}, Name: "test"},
+	})
+	newRequest := func(address string) *Request {
+		request := &Request{
+			ID:      templateID,
+			Address: []string{address},
+			Inputs:  []*Input{{Data: "ping", Read: 1024, Name: "first"}},
+			Operators: operators.Operators{
+				Matchers: []*matchers.Matcher{{
+					Type: matchers.MatcherTypeHolder{MatcherType: matchers.DSLMatcher},
+					DSL:  []string{"first == 'echo:ping' && data == 'done'"},
+				}},
+			},
+		}
+		err := request.Compile(executerOpts)
+		require.Nil(t, err, "could not compile network request")
+		return request
+	}
+	execute := func(request *Request, host string) *output.InternalWrappedEvent {
+		var finalEvent *output.InternalWrappedEvent
+		ctxArgs := contextargs.NewWithInput(context.Background(), host)
+		err := request.ExecuteWithResults(ctxArgs, make(output.InternalEvent), make(output.InternalEvent), func(event *output.InternalWrappedEvent) {
+			finalEvent = event
+		})
+		require.Nil(t, err, "could not execute network request")
+		return finalEvent
+	}
+
+	t.Run("udp", func(t *testing.T) {
+		finalEvent := execute(newRequest("udp://{{Hostname}}"), udpConn.LocalAddr().String())
+		require.NotNil(t, finalEvent, "could not get event output from request")
+		require.Equal(t, 1, len(finalEvent.Results), "could not match udp datagrams")
+	})
+
+	t.Run("dtls", func(t *testing.T) {
+		certificate, err := selfsign.GenerateSelfSigned()
+		require.Nil(t, err, "could not generate certificate")
+		listener, err := dtls.Listen("udp", &net.UDPAddr{IP: net.ParseIP("127.0.0.1")}, &dtls.Config{
+			Certificates:         []tls.Certificate{certificate},
+			ExtendedMasterSecret: dtls.RequireExtendedMasterSecret,
+		})
+		require.Nil(t, err, "could not listen on dtls")
+		defer listener.Close()
+		go func() {
+			for {
+				conn, err := listener.Accept()
+				if err != nil {
+					return
+				}
+				go func() {
+					defer conn.Close()
+					buffer := make([]byte, 1024)
+					n, err := conn.Read(buffer)
+					if err != nil {
+						return
+					}
+					_, _ = conn.Write(append([]byte("echo:"), buffer[:n]...))
+					_, _ = conn.Write([]byte("done"))
+				}()
+			}
+		}()
+
+		finalEvent := execute(newRequest("dtls://{{Hostname}}"), listener.Addr().String())
+		require.NotNil(t, finalEvent, "could not get event output from request")
+		require.Equal(t, 1, len(finalEvent.Results), "could not match dtls records")
+	})
+}
+
 var exampleBody = `<!doctype html>
 <html>
 <head>
@@ -1,6 +1,7 @@
 package ssl
 
 import (
+	"crypto/tls"
 	"fmt"
 	"net"
 	"strings"
@@ -93,17 +94,34 @@ type Request struct {
 	//   - "secure"
 	//   - "all"
 	TLSCipherTypes []string `yaml:"tls_cipher_types,omitempty" json:"tls_cipher_types,omitempty" jsonschema:"title=TLS Cipher Types,description=TLS Cipher Types to enumerate,enum=weak,enum=secure,enum=insecure,enum=all"`
+	// description: |
+	//   StartTLS upgrades a plaintext connection using the protocol before the
+	//   tls handshake for services not speaking tls directly on the port.
+	//
+	//   The handshake is always performed with ctls and tls version or cipher
+	//   enumeration are not supported.
+	// values:
+	//   - "smtp"
+	//   - "imap"
+	//   - "pop3"
+	//   - "ftp"
+	//   - "xmpp"
+	//   - "ldap"
+	//   - "postgres"
+	StartTLS string `yaml:"starttls,omitempty" json:"starttls,omitempty" jsonschema:"title=starttls protocol,description=StartTLS upgrades a plaintext connection using the protocol before the tls handshake,enum=smtp,enum=imap,enum=pop3,enum=ftp,enum=xmpp,enum=ldap,enum=postgres"`
 
 	// cache any variables that may be needed for operation.
-	dialer  *fastdialer.Dialer
-	tlsx    *tlsx.Service
-	options *protocols.ExecutorOptions
+	dialer         *fastdialer.Dialer
+	tlsx           *tlsx.Service
+	tlsxOptions    *clients.Options
+	startTLSConfig *tls.Config
+	options        *protocols.ExecutorOptions
 }
 
 // TmplClusterKey generates a unique key for the request
 // to be used in the clustering process.
 func (request *Request) TmplClusterKey() uint64 {
-	inp := fmt.Sprintf("%s-%s-%t-%t-%s", request.Address, request.ScanMode, request.TLSCiphersEnum, request.TLSVersionsEnum, strings.Join(request.TLSCipherTypes, ","))
+	inp := fmt.Sprintf("%s-%s-%t-%t-%s-%s", request.Address, request.ScanMode, request.TLSCiphersEnum, request.TLSVersionsEnum, strings.Join(request.TLSCipherTypes, ","), request.StartTLS)
 	return xxhash.Sum64String(inp)
 }
 
@@ -132,6 +150,11 @@ func (request *Request) Compile(options *protocols.ExecutorOptions) error {
 		// if openssl is not installed instead of failing "auto" scanmode is used
 		request.ScanMode = "auto"
 	}
+	if request.StartTLS != "" {
+		if err := request.compileStartTLS(); err != nil {
+			return err
+		}
+	}
 	if request.TLSCiphersEnum {
 		// cipher enumeration requires tls version enumeration first
 		request.TLSVersionsEnum = true
@@ -168,6 +191,7 @@ func (request *Request) Compile(options *protocols.ExecutorOptions) error {
 		return errorutil.NewWithTag(request.TemplateID, "could not create tlsx service")
 	}
 	request.tlsx = tlsxService
+	request.tlsxOptions = tlsxOptions
 
 	if len(request.Matchers) > 0 || len(request.Extractors) > 0 {
 		compiled := &request.Operators
@@ -244,7 +268,12 @@ func (request *Request) ExecuteWithResults(input *contextargs.Context, dynamicVa
 		hostIp = host
 	}
 
-	response, err := request.tlsx.Connect(host, hostIp, port)
+	var response *clients.Response
+	if request.StartTLS != "" {
+		response, err = request.connectStartTLS(host, hostIp, port)
+	} else {
+		response, err = request.tlsx.Connect(host, hostIp, port)
+	}
 	if err != nil {
 		requestOptions.Output.Request(requestOptions.TemplateID, input.MetaInput.Input, request.Type().String(), err)
 		requestOptions.Progress.IncrementFailedRequestsBy(1)
@@ -1,7 +1,11 @@
 package ssl
 
 import (
+	"bufio"
 	"context"
+	"crypto/tls"
+	"net"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -36,3 +40,60 @@ func TestSSLProtocol(t *testing.T) {
 	require.Nil(t, err, "could not run ssl request")
 	require.NotEmpty(t, gotEvent, "could not get event items")
 }
+
+func TestSSLProtocolStartTLS(t *testing.T) {
+	options := testutils.DefaultOptions
+
+	testutils.Init(options)
+	templateID := "testing-ssl-starttls"
+
+	// reuse the certificate generated for tls test servers
+	ts := httptest.NewTLSServer(nil)
+	certificates := ts.TLS.Certificates
+	ts.Close()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.Nil(t, err, "could not listen on tcp")
+	defer listener.Close()
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		reader := bufio.NewReader(conn)
+		_, _ = conn.Write([]byte("220 ready\r\n"))
+		if _, err := reader.ReadString('\n'); err != nil {
+			return
+		}
+		_, _ = conn.Write([]byte("250-localhost\r\n250 STARTTLS\r\n"))
+		if _, err := reader.ReadString('\n'); err != nil {
+			return
+		}
+		_, _ = conn.Write([]byte("220 go ahead\r\n"))
+		_ = tls.Server(conn, &tls.Config{Certificates: certificates}).Handshake()
+	}()
+
+	request := &Request{
+		Address:  "{{Hostname}}",
+		StartTLS: "SMTP",
+	}
+	executerOpts := testutils.NewMockExecuterOptions(options, &testutils.TemplateInfo{
+		ID:   templateID,
+		Info: model.Info{SeverityHolder: severity.Holder{Severity: severity.Low}, Name: "test"},
+	})
+	err = request.Compile(executerOpts)
+	require.Nil(t, err, "could not compile ssl request")
+
+	var gotEvent output.InternalEvent
+	ctxArgs := contextargs.NewWithInput(context.Background(), listener.Addr().String())
+	err = request.ExecuteWithResults(ctxArgs, nil, nil, func(event *output.InternalWrappedEvent) {
+		gotEvent = event.InternalEvent
+	})
+	require.Nil(t, err, "could not run ssl request")
+	require.Equal(t, "tls13", gotEvent["tls_version"], "could not get tls version")
+	require.Equal(t, "Acme Co", gotEvent["issuer_org"].([]string)[0], "could not get certificate issuer")
+
+	request = &Request{Address: "{{Hostname}}", StartTLS: "smtp", TLSCiphersEnum: true}
+	require.NotNil(t, request.Compile(executerOpts), "could compile starttls with cipher enumeration")
+}
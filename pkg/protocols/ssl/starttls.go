@@ -0,0 +1,136 @@
+package ssl
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/projectdiscovery/nuclei/v3/pkg/protocols/ssl/starttls"
+	"github.com/projectdiscovery/tlsx/pkg/tlsx/clients"
+	errorutil "github.com/projectdiscovery/utils/errors"
+	iputil "github.com/projectdiscovery/utils/ip"
+)
+
+// tlsVersions contains the tls versions supported by ctls for starttls connections
+var tlsVersions = map[string]uint16{
+	"tls10": tls.VersionTLS10,
+	"tls11": tls.VersionTLS11,
+	"tls12": tls.VersionTLS12,
+	"tls13": tls.VersionTLS13,
+}
+
+// compileStartTLS validates the starttls protocol and prepares the tls config used after the upgrade
+func (request *Request) compileStartTLS() error {
+	request.StartTLS = strings.ToLower(request.StartTLS)
+	if !starttls.IsSupported(request.StartTLS) {
+		return errorutil.NewWithTag(request.TemplateID, "template %v contains unsupported starttls protocol %v (supported: %v)", request.TemplateID, request.StartTLS, strings.Join(starttls.Protocols(), ","))
+	}
+	if request.TLSVersionsEnum || request.TLSCiphersEnum {
+		return errorutil.NewWithTag(request.TemplateID, "template %v cannot use tls version or cipher enumeration with starttls", request.TemplateID)
+	}
+
+	// same defaults as tlsx to allow inspecting legacy configurations
+	config := &tls.Config{
+		InsecureSkipVerify: true,
+		MinVersion:         tls.VersionTLS10,
+		MaxVersion:         tls.VersionTLS13,
+	}
+	for _, version := range []struct {
+		value  string
+		target *uint16
+	}{{request.MinVersion, &config.MinVersion}, {request.MaxVersion, &config.MaxVersion}} {
+		if version.value == "" {
+			continue
+		}
+		parsed, ok := tlsVersions[version.value]
+		if !ok {
+			return errorutil.NewWithTag(request.TemplateID, "template %v contains tls version %v not supported with starttls", request.TemplateID, version.value)
+		}
+		*version.target = parsed
+	}
+
+	cipherSuites := make(map[string]uint16)
+	for _, suite := range append(tls.CipherSuites(), tls.InsecureCipherSuites()...) {
+		cipherSuites[suite.Name] = suite.ID
+		if len(request.CipherSuites) == 0 {
+			config.CipherSuites = append(config.CipherSuites, suite.ID)
+		}
+	}
+	for _, name := range request.CipherSuites {
+		id, ok := cipherSuites[name]
+		if !ok {
+			return errorutil.NewWithTag(request.TemplateID, "template %v contains cipher suite %v not supported with starttls", request.TemplateID, name)
+		}
+		config.CipherSuites = append(config.CipherSuites, id)
+	}
+	request.startTLSConfig = config
+	return nil
+}
+
+// connectStartTLS upgrades a plaintext connection using the starttls protocol
+// and returns the details of the tls handshake in the same format as tlsx.
+func (request *Request) connectStartTLS(host, hostIp, port string) (*clients.Response, error) {
+	ctx := context.Background()
+	timeout := time.Duration(request.options.Options.Timeout) * time.Second
+	if timeout != 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	rawConn, err := request.dialer.Dial(ctx, "tcp", net.JoinHostPort(hostIp, port))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not connect to server")
+	}
+	defer rawConn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = rawConn.SetDeadline(deadline)
+	}
+
+	if err := starttls.Upgrade(rawConn, request.StartTLS, host); err != nil {
+		return nil, err
+	}
+
+	config := request.startTLSConfig.Clone()
+	if request.options.Options.SNI != "" {
+		config.ServerName = request.options.Options.SNI
+	} else if !iputil.IsIP(host) {
+		config.ServerName = host
+	}
+	conn := tls.Client(rawConn, config)
+	if err := conn.HandshakeContext(ctx); err != nil {
+		return nil, errors.Wrap(err, "could not do tls handshake")
+	}
+
+	state := conn.ConnectionState()
+	if len(state.PeerCertificates) == 0 {
+		return nil, errors.New("no certificates returned by server")
+	}
+	var tlsVersion string
+	for name, version := range tlsVersions {
+		if version == state.Version {
+			tlsVersion = name
+		}
+	}
+	resolvedIP, _, _ := net.SplitHostPort(rawConn.RemoteAddr().String())
+
+	now := time.Now()
+	response := &clients.Response{
+		Timestamp:           &now,
+		Host:                host,
+		IP:                  resolvedIP,
+		ProbeStatus:         true,
+		Port:                port,
+		Version:             tlsVersion,
+		Cipher:              tls.CipherSuiteName(state.CipherSuite),
+		TLSConnection:       "ctls",
+		CertificateResponse: clients.Convertx509toResponse(request.tlsxOptions, host, state.PeerCertificates[0], false),
+		ServerName:          config.ServerName,
+	}
+	response.Untrusted = clients.IsUntrustedCA(state.PeerCertificates[1:])
+	return response, nil
+}
@@ -0,0 +1,6 @@
+// Package starttls implements the plaintext negotiation of protocols
+// upgrading an existing connection to tls with STARTTLS or similar commands.
+//
+// After a successful upgrade the connection is ready for the tls handshake
+// which is performed by the caller.
+package starttls
@@ -0,0 +1,354 @@
+package starttls
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Protocols supporting the starttls upgrade
+const (
+	SMTP     = "smtp"
+	IMAP     = "imap"
+	POP3     = "pop3"
+	FTP      = "ftp"
+	XMPP     = "xmpp"
+	LDAP     = "ldap"
+	Postgres = "postgres"
+)
+
+// clientName is the name used to identify the client to the server
+const clientName = "nuclei"
+
+// maxResponseSize is the maximum size of a single server response read during negotiation
+const maxResponseSize = 64 * 1024
+
+var upgraders = map[string]func(c *conn, hostname string) error{
+	SMTP:     upgradeSMTP,
+	IMAP:     upgradeIMAP,
+	POP3:     upgradePOP3,
+	FTP:      upgradeFTP,
+	XMPP:     upgradeXMPP,
+	LDAP:     upgradeLDAP,
+	Postgres: upgradePostgres,
+}
+
+// IsSupported returns true if the protocol supports the starttls upgrade
+func IsSupported(protocol string) bool {
+	_, ok := upgraders[protocol]
+	return ok
+}
+
+// Protocols returns the sorted list of supported protocols
+func Protocols() []string {
+	protocols := make([]string, 0, len(upgraders))
+	for protocol := range upgraders {
+		protocols = append(protocols, protocol)
+	}
+	sort.Strings(protocols)
+	return protocols
+}
+
+// Upgrade performs the plaintext negotiation of the protocol on the connection.
+//
+// The hostname is sent to the server for protocols requiring it (e.g. xmpp).
+func Upgrade(netConn net.Conn, protocol, hostname string) error {
+	upgrade, ok := upgraders[protocol]
+	if !ok {
+		return fmt.Errorf("unsupported starttls protocol %v", protocol)
+	}
+	c := &conn{Conn: netConn, reader: bufio.NewReader(netConn)}
+	if err := upgrade(c, hostname); err != nil {
+		return errors.Wrapf(err, "could not perform %v starttls", protocol)
+	}
+	// data sent by the server before the handshake would be lost (or injected
+	// into the tls session by a vulnerable server) so it is treated as an error
+	if c.reader.Buffered() > 0 {
+		return fmt.Errorf("unexpected data after %v starttls response", protocol)
+	}
+	return nil
+}
+
+// conn is a connection with buffered reads for line based protocols
+type conn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+func (c *conn) writeLine(line string) error {
+	_, err := c.Write([]byte(line + "\r\n"))
+	return err
+}
+
+func (c *conn) readLine() (string, error) {
+	var line []byte
+	for {
+		chunk, isPrefix, err := c.reader.ReadLine()
+		if err != nil {
+			return "", err
+		}
+		line = append(line, chunk...)
+		if len(line) > maxResponseSize {
+			return "", errors.New("response line too long")
+		}
+		if !isPrefix {
+			return string(line), nil
+		}
+	}
+}
+
+// readUntil reads from the connection until the data ends with the suffix
+func (c *conn) readUntil(suffix string) (string, error) {
+	var data strings.Builder
+	for data.Len() < maxResponseSize {
+		char, err := c.reader.ReadByte()
+		if err != nil {
+			return data.String(), err
+		}
+		data.WriteByte(char)
+		if strings.HasSuffix(data.String(), suffix) {
+			return data.String(), nil
+		}
+	}
+	return data.String(), errors.New("response too long")
+}
+
+// expectCode reads a (possibly multiline) smtp or ftp reply and checks its code
+func (c *conn) expectCode(expected int) error {
+	line, err := c.readLine()
+	if err != nil {
+		return err
+	}
+	if len(line) < 3 {
+		return fmt.Errorf("invalid reply %q", line)
+	}
+	code, err := strconv.Atoi(line[:3])
+	if err != nil {
+		return fmt.Errorf("invalid reply %q", line)
+	}
+	// multiline replies end with a line starting with the code followed by a space
+	for prefix := line[:3]; (len(line) > 3 && line[3] == '-') || !strings.HasPrefix(line, prefix); {
+		if line, err = c.readLine(); err != nil {
+			return err
+		}
+	}
+	if code != expected {
+		return fmt.Errorf("unexpected reply %q", line)
+	}
+	return nil
+}
+
+// expectPrefix reads a single line and checks its prefix
+func (c *conn) expectPrefix(prefix string) error {
+	line, err := c.readLine()
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(line, prefix) {
+		return fmt.Errorf("unexpected reply %q", line)
+	}
+	return nil
+}
+
+// upgradeSMTP performs the smtp starttls command (RFC 3207)
+func upgradeSMTP(c *conn, _ string) error {
+	if err := c.expectCode(220); err != nil {
+		return err
+	}
+	if err := c.writeLine("EHLO " + clientName); err != nil {
+		return err
+	}
+	if err := c.expectCode(250); err != nil {
+		return err
+	}
+	if err := c.writeLine("STARTTLS"); err != nil {
+		return err
+	}
+	return c.expectCode(220)
+}
+
+// upgradeFTP performs the ftp auth tls command (RFC 4217)
+func upgradeFTP(c *conn, _ string) error {
+	if err := c.expectCode(220); err != nil {
+		return err
+	}
+	if err := c.writeLine("AUTH TLS"); err != nil {
+		return err
+	}
+	return c.expectCode(234)
+}
+
+// upgradePOP3 performs the pop3 stls command (RFC 2595)
+func upgradePOP3(c *conn, _ string) error {
+	if err := c.expectPrefix("+OK"); err != nil {
+		return err
+	}
+	if err := c.writeLine("STLS"); err != nil {
+		return err
+	}
+	return c.expectPrefix("+OK")
+}
+
+// upgradeIMAP performs the imap starttls command (RFC 2595)
+func upgradeIMAP(c *conn, _ string) error {
+	if err := c.expectPrefix("* OK"); err != nil {
+		return err
+	}
+	const tag = "a001"
+	if err := c.writeLine(tag + " STARTTLS"); err != nil {
+		return err
+	}
+	// skip untagged responses sent before the tagged completion
+	for {
+		line, err := c.readLine()
+		if err != nil {
+			return err
+		}
+		if !strings.HasPrefix(line, tag+" ") {
+			continue
+		}
+		if !strings.HasPrefix(line, tag+" OK") {
+			return fmt.Errorf("unexpected reply %q", line)
+		}
+		return nil
+	}
+}
+
+// upgradeXMPP performs the xmpp starttls negotiation (RFC 6120)
+func upgradeXMPP(c *conn, hostname string) error {
+	header := fmt.Sprintf("<?xml version='1.0'?><stream:stream to='%s' xmlns='jabber:client' xmlns:stream='http://etherx.jabber.org/streams' version='1.0'>", hostname)
+	if _, err := c.Write([]byte(header)); err != nil {
+		return err
+	}
+	features, err := c.readUntil("</stream:features>")
+	if err != nil {
+		return err
+	}
+	if !strings.Contains(features, "urn:ietf:params:xml:ns:xmpp-tls") {
+		return errors.New("starttls is not offered in stream features")
+	}
+	if _, err := c.Write([]byte("<starttls xmlns='urn:ietf:params:xml:ns:xmpp-tls'/>")); err != nil {
+		return err
+	}
+	reply, err := c.readUntil("/>")
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(strings.TrimSpace(reply), "<proceed") {
+		return fmt.Errorf("unexpected reply %q", reply)
+	}
+	return nil
+}
+
+// ldapStartTLSOID is the object identifier of the ldap starttls extended operation
+const ldapStartTLSOID = "1.3.6.1.4.1.1466.20037"
+
+// ldapStartTLSRequest is the ber encoded ExtendedRequest message for starttls (RFC 4511)
+var ldapStartTLSRequest = append([]byte{
+	0x30, 0x1d, // LDAPMessage sequence
+	0x02, 0x01, 0x01, // messageID 1
+	0x77, 0x18, // [APPLICATION 23] ExtendedRequest
+	0x80, byte(len(ldapStartTLSOID)), // [0] requestName
+}, ldapStartTLSOID...)
+
+// upgradeLDAP performs the ldap starttls extended operation (RFC 4511)
+func upgradeLDAP(c *conn, _ string) error {
+	if _, err := c.Write(ldapStartTLSRequest); err != nil {
+		return err
+	}
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(c.reader, header); err != nil {
+		return err
+	}
+	if header[0] != 0x30 {
+		return fmt.Errorf("unexpected ldap message tag 0x%x", header[0])
+	}
+	length := int(header[1])
+	if length&0x80 != 0 {
+		lengthBytes := make([]byte, length&0x7f)
+		if len(lengthBytes) == 0 || len(lengthBytes) > 4 {
+			return errors.New("invalid ldap message length")
+		}
+		if _, err := io.ReadFull(c.reader, lengthBytes); err != nil {
+			return err
+		}
+		length = 0
+		for _, value := range lengthBytes {
+			length = length<<8 | int(value)
+		}
+	}
+	if length > maxResponseSize {
+		return errors.New("ldap message too long")
+	}
+	message := make([]byte, length)
+	if _, err := io.ReadFull(c.reader, message); err != nil {
+		return err
+	}
+
+	_, _, operation, err := parseBERElement(message) // messageID
+	if err != nil {
+		return err
+	}
+	tag, response, _, err := parseBERElement(operation)
+	if err != nil {
+		return err
+	}
+	if tag != 0x78 {
+		return fmt.Errorf("unexpected ldap response tag 0x%x", tag)
+	}
+	tag, resultCode, _, err := parseBERElement(response)
+	if err != nil {
+		return err
+	}
+	if tag != 0x0a || len(resultCode) != 1 || resultCode[0] != 0 {
+		return fmt.Errorf("unexpected ldap result code %v", resultCode)
+	}
+	return nil
+}
+
+// parseBERElement parses a single ber element returning its tag, content and the remaining data
+func parseBERElement(data []byte) (byte, []byte, []byte, error) {
+	if len(data) < 2 {
+		return 0, nil, nil, errors.New("invalid ber element")
+	}
+	tag, length, offset := data[0], int(data[1]), 2
+	if length&0x80 != 0 {
+		size := length & 0x7f
+		if size == 0 || size > 4 || len(data) < offset+size {
+			return 0, nil, nil, errors.New("invalid ber element length")
+		}
+		length = 0
+		for _, value := range data[offset : offset+size] {
+			length = length<<8 | int(value)
+		}
+		offset += size
+	}
+	if len(data) < offset+length {
+		return 0, nil, nil, errors.New("truncated ber element")
+	}
+	return tag, data[offset : offset+length], data[offset+length:], nil
+}
+
+// postgresSSLRequest is the SSLRequest startup message (length 8, code 80877103)
+var postgresSSLRequest = []byte{0x00, 0x00, 0x00, 0x08, 0x04, 0xd2, 0x16, 0x2f}
+
+// upgradePostgres sends the postgres SSLRequest message
+func upgradePostgres(c *conn, _ string) error {
+	if _, err := c.Write(postgresSSLRequest); err != nil {
+		return err
+	}
+	reply, err := c.reader.ReadByte()
+	if err != nil {
+		return err
+	}
+	if reply != 'S' {
+		return fmt.Errorf("server does not support ssl (reply %q)", reply)
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package starttls
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// serve runs the server side of the negotiation on a pipe and returns the client side
+func serve(t *testing.T, handler func(reader *bufio.Reader, server net.Conn)) net.Conn {
+	client, server := net.Pipe()
+	go func() {
+		defer server.Close()
+		handler(bufio.NewReader(server), server)
+	}()
+	t.Cleanup(func() { _ = client.Close() })
+	return client
+}
+
+// lineServer replies to each received line with the mapped reply after the greeting
+func lineServer(greeting string, replies map[string]string) func(*bufio.Reader, net.Conn) {
+	return func(reader *bufio.Reader, server net.Conn) {
+		_, _ = server.Write([]byte(greeting))
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			reply, ok := replies[strings.TrimSpace(line)]
+			if !ok {
+				return
+			}
+			_, _ = server.Write([]byte(reply))
+		}
+	}
+}
+
+func TestUpgrade(t *testing.T) {
+	tests := []struct {
+		protocol string
+		server   func(*bufio.Reader, net.Conn)
+	}{
+		{SMTP, lineServer("220-mail.example.com ESMTP\r\n220 ready\r\n", map[string]string{
+			"EHLO nuclei": "250-mail.example.com\r\n250-STARTTLS\r\n250 SIZE 1024\r\n",
+			"STARTTLS":    "220 2.0.0 Ready to start TLS\r\n",
+		})},
+		{FTP, lineServer("220-Welcome\r\n some banner\r\n220 FTP server ready\r\n", map[string]string{
+			"AUTH TLS": "234 AUTH TLS successful\r\n",
+		})},
+		{POP3, lineServer("+OK POP3 ready\r\n", map[string]string{
+			"STLS": "+OK Begin TLS negotiation\r\n",
+		})},
+		{IMAP, lineServer("* OK IMAP4rev1 ready\r\n", map[string]string{
+			"a001 STARTTLS": "* CAPABILITY IMAP4rev1\r\na001 OK Begin TLS negotiation now\r\n",
+		})},
+		{XMPP, func(reader *bufio.Reader, server net.Conn) {
+			if _, err := reader.ReadString('>'); err != nil {
+				return
+			}
+			if _, err := reader.ReadString('>'); err != nil {
+				return
+			}
+			_, _ = server.Write([]byte("<stream:stream from='example.com' version='1.0'><stream:features><starttls xmlns='urn:ietf:params:xml:ns:xmpp-tls'><required/></starttls></stream:features>"))
+			if _, err := reader.ReadString('>'); err != nil {
+				return
+			}
+			_, _ = server.Write([]byte("<proceed xmlns='urn:ietf:params:xml:ns:xmpp-tls'/>"))
+		}},
+		{LDAP, func(reader *bufio.Reader, server net.Conn) {
+			request := make([]byte, len(ldapStartTLSRequest))
+			if _, err := io.ReadFull(reader, request); err != nil {
+				return
+			}
+			// ExtendedResponse with success result code and long form message length
+			_, _ = server.Write([]byte{0x30, 0x81, 0x0c, 0x02, 0x01, 0x01, 0x78, 0x07, 0x0a, 0x01, 0x00, 0x04, 0x00, 0x04, 0x00})
+		}},
+		{Postgres, func(reader *bufio.Reader, server net.Conn) {
+			request := make([]byte, len(postgresSSLRequest))
+			if _, err := io.ReadFull(reader, request); err != nil {
+				return
+			}
+			_, _ = server.Write([]byte("S"))
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.protocol, func(t *testing.T) {
+			require.Nil(t, Upgrade(serve(t, test.server), test.protocol, "example.com"), "could not upgrade connection")
+		})
+	}
+}
+
+func TestUpgradeErrors(t *testing.T) {
+	t.Run("unsupported", func(t *testing.T) {
+		require.NotNil(t, Upgrade(nil, "telnet", "example.com"), "could upgrade unsupported protocol")
+	})
+	t.Run("rejected", func(t *testing.T) {
+		conn := serve(t, lineServer("220 ready\r\n", map[string]string{
+			"EHLO nuclei": "250 mail.example.com\r\n",
+			"STARTTLS":    "454 TLS not available\r\n",
+		}))
+		require.NotNil(t, Upgrade(conn, SMTP, "example.com"), "could upgrade rejected connection")
+	})
+	t.Run("injected-data", func(t *testing.T) {
+		conn := serve(t, lineServer("+OK ready\r\n", map[string]string{
+			"STLS": "+OK Begin TLS negotiation\r\n+OK injected\r\n",
+		}))
+		require.NotNil(t, Upgrade(conn, POP3, "example.com"), "could upgrade with data after response")
+	})
+	t.Run("postgres-refused", func(t *testing.T) {
+		conn := serve(t, func(reader *bufio.Reader, server net.Conn) {
+			request := make([]byte, len(postgresSSLRequest))
+			if _, err := io.ReadFull(reader, request); err != nil {
+				return
+			}
+			_, _ = server.Write([]byte("N"))
+		})
+		require.NotNil(t, Upgrade(conn, Postgres, "example.com"), "could upgrade refused connection")
+	})
+}
@@ -12,5 +12,6 @@ type Client interface {
 	Clear()
 	CreateIssue(event *output.ResultEvent) error
 	CloseIssue(event *output.ResultEvent) error
+	Reconcile(scope *ReconcileScope) (int, error)
 	GetReportingOptions() *Options
 }
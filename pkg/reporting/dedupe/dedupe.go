@@ -6,11 +6,13 @@ package dedupe
 
 import (
 	"crypto/sha1"
+	"encoding/json"
 	"os"
 
 	"github.com/syndtr/goleveldb/leveldb"
 	"github.com/syndtr/goleveldb/leveldb/errors"
 
+	"github.com/projectdiscovery/nuclei/v3/pkg/model/types/severity"
 	"github.com/projectdiscovery/nuclei/v3/pkg/output"
 	"github.com/projectdiscovery/nuclei/v3/pkg/types"
 	"github.com/projectdiscovery/utils/conversion"
@@ -73,6 +75,80 @@ func (s *Storage) Close() {
 // Index indexes an item in storage and returns true if the item
 // was unique.
 func (s *Storage) Index(result *output.ResultEvent) (bool, error) {
+	hash := Key(result)
+
+	exists, err := s.storage.Has(hash, nil)
+	if err != nil {
+		// if we have an error, return with it but mark it as true
+		// since we don't want to lose an issue considering it a dupe.
+		return true, err
+	}
+	if !exists {
+		// the event is stored to reconcile issues of findings that disappear
+		value, _ := json.Marshal(storedEvent(result))
+		return true, s.storage.Put(hash, value, nil)
+	}
+	return false, err
+}
+
+// Iterate iterates over the indexed items of the storage. Items indexed
+// by older versions without event data are skipped.
+func (s *Storage) Iterate(callback func(key []byte, result *output.ResultEvent) bool) error {
+	iter := s.storage.NewIterator(nil, nil)
+	defer iter.Release()
+
+	for iter.Next() {
+		if len(iter.Value()) == 0 {
+			continue
+		}
+		result := &output.ResultEvent{}
+		if err := json.Unmarshal(iter.Value(), result); err != nil {
+			continue
+		}
+		key := make([]byte, len(iter.Key()))
+		copy(key, iter.Key())
+		if !callback(key, result) {
+			break
+		}
+	}
+	return iter.Error()
+}
+
+// Delete removes an indexed item from the storage
+func (s *Storage) Delete(key []byte) error {
+	return s.storage.Delete(key, nil)
+}
+
+// storedEvent returns the fields of an event stored for reconciliation
+// leaving out request/response data.
+func storedEvent(result *output.ResultEvent) *output.ResultEvent {
+	info := result.Info
+	// undefined severities can't be unmarshalled
+	if info.SeverityHolder.Severity == severity.Undefined {
+		info.SeverityHolder.Severity = severity.Unknown
+	}
+	return &output.ResultEvent{
+		TemplateID:       result.TemplateID,
+		TemplatePath:     result.TemplatePath,
+		Info:             info,
+		MatcherName:      result.MatcherName,
+		ExtractorName:    result.ExtractorName,
+		Type:             result.Type,
+		Host:             result.Host,
+		Port:             result.Port,
+		Scheme:           result.Scheme,
+		URL:              result.URL,
+		Matched:          result.Matched,
+		ExtractedResults: result.ExtractedResults,
+		Metadata:         result.Metadata,
+		IP:               result.IP,
+		Timestamp:        result.Timestamp,
+	}
+}
+
+// Key returns the hash identifying the finding of an event. It is used
+// as storage key and by trackers to find the issue of a finding.
+func Key(result *output.ResultEvent) []byte {
 	hasher := sha1.New()
 	if result.TemplateID != "" {
 		_, _ = hasher.Write(conversion.Bytes(result.TemplateID))
@@ -99,16 +175,5 @@ func (s *Storage) Index(result *output.ResultEvent) (bool, error) {
 		_, _ = hasher.Write(conversion.Bytes(k))
 		_, _ = hasher.Write(conversion.Bytes(types.ToString(v)))
 	}
-	hash := hasher.Sum(nil)
-
-	exists, err := s.storage.Has(hash, nil)
-	if err != nil {
-		// if we have an error, return with it but mark it as true
-		// since we don't want to lose an issue considering it a dupe.
-		return true, err
-	}
-	if !exists {
-		return true, s.storage.Put(hash, nil, nil)
-	}
-	return false, err
+	return hasher.Sum(nil)
 }
@@ -29,3 +29,28 @@ func TestDedupeDuplicates(t *testing.T) {
 	require.Nil(t, err, "could not index item")
 	require.False(t, second, "could index duplicate item")
 }
+
+func TestDedupeIterate(t *testing.T) {
+	storage, err := New(t.TempDir())
+	require.Nil(t, err, "could not create duplicate storage")
+	defer storage.Close()
+
+	_, err = storage.Index(&output.ResultEvent{TemplateID: "test", Host: "https://example.com", Response: "HTTP/1.1 200 OK"})
+	require.Nil(t, err, "could not index item")
+
+	var keys [][]byte
+	err = storage.Iterate(func(key []byte, result *output.ResultEvent) bool {
+		require.Equal(t, "test", result.TemplateID)
+		require.Equal(t, "https://example.com", result.Host)
+		require.Empty(t, result.Response, "response was stored")
+		keys = append(keys, key)
+		return true
+	})
+	require.Nil(t, err, "could not iterate items")
+	require.Len(t, keys, 1)
+
+	require.Nil(t, storage.Delete(keys[0]), "could not delete item")
+	unique, err := storage.Index(&output.ResultEvent{TemplateID: "test", Host: "https://example.com"})
+	require.Nil(t, err, "could not index item")
+	require.True(t, unique, "deleted item is a duplicate")
+}
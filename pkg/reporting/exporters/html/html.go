@@ -0,0 +1,324 @@
+package html
+
+import (
+	_ "embed"
+	"fmt"
+	"html/template"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/projectdiscovery/nuclei/v3/pkg/model/types/severity"
+	"github.com/projectdiscovery/nuclei/v3/pkg/output"
+	"github.com/projectdiscovery/nuclei/v3/pkg/reporting/format"
+	"github.com/projectdiscovery/nuclei/v3/pkg/types"
+)
+
+//go:embed report.html
+var reportTemplate string
+
+// maxHostsChart is the number of hosts shown in the findings per host chart
+const maxHostsChart = 10
+
+// severityColors are the colors of severities in the report
+var severityColors = map[severity.Severity]string{
+	severity.Critical: "#8e24aa",
+	severity.High:     "#e53935",
+	severity.Medium:   "#fb8c00",
+	severity.Low:      "#fdd835",
+	severity.Info:     "#1e88e5",
+	severity.Unknown:  "#9e9e9e",
+}
+
+// severityOrder is the order of severities in the report
+var severityOrder = []severity.Severity{
+	severity.Critical,
+	severity.High,
+	severity.Medium,
+	severity.Low,
+	severity.Info,
+	severity.Unknown,
+}
+
+type Exporter struct {
+	options *Options
+	mutex   *sync.Mutex
+	rows    []output.ResultEvent
+}
+
+// Options contains the configuration options for HTML exporter client
+type Options struct {
+	// File is the file to export the HTML report to
+	File    string `yaml:"file"`
+	OmitRaw bool   `yaml:"omit-raw"`
+}
+
+// New creates a new HTML exporter integration client based on options.
+func New(options *Options) (*Exporter, error) {
+	if options.File == "" {
+		return nil, errors.New("no file specified for html export")
+	}
+	exporter := &Exporter{
+		mutex:   &sync.Mutex{},
+		options: options,
+		rows:    []output.ResultEvent{},
+	}
+	return exporter, nil
+}
+
+// Export appends the passed result event to the list of findings of the report
+func (exporter *Exporter) Export(event *output.ResultEvent) error {
+	exporter.mutex.Lock()
+	defer exporter.mutex.Unlock()
+
+	row := *event
+	if exporter.options.OmitRaw {
+		row.Request = ""
+		row.Response = ""
+		row.CURLCommand = ""
+	}
+	exporter.rows = append(exporter.rows, row)
+	return nil
+}
+
+// Close writes the HTML report to the file specified in options
+// and closes the exporter after operation
+func (exporter *Exporter) Close() error {
+	exporter.mutex.Lock()
+	defer exporter.mutex.Unlock()
+
+	tpl, err := template.New("report").Parse(reportTemplate)
+	if err != nil {
+		return errors.Wrap(err, "could not parse HTML report template")
+	}
+	file, err := os.Create(exporter.options.File)
+	if err != nil {
+		return errors.Wrap(err, "failed to create HTML file")
+	}
+	defer file.Close()
+
+	if err := tpl.Execute(file, newReport(exporter.rows)); err != nil {
+		return errors.Wrap(err, "failed to generate HTML report")
+	}
+	return nil
+}
+
+// report is the data of the HTML report template
+type report struct {
+	GeneratedAt time.Time
+	Total       int
+	Severities  []*severityCount
+	// SeverityChart is the conic-gradient of the severity donut chart
+	SeverityChart template.CSS
+	TopHosts      []*hostCount
+	Hosts         []string
+	Tags          []string
+	Templates     []string
+	Findings      []*finding
+}
+
+type severityCount struct {
+	Name    string
+	Color   template.CSS
+	Count   int
+	Percent float64
+}
+
+type hostCount struct {
+	Host    string
+	Count   int
+	Percent float64
+}
+
+// finding is a result event prepared for the report
+type finding struct {
+	Index            int
+	TemplateID       string
+	TemplateName     string
+	Name             string
+	Severity         string
+	Color            template.CSS
+	Type             string
+	Host             string
+	Matched          string
+	Timestamp        time.Time
+	Tags             []string
+	Authors          []string
+	Description      string
+	Impact           string
+	Remediation      string
+	References       []string
+	ExtractedResults []string
+	Metadata         map[string]string
+	CVEID            []string
+	CWEID            []string
+	CVSSScore        string
+	CVSSMetrics      string
+	EPSSScore        string
+	EPSSPercentile   string
+	CPE              string
+	Request          string
+	Response         string
+	CURLCommand      string
+	// FilterTags is the tags value used by the report filters
+	FilterTags string
+}
+
+// newReport creates the report data from the exported results
+func newReport(rows []output.ResultEvent) *report {
+	r := &report{GeneratedAt: time.Now(), Total: len(rows)}
+
+	severities := make(map[severity.Severity]int)
+	hosts := make(map[string]int)
+	tags := make(map[string]struct{})
+	templates := make(map[string]struct{})
+	for i := range rows {
+		f := newFinding(&rows[i])
+		r.Findings = append(r.Findings, f)
+
+		severities[rows[i].Info.SeverityHolder.Severity]++
+		hosts[f.Host]++
+		templates[f.TemplateID] = struct{}{}
+		for _, tag := range f.Tags {
+			tags[tag] = struct{}{}
+		}
+	}
+
+	sort.SliceStable(r.Findings, func(i, j int) bool {
+		left, right := severityRank(r.Findings[i].Severity), severityRank(r.Findings[j].Severity)
+		if left != right {
+			return left < right
+		}
+		if r.Findings[i].Host != r.Findings[j].Host {
+			return r.Findings[i].Host < r.Findings[j].Host
+		}
+		return r.Findings[i].TemplateID < r.Findings[j].TemplateID
+	})
+	for i, f := range r.Findings {
+		f.Index = i
+	}
+
+	var gradient []string
+	var offset float64
+	for _, value := range severityOrder {
+		count := severities[value]
+		if value == severity.Unknown {
+			count += severities[severity.Undefined]
+		}
+		if count == 0 {
+			continue
+		}
+		percent := float64(count) * 100 / float64(r.Total)
+		r.Severities = append(r.Severities, &severityCount{
+			Name:    value.String(),
+			Color:   template.CSS(severityColors[value]),
+			Count:   count,
+			Percent: percent,
+		})
+		gradient = append(gradient, fmt.Sprintf("%s %.2f%% %.2f%%", severityColors[value], offset, offset+percent))
+		offset += percent
+	}
+	if len(gradient) > 0 {
+		r.SeverityChart = template.CSS("conic-gradient(" + strings.Join(gradient, ", ") + ")")
+	}
+
+	for host, count := range hosts {
+		r.Hosts = append(r.Hosts, host)
+		r.TopHosts = append(r.TopHosts, &hostCount{Host: host, Count: count})
+	}
+	sort.Strings(r.Hosts)
+	sort.SliceStable(r.TopHosts, func(i, j int) bool {
+		if r.TopHosts[i].Count != r.TopHosts[j].Count {
+			return r.TopHosts[i].Count > r.TopHosts[j].Count
+		}
+		return r.TopHosts[i].Host < r.TopHosts[j].Host
+	})
+	if len(r.TopHosts) > maxHostsChart {
+		r.TopHosts = r.TopHosts[:maxHostsChart]
+	}
+	if len(r.TopHosts) > 0 {
+		for _, host := range r.TopHosts {
+			host.Percent = float64(host.Count) * 100 / float64(r.TopHosts[0].Count)
+		}
+	}
+
+	r.Tags = sortedKeys(tags)
+	r.Templates = sortedKeys(templates)
+	return r
+}
+
+// newFinding creates a report finding from a result event
+func newFinding(event *output.ResultEvent) *finding {
+	value := event.Info.SeverityHolder.Severity
+	if value == severity.Undefined {
+		value = severity.Unknown
+	}
+	f := &finding{
+		TemplateID:       event.TemplateID,
+		TemplateName:     format.GetMatchedTemplateName(event),
+		Name:             event.Info.Name,
+		Severity:         value.String(),
+		Color:            template.CSS(severityColors[value]),
+		Type:             event.Type,
+		Host:             event.Host,
+		Matched:          event.Matched,
+		Timestamp:        event.Timestamp,
+		Tags:             event.Info.Tags.ToSlice(),
+		Authors:          event.Info.Authors.ToSlice(),
+		Description:      event.Info.Description,
+		Impact:           event.Info.Impact,
+		Remediation:      event.Info.Remediation,
+		ExtractedResults: event.ExtractedResults,
+		Request:          event.Request,
+		Response:         event.Response,
+		CURLCommand:      event.CURLCommand,
+	}
+	if event.Info.Reference != nil {
+		f.References = event.Info.Reference.ToSlice()
+	}
+	if len(event.Metadata) > 0 {
+		f.Metadata = make(map[string]string, len(event.Metadata))
+		for key, value := range event.Metadata {
+			f.Metadata[key] = types.ToString(value)
+		}
+	}
+	if classification := event.Info.Classification; classification != nil {
+		f.CVEID = classification.CVEID.ToSlice()
+		f.CWEID = classification.CWEID.ToSlice()
+		f.CVSSMetrics = classification.CVSSMetrics
+		f.CPE = classification.CPE
+		if classification.CVSSScore > 0 {
+			f.CVSSScore = fmt.Sprintf("%.1f", classification.CVSSScore)
+		}
+		if classification.EPSSScore > 0 {
+			f.EPSSScore = fmt.Sprintf("%.5f", classification.EPSSScore)
+		}
+		if classification.EPSSPercentile > 0 {
+			f.EPSSPercentile = fmt.Sprintf("%.5f", classification.EPSSPercentile)
+		}
+	}
+	f.FilterTags = "|" + strings.Join(f.Tags, "|") + "|"
+	return f
+}
+
+// severityRank returns the position of a severity in the report order
+func severityRank(value string) int {
+	for i, current := range severityOrder {
+		if current.String() == value {
+			return i
+		}
+	}
+	return len(severityOrder)
+}
+
+func sortedKeys(values map[string]struct{}) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
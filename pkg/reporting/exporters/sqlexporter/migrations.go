@@ -0,0 +1,106 @@
+package sqlexporter
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// migration is a versioned schema change. {{id}} in statements is
+// replaced with the auto increment primary key of the dialect.
+type migration struct {
+	version    int
+	statements []string
+}
+
+// migrations are applied in order on databases not having their version
+var migrations = []migration{
+	{
+		version: 1,
+		statements: []string{
+			`CREATE TABLE IF NOT EXISTS scans (
+				id TEXT PRIMARY KEY,
+				started_at TIMESTAMP NOT NULL,
+				finished_at TIMESTAMP
+			)`,
+			`CREATE TABLE IF NOT EXISTS templates (
+				id TEXT PRIMARY KEY,
+				name TEXT NOT NULL,
+				severity TEXT NOT NULL,
+				authors TEXT,
+				tags TEXT,
+				description TEXT,
+				path TEXT,
+				cve_ids TEXT,
+				cwe_ids TEXT,
+				cvss_score DOUBLE PRECISION
+			)`,
+			`CREATE TABLE IF NOT EXISTS hosts (
+				id {{id}},
+				host TEXT NOT NULL UNIQUE
+			)`,
+			`CREATE TABLE IF NOT EXISTS findings (
+				id {{id}},
+				scan_id TEXT NOT NULL REFERENCES scans(id),
+				template_id TEXT NOT NULL REFERENCES templates(id),
+				host_id BIGINT NOT NULL REFERENCES hosts(id),
+				type TEXT NOT NULL,
+				matched_at TEXT,
+				matcher_name TEXT,
+				extractor_name TEXT,
+				ip TEXT,
+				request TEXT,
+				response TEXT,
+				curl_command TEXT,
+				found_at TIMESTAMP NOT NULL
+			)`,
+			`CREATE INDEX IF NOT EXISTS findings_scan_id ON findings (scan_id)`,
+			`CREATE INDEX IF NOT EXISTS findings_template_host ON findings (template_id, host_id)`,
+			`CREATE TABLE IF NOT EXISTS extracted_values (
+				finding_id BIGINT NOT NULL REFERENCES findings(id),
+				position INTEGER NOT NULL,
+				value TEXT NOT NULL,
+				PRIMARY KEY (finding_id, position)
+			)`,
+		},
+	},
+}
+
+// migrate creates the migrations table and applies pending migrations
+func migrate(db *sql.DB, dialect *dialect) error {
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version INTEGER PRIMARY KEY,
+		applied_at TIMESTAMP NOT NULL
+	)`); err != nil {
+		return err
+	}
+	var current int
+	if err := db.QueryRow(`SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&current); err != nil {
+		return err
+	}
+
+	for _, m := range migrations {
+		if m.version <= current {
+			continue
+		}
+		tx, err := db.Begin()
+		if err != nil {
+			return err
+		}
+		for _, statement := range m.statements {
+			if _, err := tx.Exec(strings.ReplaceAll(statement, "{{id}}", dialect.autoIncrement)); err != nil {
+				_ = tx.Rollback()
+				return fmt.Errorf("migration %d: %w", m.version, err)
+			}
+		}
+		if _, err := tx.Exec(dialect.rebind(`INSERT INTO schema_migrations (version, applied_at) VALUES (?, ?)`), m.version, time.Now().UTC()); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,264 @@
+package sqlexporter
+
+import (
+	"database/sql"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	_ "github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/projectdiscovery/gologger"
+	"github.com/projectdiscovery/nuclei/v3/pkg/output"
+	"github.com/rs/xid"
+	// pure go sqlite driver as release builds are built without cgo
+	_ "modernc.org/sqlite"
+)
+
+// defaultBatchSize is the number of results inserted per transaction
+const defaultBatchSize = 100
+
+// Exporter is an exporter writing results to a relational database
+type Exporter struct {
+	options *Options
+	db      *sql.DB
+	dialect *dialect
+
+	mutex *sync.Mutex
+	rows  []*output.ResultEvent
+	// templates and hosts written by the exporter
+	templates map[string]struct{}
+	hosts     map[string]int64
+}
+
+// Options contains the configuration options for SQL exporter client
+type Options struct {
+	// Driver is the database driver (sqlite, postgres). It is detected
+	// from the connection string if not set.
+	Driver string `yaml:"driver"`
+	// ConnectionString is the sqlite database file or the postgres connection url
+	ConnectionString string `yaml:"connection-string"`
+	// ScanID is the id of the scan results are stored with (generated if empty)
+	ScanID string `yaml:"scan-id"`
+	// OmitRaw excludes the Request and Response from the results (helps with filesize)
+	OmitRaw bool `yaml:"omit-raw"`
+	// BatchSize is the number of results inserted per transaction (default 100)
+	BatchSize int `yaml:"batch-size"`
+}
+
+// dialect contains the differences of the supported databases
+type dialect struct {
+	driver        string
+	autoIncrement string
+	numbered      bool
+}
+
+var dialects = map[string]*dialect{
+	"sqlite":   {driver: "sqlite", autoIncrement: "INTEGER PRIMARY KEY AUTOINCREMENT"},
+	"postgres": {driver: "postgres", autoIncrement: "BIGSERIAL PRIMARY KEY", numbered: true},
+}
+
+// rebind replaces ? placeholders with numbered placeholders if required by the dialect
+func (d *dialect) rebind(query string) string {
+	if !d.numbered {
+		return query
+	}
+	var builder strings.Builder
+	var n int
+	for _, r := range query {
+		if r == '?' {
+			n++
+			builder.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		builder.WriteRune(r)
+	}
+	return builder.String()
+}
+
+// New creates a new SQL exporter integration client based on options.
+func New(options *Options) (*Exporter, error) {
+	// If the environment variable for the connection string is set, then use that instead.
+	if envConnectionString := os.Getenv("SQL_CONNECTION_STRING"); envConnectionString != "" {
+		options.ConnectionString = envConnectionString
+		gologger.Info().Msgf("Using connection string from environment variable SQL_CONNECTION_STRING")
+	}
+	if options.ConnectionString == "" {
+		return nil, errors.New("connection string is required for sql exporter")
+	}
+	if options.Driver == "" {
+		options.Driver = "sqlite"
+		if strings.HasPrefix(options.ConnectionString, "postgres://") || strings.HasPrefix(options.ConnectionString, "postgresql://") {
+			options.Driver = "postgres"
+		}
+	}
+	dialect, ok := dialects[options.Driver]
+	if !ok {
+		return nil, errors.Errorf("unsupported sql driver %q", options.Driver)
+	}
+	if options.BatchSize <= 0 {
+		options.BatchSize = defaultBatchSize
+	}
+	if options.ScanID == "" {
+		options.ScanID = xid.New().String()
+	}
+
+	db, err := sql.Open(dialect.driver, options.ConnectionString)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not open database")
+	}
+	if options.Driver == "sqlite" {
+		// sqlite does not support concurrent writers
+		db.SetMaxOpenConns(1)
+	}
+	if err := db.Ping(); err != nil {
+		_ = db.Close()
+		return nil, errors.Wrap(err, "could not connect to database")
+	}
+	if err := migrate(db, dialect); err != nil {
+		_ = db.Close()
+		return nil, errors.Wrap(err, "could not migrate database")
+	}
+
+	_, err = db.Exec(dialect.rebind(`INSERT INTO scans (id, started_at) VALUES (?, ?) ON CONFLICT (id) DO NOTHING`), options.ScanID, time.Now().UTC())
+	if err != nil {
+		_ = db.Close()
+		return nil, errors.Wrap(err, "could not create scan")
+	}
+	gologger.Verbose().Msgf("Exporting results of scan %s to %s database", options.ScanID, options.Driver)
+
+	return &Exporter{
+		options:   options,
+		db:        db,
+		dialect:   dialect,
+		mutex:     &sync.Mutex{},
+		templates: make(map[string]struct{}),
+		hosts:     make(map[string]int64),
+	}, nil
+}
+
+// Export queues a result and writes the queued results once the batch is full
+func (exporter *Exporter) Export(event *output.ResultEvent) error {
+	exporter.mutex.Lock()
+	defer exporter.mutex.Unlock()
+
+	exporter.rows = append(exporter.rows, event)
+	if len(exporter.rows) >= exporter.options.BatchSize {
+		return exporter.flush()
+	}
+	return nil
+}
+
+// flush writes the queued results in a single transaction
+func (exporter *Exporter) flush() error {
+	if len(exporter.rows) == 0 {
+		return nil
+	}
+	tx, err := exporter.db.Begin()
+	if err != nil {
+		return errors.Wrap(err, "could not begin transaction")
+	}
+	// templates and hosts are only cached once the transaction is committed
+	templates := make(map[string]struct{})
+	hosts := make(map[string]int64)
+
+	for _, event := range exporter.rows {
+		if err := exporter.insert(tx, event, templates, hosts); err != nil {
+			_ = tx.Rollback()
+			return errors.Wrapf(err, "could not insert result of %s", event.TemplateID)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return errors.Wrap(err, "could not commit results")
+	}
+	for id := range templates {
+		exporter.templates[id] = struct{}{}
+	}
+	for host, id := range hosts {
+		exporter.hosts[host] = id
+	}
+	exporter.rows = exporter.rows[:0]
+	return nil
+}
+
+// insert writes a result with its template, host and extracted values
+func (exporter *Exporter) insert(tx *sql.Tx, event *output.ResultEvent, templates map[string]struct{}, hosts map[string]int64) error {
+	rebind := exporter.dialect.rebind
+
+	_, cached := exporter.templates[event.TemplateID]
+	if _, ok := templates[event.TemplateID]; !ok && !cached {
+		info := event.Info
+		var cveIDs, cweIDs string
+		var cvssScore float64
+		if info.Classification != nil {
+			cveIDs = strings.Join(info.Classification.CVEID.ToSlice(), ",")
+			cweIDs = strings.Join(info.Classification.CWEID.ToSlice(), ",")
+			cvssScore = info.Classification.CVSSScore
+		}
+		_, err := tx.Exec(rebind(`INSERT INTO templates (id, name, severity, authors, tags, description, path, cve_ids, cwe_ids, cvss_score)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT (id) DO UPDATE SET name = excluded.name, severity = excluded.severity, authors = excluded.authors,
+			tags = excluded.tags, description = excluded.description, path = excluded.path, cve_ids = excluded.cve_ids,
+			cwe_ids = excluded.cwe_ids, cvss_score = excluded.cvss_score`),
+			event.TemplateID, info.Name, info.SeverityHolder.Severity.String(), strings.Join(info.Authors.ToSlice(), ","),
+			strings.Join(info.Tags.ToSlice(), ","), info.Description, event.TemplatePath, cveIDs, cweIDs, cvssScore)
+		if err != nil {
+			return err
+		}
+		templates[event.TemplateID] = struct{}{}
+	}
+
+	hostID, ok := exporter.hosts[event.Host]
+	if !ok {
+		hostID, ok = hosts[event.Host]
+	}
+	if !ok {
+		err := tx.QueryRow(rebind(`INSERT INTO hosts (host) VALUES (?) ON CONFLICT (host) DO UPDATE SET host = excluded.host RETURNING id`), event.Host).Scan(&hostID)
+		if err != nil {
+			return err
+		}
+		hosts[event.Host] = hostID
+	}
+
+	request, response := event.Request, event.Response
+	if exporter.options.OmitRaw {
+		request, response = "", ""
+	}
+	foundAt := event.Timestamp
+	if foundAt.IsZero() {
+		foundAt = time.Now()
+	}
+	var findingID int64
+	err := tx.QueryRow(rebind(`INSERT INTO findings (scan_id, template_id, host_id, type, matched_at, matcher_name, extractor_name, ip, request, response, curl_command, found_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING id`),
+		exporter.options.ScanID, event.TemplateID, hostID, event.Type, event.Matched, event.MatcherName, event.ExtractorName, event.IP,
+		request, response, event.CURLCommand, foundAt.UTC()).Scan(&findingID)
+	if err != nil {
+		return err
+	}
+
+	for position, value := range event.ExtractedResults {
+		if _, err := tx.Exec(rebind(`INSERT INTO extracted_values (finding_id, position, value) VALUES (?, ?, ?)`), findingID, position, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close writes the queued results, marks the scan as finished and closes the database
+func (exporter *Exporter) Close() error {
+	exporter.mutex.Lock()
+	defer exporter.mutex.Unlock()
+
+	defer exporter.db.Close()
+	if err := exporter.flush(); err != nil {
+		return err
+	}
+	_, err := exporter.db.Exec(exporter.dialect.rebind(`UPDATE scans SET finished_at = ? WHERE id = ?`), time.Now().UTC(), exporter.options.ScanID)
+	if err != nil {
+		return errors.Wrap(err, "could not finish scan")
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package sqlexporter
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/projectdiscovery/nuclei/v3/pkg/model"
+	"github.com/projectdiscovery/nuclei/v3/pkg/model/types/severity"
+	"github.com/projectdiscovery/nuclei/v3/pkg/output"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExporterSQLite(t *testing.T) {
+	database := filepath.Join(t.TempDir(), "results.db")
+
+	events := []*output.ResultEvent{
+		{TemplateID: "tech-detect", Type: "http", Host: "https://a.example.com", Matched: "https://a.example.com", MatcherName: "nginx",
+			Info: model.Info{Name: "Tech Detect", SeverityHolder: severity.Holder{Severity: severity.Info}}},
+		{TemplateID: "tech-detect", Type: "http", Host: "https://b.example.com", Matched: "https://b.example.com", MatcherName: "apache",
+			Info: model.Info{Name: "Tech Detect", SeverityHolder: severity.Holder{Severity: severity.Info}}},
+		{TemplateID: "exposed-env", Type: "http", Host: "https://a.example.com", Matched: "https://a.example.com/.env",
+			ExtractedResults: []string{"DB_PASSWORD", "API_KEY"}, Request: "GET /.env", Response: "HTTP/1.1 200 OK",
+			Info: model.Info{Name: "Exposed Env", SeverityHolder: severity.Holder{Severity: severity.High}}},
+	}
+
+	// two scans with a batch size smaller than the number of results
+	for _, scanID := range []string{"scan-1", "scan-2"} {
+		exporter, err := New(&Options{ConnectionString: database, ScanID: scanID, BatchSize: 2, OmitRaw: scanID == "scan-2"})
+		require.NoError(t, err)
+		for _, event := range events {
+			require.NoError(t, exporter.Export(event))
+		}
+		require.NoError(t, exporter.Close())
+	}
+
+	db, err := sql.Open("sqlite", database)
+	require.NoError(t, err)
+	defer db.Close()
+
+	count := func(query string, args ...interface{}) int {
+		var value int
+		require.NoError(t, db.QueryRow(query, args...).Scan(&value))
+		return value
+	}
+	require.Equal(t, 1, count(`SELECT COUNT(*) FROM schema_migrations`))
+	require.Equal(t, 2, count(`SELECT COUNT(*) FROM scans WHERE finished_at IS NOT NULL`))
+	require.Equal(t, 2, count(`SELECT COUNT(*) FROM templates`))
+	require.Equal(t, 2, count(`SELECT COUNT(*) FROM hosts`))
+	require.Equal(t, 3, count(`SELECT COUNT(*) FROM findings WHERE scan_id = ?`, "scan-2"))
+	require.Equal(t, 4, count(`SELECT COUNT(*) FROM extracted_values`))
+	require.Equal(t, 2, count(`SELECT COUNT(*) FROM findings f JOIN templates t ON t.id = f.template_id JOIN hosts h ON h.id = f.host_id
+		WHERE h.host = ? AND t.severity = ?`, "https://a.example.com", "high"))
+	require.Equal(t, 1, count(`SELECT COUNT(*) FROM findings WHERE request <> ''`))
+}
+
+func TestRebind(t *testing.T) {
+	require.Equal(t, "SELECT ? FROM t WHERE a = ?", dialects["sqlite"].rebind("SELECT ? FROM t WHERE a = ?"))
+	require.Equal(t, "SELECT $1 FROM t WHERE a = $2", dialects["postgres"].rebind("SELECT ? FROM t WHERE a = ?"))
+}
@@ -0,0 +1,84 @@
+package stream
+
+import (
+	"context"
+	"crypto/tls"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/sasl/plain"
+)
+
+// kafkaProducer publishes messages to kafka topics. records are assigned
+// to partitions by the murmur2 hash of their key like the java client.
+type kafkaProducer struct {
+	options *Options
+	client  *kgo.Client
+}
+
+func newKafkaProducer(options *Options) (*kafkaProducer, error) {
+	opts := []kgo.Opt{
+		kgo.SeedBrokers(options.Brokers...),
+		kgo.ClientID(clientID),
+		kgo.DialTimeout(options.Timeout),
+		kgo.ProduceRequestTimeout(options.Timeout),
+		// failed publishes are retried by the exporter
+		kgo.RecordDeliveryTimeout(options.Timeout),
+		kgo.MaxBufferedRecords(options.BatchSize),
+	}
+	switch options.Acks {
+	case "", "all":
+		opts = append(opts, kgo.RequiredAcks(kgo.AllISRAcks()))
+	case "leader":
+		opts = append(opts, kgo.RequiredAcks(kgo.LeaderAck()), kgo.DisableIdempotentWrite())
+	case "none":
+		opts = append(opts, kgo.RequiredAcks(kgo.NoAck()), kgo.DisableIdempotentWrite())
+	default:
+		return nil, errors.Errorf("invalid kafka acks %q (all, leader, none)", options.Acks)
+	}
+	if options.Compression == CompressionGzip {
+		opts = append(opts, kgo.ProducerBatchCompression(kgo.GzipCompression()))
+	} else {
+		opts = append(opts, kgo.ProducerBatchCompression(kgo.NoCompression()))
+	}
+	if options.TLS {
+		opts = append(opts, kgo.DialTLSConfig(&tls.Config{InsecureSkipVerify: options.InsecureSkipVerify}))
+	}
+	if options.Username != "" {
+		opts = append(opts, kgo.SASL(plain.Auth{User: options.Username, Pass: options.Password}.AsMechanism()))
+	}
+
+	client, err := kgo.NewClient(opts...)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create kafka client")
+	}
+	return &kafkaProducer{options: options, client: client}, nil
+}
+
+// Publish writes messages to the partitions of their keys and waits for the configured acks
+func (p *kafkaProducer) Publish(topic string, messages []*message) error {
+	records := make([]*kgo.Record, 0, len(messages))
+	for _, msg := range messages {
+		record := &kgo.Record{
+			Topic:     topic,
+			Key:       msg.key,
+			Value:     msg.value,
+			Timestamp: time.UnixMilli(msg.timestamp),
+		}
+		for _, header := range msg.headers {
+			record.Headers = append(record.Headers, kgo.RecordHeader{Key: header[0], Value: []byte(header[1])})
+		}
+		records = append(records, record)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), p.options.Timeout)
+	defer cancel()
+	return p.client.ProduceSync(ctx, records...).FirstErr()
+}
+
+// Close closes the connections of the brokers
+func (p *kafkaProducer) Close() error {
+	p.client.Close()
+	return nil
+}
@@ -0,0 +1,124 @@
+package stream
+
+import (
+	"crypto/tls"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/pkg/errors"
+)
+
+// natsPublisher publishes messages with headers to nats subjects.
+// with jetstream enabled the publish acknowledgements of the stream are awaited.
+type natsPublisher struct {
+	options *Options
+
+	conn      *nats.Conn
+	jetstream nats.JetStreamContext
+}
+
+func newNatsPublisher(options *Options) *natsPublisher {
+	return &natsPublisher{options: options}
+}
+
+// Publish publishes messages to a subject and waits until the server
+// processed them (or the jetstream stream stored them)
+func (p *natsPublisher) Publish(subject string, messages []*message) error {
+	if p.conn == nil {
+		if err := p.connect(); err != nil {
+			return err
+		}
+	}
+	if p.jetstream != nil {
+		return p.publishJetStream(subject, messages)
+	}
+	for _, msg := range messages {
+		if err := p.conn.PublishMsg(p.newMsg(subject, msg)); err != nil {
+			return err
+		}
+	}
+	// the flush returns once the server processed all messages
+	return p.conn.FlushTimeout(p.options.Timeout)
+}
+
+func (p *natsPublisher) publishJetStream(subject string, messages []*message) error {
+	futures := make([]nats.PubAckFuture, 0, len(messages))
+	for _, msg := range messages {
+		future, err := p.jetstream.PublishMsgAsync(p.newMsg(subject, msg))
+		if err != nil {
+			return err
+		}
+		futures = append(futures, future)
+	}
+	select {
+	case <-p.jetstream.PublishAsyncComplete():
+	case <-time.After(p.options.Timeout):
+		return errors.Errorf("timeout waiting for jetstream acknowledgements of %s", subject)
+	}
+	for _, future := range futures {
+		select {
+		case err := <-future.Err():
+			return errors.Wrap(err, "jetstream error")
+		default:
+		}
+	}
+	return nil
+}
+
+func (p *natsPublisher) newMsg(subject string, msg *message) *nats.Msg {
+	natsMsg := nats.NewMsg(subject)
+	natsMsg.Data = msg.value
+	for _, header := range msg.headers {
+		natsMsg.Header.Set(header[0], header[1])
+	}
+	natsMsg.Header.Set("Nuclei-Key", string(msg.key))
+	return natsMsg
+}
+
+// connect connects to the first reachable server
+func (p *natsPublisher) connect() error {
+	opts := []nats.Option{
+		nats.Name(clientID),
+		nats.Timeout(p.options.Timeout),
+	}
+	if p.options.Username != "" {
+		opts = append(opts, nats.UserInfo(p.options.Username, p.options.Password))
+	}
+	if p.options.Token != "" {
+		opts = append(opts, nats.Token(p.options.Token))
+	}
+	tlsConfig := &tls.Config{InsecureSkipVerify: p.options.InsecureSkipVerify}
+	if p.options.TLS {
+		opts = append(opts, nats.Secure(tlsConfig))
+	} else {
+		// used if the url scheme or the server requires tls
+		opts = append(opts, func(o *nats.Options) error {
+			o.TLSConfig = tlsConfig
+			return nil
+		})
+	}
+
+	conn, err := nats.Connect(strings.Join(p.options.Brokers, ","), opts...)
+	if err != nil {
+		return errors.Wrap(err, "could not connect to nats")
+	}
+	if p.options.JetStream {
+		jetstream, err := conn.JetStream()
+		if err != nil {
+			conn.Close()
+			return errors.Wrap(err, "could not create jetstream context")
+		}
+		p.jetstream = jetstream
+	}
+	p.conn = conn
+	return nil
+}
+
+// Close closes the connection to the server
+func (p *natsPublisher) Close() error {
+	if p.conn != nil {
+		p.conn.Close()
+	}
+	return nil
+}
@@ -0,0 +1,325 @@
+package stream
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/projectdiscovery/gologger"
+	"github.com/projectdiscovery/nuclei/v3/pkg/output"
+	"github.com/projectdiscovery/nuclei/v3/pkg/scan/events"
+)
+
+// Supported streaming platforms
+const (
+	TypeKafka = "kafka"
+	TypeNATS  = "nats"
+)
+
+// Supported compressions of messages
+const (
+	CompressionNone = "none"
+	CompressionGzip = "gzip"
+)
+
+const (
+	clientID = "nuclei"
+
+	defaultQueueSize  = 10000
+	defaultBatchSize  = 100
+	defaultMaxRetries = 5
+	defaultTimeout    = 10 * time.Second
+)
+
+// Exporter is an exporter publishing results and scan lifecycle
+// events to a kafka topic or a nats subject
+type Exporter struct {
+	options   *Options
+	publisher publisher
+
+	// queue is the bounded queue of messages. Export blocks while the
+	// queue is full so results are not dropped if the broker is slow,
+	// while scan events are dropped to not stall the execution.
+	queue    chan *message
+	closedMu sync.RWMutex
+	closed   bool
+	done     chan struct{}
+
+	// failed is the number of messages which could not be delivered
+	failed int
+	// dropped is the number of scan events dropped while the queue was full
+	dropped atomic.Int64
+}
+
+// Options contains the configuration options for the streaming exporter
+type Options struct {
+	// Type is the streaming platform (kafka, nats)
+	Type string `yaml:"type"`
+	// Brokers are the addresses (host:port) of kafka brokers or nats servers
+	Brokers []string `yaml:"brokers"`
+	// Topic is the kafka topic or nats subject results are published to
+	Topic string `yaml:"topic"`
+	// EventsTopic is the topic scan lifecycle events are published to (disabled if empty)
+	EventsTopic string `yaml:"events-topic"`
+	// Compression is the compression of messages (none, gzip)
+	Compression string `yaml:"compression"`
+	// QueueSize is the maximum number of messages queued in memory
+	QueueSize int `yaml:"queue-size"`
+	// BatchSize is the maximum number of messages published at once
+	BatchSize int `yaml:"batch-size"`
+	// MaxRetries is the number of retries of failed publishes
+	MaxRetries int `yaml:"max-retries"`
+	// Timeout is the timeout of connections and publishes
+	Timeout time.Duration `yaml:"timeout"`
+	// Acks is the acknowledgement required from kafka brokers (all, leader, none)
+	Acks string `yaml:"acks"`
+	// JetStream waits for the acknowledgements of nats jetstream streams
+	JetStream bool `yaml:"jetstream"`
+	// TLS enables tls connections to brokers
+	TLS bool `yaml:"tls"`
+	// InsecureSkipVerify disables the verification of broker certificates
+	InsecureSkipVerify bool `yaml:"insecure-skip-verify"`
+	// Username is the kafka SASL/PLAIN or nats username
+	Username string `yaml:"username"`
+	// Password is the kafka SASL/PLAIN or nats password
+	Password string `yaml:"password"`
+	// Token is the nats authentication token
+	Token string `yaml:"token"`
+	// OmitRaw excludes the Request and Response from the results (helps with filesize)
+	OmitRaw bool `yaml:"omit-raw"`
+}
+
+// message is a keyed message of a topic
+type message struct {
+	topic     string
+	key       []byte
+	value     []byte
+	headers   [][2]string
+	timestamp int64
+}
+
+// publisher publishes messages to a streaming platform
+type publisher interface {
+	// Publish publishes messages and returns once they are acknowledged
+	Publish(topic string, messages []*message) error
+	// Close closes the connections of the publisher
+	Close() error
+}
+
+// New creates a new streaming exporter integration client based on options.
+func New(options *Options) (*Exporter, error) {
+	if len(options.Brokers) == 0 || options.Topic == "" {
+		return nil, errors.New("brokers and topic are required for stream exporter")
+	}
+	if options.Compression == "" {
+		options.Compression = CompressionNone
+	}
+	if options.Compression != CompressionNone && options.Compression != CompressionGzip {
+		return nil, errors.Errorf("invalid compression %q (none, gzip)", options.Compression)
+	}
+	if options.QueueSize <= 0 {
+		options.QueueSize = defaultQueueSize
+	}
+	if options.BatchSize <= 0 {
+		options.BatchSize = defaultBatchSize
+	}
+	if options.MaxRetries <= 0 {
+		options.MaxRetries = defaultMaxRetries
+	}
+	if options.Timeout <= 0 {
+		options.Timeout = defaultTimeout
+	}
+
+	var publisher publisher
+	switch options.Type {
+	case TypeKafka:
+		producer, err := newKafkaProducer(options)
+		if err != nil {
+			return nil, err
+		}
+		publisher = producer
+	case TypeNATS:
+		publisher = newNatsPublisher(options)
+	default:
+		return nil, errors.Errorf("invalid stream type %q (kafka, nats)", options.Type)
+	}
+
+	exporter := &Exporter{
+		options:   options,
+		publisher: publisher,
+		queue:     make(chan *message, options.QueueSize),
+		done:      make(chan struct{}),
+	}
+	go exporter.run()
+
+	if options.EventsTopic != "" {
+		events.AddListener(exporter)
+	}
+	return exporter, nil
+}
+
+// Export queues a result to be published to the topic
+func (exporter *Exporter) Export(event *output.ResultEvent) error {
+	if exporter.options.OmitRaw {
+		copied := *event
+		copied.Request = ""
+		copied.Response = ""
+		event = &copied
+	}
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	msg, err := exporter.newMessage(exporter.options.Topic, event.TemplateID+"@"+event.Host, data)
+	if err != nil {
+		return err
+	}
+	exporter.enqueue(msg, true)
+	return nil
+}
+
+// AddScanEvent queues a scan lifecycle event to be published to the events topic.
+// Scan events are sent for every template and target pair, so they are dropped
+// instead of stalling the execution while the queue is full.
+func (exporter *Exporter) AddScanEvent(event events.ScanEvent) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	msg, err := exporter.newMessage(exporter.options.EventsTopic, event.TemplateID+"@"+event.Target, data)
+	if err != nil {
+		return
+	}
+	// run events are sent once per scan and are not dropped
+	wait := event.EventType == events.RunStarted || event.EventType == events.RunFinished
+	if !exporter.enqueue(msg, wait) {
+		exporter.dropped.Add(1)
+	}
+}
+
+func (exporter *Exporter) newMessage(topic, key string, value []byte) (*message, error) {
+	msg := &message{
+		topic:     topic,
+		key:       []byte(key),
+		value:     value,
+		timestamp: time.Now().UnixMilli(),
+		headers:   [][2]string{{"Content-Type", "application/json"}},
+	}
+	// kafka record batches are compressed as a whole
+	if exporter.options.Type == TypeNATS && exporter.options.Compression == CompressionGzip {
+		var compressed bytes.Buffer
+		writer := gzip.NewWriter(&compressed)
+		if _, err := writer.Write(value); err != nil {
+			return nil, err
+		}
+		if err := writer.Close(); err != nil {
+			return nil, err
+		}
+		msg.value = compressed.Bytes()
+		msg.headers = append(msg.headers, [2]string{"Content-Encoding", "gzip"})
+	}
+	return msg, nil
+}
+
+// enqueue queues a message waiting while the queue is full if wait is true.
+// It returns false if the message was not queued.
+func (exporter *Exporter) enqueue(msg *message, wait bool) bool {
+	exporter.closedMu.RLock()
+	defer exporter.closedMu.RUnlock()
+
+	if exporter.closed {
+		return false
+	}
+	if wait {
+		exporter.queue <- msg
+		return true
+	}
+	select {
+	case exporter.queue <- msg:
+		return true
+	default:
+		return false
+	}
+}
+
+// run publishes queued messages in batches until the queue is closed
+func (exporter *Exporter) run() {
+	defer close(exporter.done)
+
+	for msg := range exporter.queue {
+		batch := []*message{msg}
+	collect:
+		for len(batch) < exporter.options.BatchSize {
+			select {
+			case next, ok := <-exporter.queue:
+				if !ok {
+					break collect
+				}
+				batch = append(batch, next)
+			default:
+				break collect
+			}
+		}
+
+		// messages of a batch are published per topic keeping their order
+		byTopic := make(map[string][]*message)
+		var topics []string
+		for _, msg := range batch {
+			if _, ok := byTopic[msg.topic]; !ok {
+				topics = append(topics, msg.topic)
+			}
+			byTopic[msg.topic] = append(byTopic[msg.topic], msg)
+		}
+		for _, topic := range topics {
+			exporter.publish(topic, byTopic[topic])
+		}
+	}
+}
+
+// publish publishes messages retrying failures with an exponential backoff
+func (exporter *Exporter) publish(topic string, messages []*message) {
+	var err error
+	backoff := 250 * time.Millisecond
+	for attempt := 0; attempt <= exporter.options.MaxRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+		if err = exporter.publisher.Publish(topic, messages); err == nil {
+			return
+		}
+		gologger.Verbose().Msgf("Could not publish %d messages to %s (attempt %d): %s", len(messages), topic, attempt+1, err)
+	}
+	exporter.failed += len(messages)
+	gologger.Error().Msgf("Could not publish %d messages to %s %s: %s", len(messages), exporter.options.Type, topic, err)
+}
+
+// Close publishes the queued messages and closes the connections
+func (exporter *Exporter) Close() error {
+	if exporter.options.EventsTopic != "" {
+		events.RemoveListener(exporter)
+	}
+
+	exporter.closedMu.Lock()
+	if exporter.closed {
+		exporter.closedMu.Unlock()
+		return nil
+	}
+	exporter.closed = true
+	close(exporter.queue)
+	exporter.closedMu.Unlock()
+
+	<-exporter.done
+	_ = exporter.publisher.Close()
+	if dropped := exporter.dropped.Load(); dropped > 0 {
+		gologger.Warning().Msgf("Dropped %d scan events while the %s queue was full", dropped, exporter.options.Type)
+	}
+	if exporter.failed > 0 {
+		return errors.Errorf("could not publish %d messages to %s", exporter.failed, exporter.options.Type)
+	}
+	return nil
+}
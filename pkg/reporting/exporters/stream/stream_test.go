@@ -0,0 +1,178 @@
+package stream
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/projectdiscovery/nuclei/v3/pkg/model"
+	"github.com/projectdiscovery/nuclei/v3/pkg/model/types/severity"
+	"github.com/projectdiscovery/nuclei/v3/pkg/output"
+	"github.com/projectdiscovery/nuclei/v3/pkg/scan/events"
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kfake"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+func testEvent(host string) *output.ResultEvent {
+	return &output.ResultEvent{
+		TemplateID: "exposed-panel",
+		Host:       host,
+		Matched:    host + "/admin",
+		Request:    "GET /admin HTTP/1.1",
+		Info:       model.Info{Name: "Exposed Panel", SeverityHolder: severity.Holder{Severity: severity.Medium}},
+	}
+}
+
+func TestExporterKafka(t *testing.T) {
+	cluster, err := kfake.NewCluster(kfake.NumBrokers(1), kfake.SeedTopics(3, "nuclei-results", "nuclei-events"))
+	require.NoError(t, err)
+	defer cluster.Close()
+
+	exporter, err := New(&Options{
+		Type:        TypeKafka,
+		Brokers:     cluster.ListenAddrs(),
+		Topic:       "nuclei-results",
+		EventsTopic: "nuclei-events",
+		Compression: CompressionGzip,
+		OmitRaw:     true,
+	})
+	require.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		require.NoError(t, exporter.Export(testEvent("https://host"+strconv.Itoa(i)+".example.com")))
+	}
+	events.AddRunEvent(events.ScanEvent{EventType: events.RunFinished, Time: time.Now()})
+	require.NoError(t, exporter.Close())
+
+	consumer, err := kgo.NewClient(
+		kgo.SeedBrokers(cluster.ListenAddrs()...),
+		kgo.ConsumeTopics("nuclei-results", "nuclei-events"),
+		kgo.ConsumeResetOffset(kgo.NewOffset().AtStart()),
+	)
+	require.NoError(t, err)
+	defer consumer.Close()
+
+	records := make(map[string][]*kgo.Record)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	for len(records["nuclei-results"]) < 10 || len(records["nuclei-events"]) < 1 {
+		fetches := consumer.PollFetches(ctx)
+		require.NoError(t, ctx.Err())
+		fetches.EachRecord(func(record *kgo.Record) {
+			records[record.Topic] = append(records[record.Topic], record)
+		})
+	}
+	require.Len(t, records["nuclei-results"], 10)
+	require.Len(t, records["nuclei-events"], 1)
+	for _, record := range records["nuclei-results"] {
+		require.True(t, strings.HasPrefix(string(record.Key), "exposed-panel@https://host"))
+		require.NotContains(t, string(record.Value), "GET /admin")
+	}
+	require.Contains(t, string(records["nuclei-events"][0].Value), `"event_type":"run_end"`)
+}
+
+// fakeNats is a nats server stand-in acknowledging jetstream publishes
+type fakeNats struct {
+	mu       sync.Mutex
+	messages []string
+	headers  []string
+}
+
+func (f *fakeNats) handle(conn net.Conn) {
+	defer conn.Close()
+	_, _ = conn.Write([]byte("INFO {\"server_id\":\"fake\",\"proto\":1,\"headers\":true,\"max_payload\":1048576}\r\n"))
+	reader := bufio.NewReader(conn)
+	// sid of the subscription of jetstream acknowledgements
+	sid := "1"
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		fields := strings.Fields(line)
+		switch {
+		case len(fields) > 0 && fields[0] == "PING":
+			_, _ = conn.Write([]byte("PONG\r\n"))
+		case len(fields) > 2 && fields[0] == "SUB":
+			sid = fields[len(fields)-1]
+		case len(fields) > 0 && fields[0] == "HPUB":
+			headerSize, _ := strconv.Atoi(fields[len(fields)-2])
+			totalSize, _ := strconv.Atoi(fields[len(fields)-1])
+			payload := make([]byte, totalSize+2)
+			if _, err := io.ReadFull(reader, payload); err != nil {
+				return
+			}
+			f.mu.Lock()
+			f.headers = append(f.headers, string(payload[:headerSize]))
+			f.messages = append(f.messages, string(payload[headerSize:totalSize]))
+			f.mu.Unlock()
+			if len(fields) == 5 {
+				ack := `{"stream":"NUCLEI","seq":1}`
+				_, _ = conn.Write([]byte("MSG " + fields[2] + " " + sid + " " + strconv.Itoa(len(ack)) + "\r\n" + ack + "\r\n"))
+			}
+		}
+	}
+}
+
+func TestExporterNATS(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	server := &fakeNats{}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go server.handle(conn)
+		}
+	}()
+
+	exporter, err := New(&Options{
+		Type:      TypeNATS,
+		Brokers:   []string{"nats://" + listener.Addr().String()},
+		Topic:     "nuclei.results",
+		JetStream: true,
+	})
+	require.NoError(t, err)
+	for i := 0; i < 5; i++ {
+		require.NoError(t, exporter.Export(testEvent("https://host"+strconv.Itoa(i)+".example.com")))
+	}
+	require.NoError(t, exporter.Close())
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	require.Len(t, server.messages, 5)
+	require.Contains(t, server.messages[0], `"template-id":"exposed-panel"`)
+	require.Contains(t, server.headers[0], "Nuclei-Key: exposed-panel@https://host0.example.com")
+}
+
+func TestExporterScanEventsFullQueue(t *testing.T) {
+	// the queue is not consumed so it stays full after the first event
+	exporter := &Exporter{
+		options: &Options{Type: TypeNATS, EventsTopic: "nuclei.events"},
+		queue:   make(chan *message, 1),
+		done:    make(chan struct{}),
+	}
+	added := make(chan struct{})
+	go func() {
+		for i := 0; i < 3; i++ {
+			exporter.AddScanEvent(events.ScanEvent{TemplateID: "exposed-panel", Target: "https://example.com", EventType: events.ScanStarted})
+		}
+		close(added)
+	}()
+	select {
+	case <-added:
+	case <-time.After(5 * time.Second):
+		t.Fatal("scan events blocked on a full queue")
+	}
+	require.Len(t, exporter.queue, 1)
+	require.Equal(t, int64(2), exporter.dropped.Load())
+}
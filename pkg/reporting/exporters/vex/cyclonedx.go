@@ -0,0 +1,189 @@
+package vex
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/projectdiscovery/nuclei/v3/pkg/output"
+)
+
+// cycloneDX is a CycloneDX 1.5 vulnerability disclosure report
+type cycloneDX struct {
+	BOMFormat       string              `json:"bomFormat"`
+	SpecVersion     string              `json:"specVersion"`
+	SerialNumber    string              `json:"serialNumber"`
+	Version         int                 `json:"version"`
+	Metadata        cdxMetadata         `json:"metadata"`
+	Components      []*cdxComponent     `json:"components,omitempty"`
+	Vulnerabilities []*cdxVulnerability `json:"vulnerabilities"`
+}
+
+type cdxMetadata struct {
+	Timestamp string   `json:"timestamp"`
+	Tools     cdxTools `json:"tools"`
+}
+
+type cdxTools struct {
+	Components []*cdxComponent `json:"components"`
+}
+
+type cdxComponent struct {
+	Type      string `json:"type"`
+	BOMRef    string `json:"bom-ref,omitempty"`
+	Publisher string `json:"publisher,omitempty"`
+	Name      string `json:"name"`
+	Version   string `json:"version,omitempty"`
+	CPE       string `json:"cpe,omitempty"`
+}
+
+type cdxVulnerability struct {
+	BOMRef         string          `json:"bom-ref"`
+	ID             string          `json:"id"`
+	Source         *cdxSource      `json:"source,omitempty"`
+	References     []*cdxReference `json:"references,omitempty"`
+	Ratings        []*cdxRating    `json:"ratings,omitempty"`
+	CWEs           []int           `json:"cwes,omitempty"`
+	Description    string          `json:"description,omitempty"`
+	Detail         string          `json:"detail,omitempty"`
+	Recommendation string          `json:"recommendation,omitempty"`
+	Advisories     []*cdxAdvisory  `json:"advisories,omitempty"`
+	Created        string          `json:"created,omitempty"`
+	Analysis       *cdxAnalysis    `json:"analysis,omitempty"`
+	Affects        []*cdxAffect    `json:"affects"`
+	Properties     []*cdxProperty  `json:"properties,omitempty"`
+}
+
+type cdxSource struct {
+	Name string `json:"name,omitempty"`
+	URL  string `json:"url,omitempty"`
+}
+
+type cdxReference struct {
+	ID     string     `json:"id"`
+	Source *cdxSource `json:"source"`
+}
+
+type cdxRating struct {
+	Source   *cdxSource `json:"source,omitempty"`
+	Score    float64    `json:"score,omitempty"`
+	Severity string     `json:"severity,omitempty"`
+	Method   string     `json:"method,omitempty"`
+	Vector   string     `json:"vector,omitempty"`
+}
+
+type cdxAdvisory struct {
+	URL string `json:"url"`
+}
+
+type cdxAnalysis struct {
+	State  string `json:"state"`
+	Detail string `json:"detail,omitempty"`
+}
+
+type cdxAffect struct {
+	Ref string `json:"ref"`
+}
+
+type cdxProperty struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// newCycloneDX creates a CycloneDX vulnerability disclosure report from results
+func newCycloneDX(rows []output.ResultEvent, now time.Time) *cycloneDX {
+	name, version := tool()
+	document := &cycloneDX{
+		BOMFormat:    "CycloneDX",
+		SpecVersion:  "1.5",
+		SerialNumber: newSerialNumber(),
+		Version:      1,
+		Metadata: cdxMetadata{
+			Timestamp: now.UTC().Format(time.RFC3339),
+			Tools: cdxTools{Components: []*cdxComponent{{
+				Type:      "application",
+				Publisher: "ProjectDiscovery",
+				Name:      name,
+				Version:   version,
+			}}},
+		},
+		Vulnerabilities: []*cdxVulnerability{},
+	}
+
+	components := make(map[string]*cdxComponent)
+	for i := range rows {
+		event := &rows[i]
+
+		// the affected component is the host together with its CPE
+		componentCPE := cpe(event)
+		ref := "host:" + event.Host
+		if componentCPE != "" {
+			ref += "|" + componentCPE
+		}
+		if _, ok := components[ref]; !ok {
+			component := &cdxComponent{Type: "application", BOMRef: ref, Name: event.Host, CPE: componentCPE}
+			components[ref] = component
+			document.Components = append(document.Components, component)
+		}
+
+		id, aliases := vulnerabilityID(event)
+		vulnerability := &cdxVulnerability{
+			BOMRef:         fmt.Sprintf("vulnerability-%d", i+1),
+			ID:             id,
+			Source:         &cdxSource{Name: "nuclei-templates", URL: event.TemplateURL},
+			Description:    event.Info.Name,
+			Detail:         event.Info.Description,
+			Recommendation: event.Info.Remediation,
+			Analysis:       &cdxAnalysis{State: "exploitable", Detail: evidence(event)},
+			Affects:        []*cdxAffect{{Ref: ref}},
+		}
+		if !event.Timestamp.IsZero() {
+			vulnerability.Created = event.Timestamp.UTC().Format(time.RFC3339)
+		}
+		if id != event.TemplateID {
+			vulnerability.Source = &cdxSource{Name: "NVD", URL: "https://nvd.nist.gov/vuln/detail/" + id}
+			vulnerability.References = append(vulnerability.References, &cdxReference{
+				ID:     event.TemplateID,
+				Source: &cdxSource{Name: "nuclei-templates", URL: event.TemplateURL},
+			})
+		}
+		for _, alias := range aliases {
+			vulnerability.References = append(vulnerability.References, &cdxReference{
+				ID:     alias,
+				Source: &cdxSource{Name: "NVD", URL: "https://nvd.nist.gov/vuln/detail/" + alias},
+			})
+		}
+
+		rating := &cdxRating{Severity: severityName(event)}
+		if classification := event.Info.Classification; classification != nil {
+			vulnerability.CWEs = cweIDs(classification.CWEID.ToSlice())
+			if classification.CVSSMetrics != "" || classification.CVSSScore > 0 {
+				rating.Score = classification.CVSSScore
+				rating.Vector = classification.CVSSMetrics
+				rating.Method = cvssMethod(classification.CVSSMetrics)
+			}
+			if classification.EPSSScore > 0 {
+				vulnerability.Properties = append(vulnerability.Properties,
+					&cdxProperty{Name: "nuclei:epss-score", Value: fmt.Sprint(classification.EPSSScore)},
+					&cdxProperty{Name: "nuclei:epss-percentile", Value: fmt.Sprint(classification.EPSSPercentile)},
+				)
+			}
+		}
+		vulnerability.Ratings = []*cdxRating{rating}
+
+		if event.Info.Reference != nil {
+			for _, reference := range event.Info.Reference.ToSlice() {
+				vulnerability.Advisories = append(vulnerability.Advisories, &cdxAdvisory{URL: reference})
+			}
+		}
+
+		vulnerability.Properties = append(vulnerability.Properties,
+			&cdxProperty{Name: "nuclei:template-id", Value: event.TemplateID},
+			&cdxProperty{Name: "nuclei:matched-at", Value: event.Matched},
+		)
+		for _, extracted := range event.ExtractedResults {
+			vulnerability.Properties = append(vulnerability.Properties, &cdxProperty{Name: "nuclei:extracted-result", Value: extracted})
+		}
+		document.Vulnerabilities = append(document.Vulnerabilities, vulnerability)
+	}
+	return document
+}
@@ -0,0 +1,104 @@
+package vex
+
+import (
+	"strings"
+	"time"
+
+	"github.com/projectdiscovery/nuclei/v3/pkg/output"
+)
+
+// openVEX is an OpenVEX 0.2.0 document
+type openVEX struct {
+	Context    string          `json:"@context"`
+	ID         string          `json:"@id"`
+	Author     string          `json:"author"`
+	Timestamp  string          `json:"timestamp"`
+	Version    int             `json:"version"`
+	Tooling    string          `json:"tooling,omitempty"`
+	Statements []*vexStatement `json:"statements"`
+}
+
+type vexStatement struct {
+	Vulnerability   vexVulnerability `json:"vulnerability"`
+	Timestamp       string           `json:"timestamp,omitempty"`
+	Products        []*vexProduct    `json:"products"`
+	Status          string           `json:"status"`
+	StatusNotes     string           `json:"status_notes,omitempty"`
+	ActionStatement string           `json:"action_statement,omitempty"`
+}
+
+type vexVulnerability struct {
+	ID          string   `json:"@id,omitempty"`
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Aliases     []string `json:"aliases,omitempty"`
+}
+
+type vexProduct struct {
+	ID          string            `json:"@id"`
+	Identifiers map[string]string `json:"identifiers,omitempty"`
+}
+
+// newOpenVEX creates an OpenVEX document with affected statements from results
+func newOpenVEX(rows []output.ResultEvent, now time.Time) *openVEX {
+	name, version := tool()
+	document := &openVEX{
+		Context:    "https://openvex.dev/ns/v0.2.0",
+		ID:         newSerialNumber(),
+		Author:     name,
+		Timestamp:  now.UTC().Format(time.RFC3339),
+		Version:    1,
+		Tooling:    name + " " + version,
+		Statements: []*vexStatement{},
+	}
+
+	for i := range rows {
+		event := &rows[i]
+
+		id, aliases := vulnerabilityID(event)
+		statement := &vexStatement{
+			Vulnerability: vexVulnerability{
+				Name:        id,
+				Description: event.Info.Name,
+				Aliases:     aliases,
+			},
+			Products:        []*vexProduct{newVEXProduct(event)},
+			Status:          "affected",
+			StatusNotes:     evidence(event),
+			ActionStatement: event.Info.Remediation,
+		}
+		if id != event.TemplateID {
+			statement.Vulnerability.ID = "https://nvd.nist.gov/vuln/detail/" + id
+			statement.Vulnerability.Aliases = append(statement.Vulnerability.Aliases, event.TemplateID)
+		}
+		// affected statements require an action statement
+		if statement.ActionStatement == "" {
+			statement.ActionStatement = "Review and remediate the issue detected by the " + event.TemplateID + " template"
+		}
+		if !event.Timestamp.IsZero() {
+			statement.Timestamp = event.Timestamp.UTC().Format(time.RFC3339)
+		}
+		document.Statements = append(document.Statements, statement)
+	}
+	return document
+}
+
+// newVEXProduct returns the affected product of an event
+func newVEXProduct(event *output.ResultEvent) *vexProduct {
+	product := &vexProduct{ID: event.Host}
+	if event.URL != "" {
+		product.ID = event.URL
+	}
+	if value := cpe(event); value != "" {
+		product.Identifiers = map[string]string{cpeIdentifier(value): value}
+	}
+	return product
+}
+
+// cpeIdentifier returns the OpenVEX identifier type of a CPE
+func cpeIdentifier(value string) string {
+	if strings.HasPrefix(value, "cpe:2.3:") {
+		return "cpe23"
+	}
+	return "cpe22"
+}
@@ -0,0 +1,173 @@
+// Package vex implements an exporter for vulnerability exchange documents
+// in the CycloneDX VDR and OpenVEX formats.
+package vex
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/projectdiscovery/nuclei/v3/pkg/catalog/config"
+	"github.com/projectdiscovery/nuclei/v3/pkg/model/types/severity"
+	"github.com/projectdiscovery/nuclei/v3/pkg/output"
+	"github.com/projectdiscovery/nuclei/v3/pkg/reporting/format"
+)
+
+const (
+	// FormatCycloneDX is the CycloneDX vulnerability disclosure report format
+	FormatCycloneDX = "cyclonedx"
+	// FormatOpenVEX is the OpenVEX format
+	FormatOpenVEX = "openvex"
+)
+
+// Exporter is an exporter for vulnerability exchange documents
+type Exporter struct {
+	options *Options
+	mutex   *sync.Mutex
+	rows    []output.ResultEvent
+}
+
+// Options contains the configuration options for vex exporter client
+type Options struct {
+	// File is the file to export the document to
+	File string `yaml:"file"`
+	// Format is the format of the document (cyclonedx, openvex)
+	Format string `yaml:"format"`
+}
+
+// New creates a new vex exporter integration client based on options.
+func New(options *Options) (*Exporter, error) {
+	if options.File == "" {
+		return nil, errors.New("no file specified for vex export")
+	}
+	switch options.Format {
+	case "":
+		options.Format = FormatCycloneDX
+	case FormatCycloneDX, FormatOpenVEX:
+	default:
+		return nil, fmt.Errorf("unsupported vex format %q (supported: %s, %s)", options.Format, FormatCycloneDX, FormatOpenVEX)
+	}
+	exporter := &Exporter{
+		mutex:   &sync.Mutex{},
+		options: options,
+		rows:    []output.ResultEvent{},
+	}
+	return exporter, nil
+}
+
+// Export appends the passed result event to the vulnerabilities of the document
+func (exporter *Exporter) Export(event *output.ResultEvent) error {
+	exporter.mutex.Lock()
+	defer exporter.mutex.Unlock()
+
+	exporter.rows = append(exporter.rows, *event)
+	return nil
+}
+
+// Close writes the document to the file specified in options
+// and closes the exporter after operation
+func (exporter *Exporter) Close() error {
+	exporter.mutex.Lock()
+	defer exporter.mutex.Unlock()
+
+	var document interface{}
+	if exporter.options.Format == FormatOpenVEX {
+		document = newOpenVEX(exporter.rows, time.Now())
+	} else {
+		document = newCycloneDX(exporter.rows, time.Now())
+	}
+	data, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to generate vex document")
+	}
+	if err := os.WriteFile(exporter.options.File, data, 0644); err != nil {
+		return errors.Wrap(err, "failed to create vex file")
+	}
+	return nil
+}
+
+// vulnerabilityID returns the CVE ID of an event or the template ID if there is none
+func vulnerabilityID(event *output.ResultEvent) (string, []string) {
+	if event.Info.Classification != nil {
+		if cves := event.Info.Classification.CVEID.ToSlice(); len(cves) > 0 {
+			ids := make([]string, 0, len(cves))
+			for _, cve := range cves {
+				ids = append(ids, strings.ToUpper(cve))
+			}
+			return ids[0], ids[1:]
+		}
+	}
+	return event.TemplateID, nil
+}
+
+// cpe returns the CPE of the affected component of an event
+func cpe(event *output.ResultEvent) string {
+	if event.Info.Classification != nil {
+		return event.Info.Classification.CPE
+	}
+	return ""
+}
+
+// evidence returns a description of the evidence of an event
+func evidence(event *output.ResultEvent) string {
+	builder := &strings.Builder{}
+	builder.WriteString(format.GetMatchedTemplateName(event))
+	builder.WriteString(" matched at ")
+	builder.WriteString(event.Matched)
+	if len(event.ExtractedResults) > 0 {
+		builder.WriteString(", extracted: ")
+		builder.WriteString(strings.Join(event.ExtractedResults, ", "))
+	}
+	return builder.String()
+}
+
+// severityName returns the name of the severity of an event
+func severityName(event *output.ResultEvent) string {
+	value := event.Info.SeverityHolder.Severity
+	if value == severity.Undefined {
+		return severity.Unknown.String()
+	}
+	return value.String()
+}
+
+// cvssMethod returns the CycloneDX rating method of a CVSS vector
+func cvssMethod(vector string) string {
+	switch {
+	case strings.Contains(vector, "4.0/"):
+		return "CVSSv4"
+	case strings.Contains(vector, "3.1/"):
+		return "CVSSv31"
+	case strings.Contains(vector, "3.0/"):
+		return "CVSSv3"
+	case strings.HasPrefix(vector, "AV:") || strings.HasPrefix(vector, "(AV:"):
+		return "CVSSv2"
+	}
+	return "other"
+}
+
+// cweIDs returns the numeric CWE IDs of CWE-XXX values
+func cweIDs(values []string) []int {
+	var ids []int
+	for _, value := range values {
+		value = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(value)), "CWE-")
+		if id, err := strconv.Atoi(value); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// tool returns the name and version of nuclei
+func tool() (string, string) {
+	return "nuclei", config.Version
+}
+
+func newSerialNumber() string {
+	return "urn:uuid:" + uuid.New().String()
+}
@@ -0,0 +1,63 @@
+package vex
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/projectdiscovery/nuclei/v3/pkg/model"
+	"github.com/projectdiscovery/nuclei/v3/pkg/model/types/severity"
+	"github.com/projectdiscovery/nuclei/v3/pkg/model/types/stringslice"
+	"github.com/projectdiscovery/nuclei/v3/pkg/output"
+)
+
+func TestVulnerabilityMapping(t *testing.T) {
+	rows := []output.ResultEvent{{
+		TemplateID: "CVE-2021-44228",
+		Host:       "example.com:443",
+		URL:        "https://example.com",
+		Matched:    "https://example.com/api",
+		Info: model.Info{
+			Name:           "Apache Log4j2 Remote Code Injection",
+			SeverityHolder: severity.Holder{Severity: severity.Critical},
+			Classification: &model.Classification{
+				CVEID:       stringslice.New("cve-2021-44228"),
+				CWEID:       stringslice.New([]string{"CWE-502", "CWE-400"}),
+				CVSSMetrics: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:C/C:H/I:H/A:H",
+				CVSSScore:   10,
+				CPE:         "cpe:2.3:a:apache:log4j:*:*:*:*:*:*:*:*",
+			},
+		},
+		ExtractedResults: []string{"dns-interaction"},
+	}, {
+		TemplateID: "tech-detect",
+		Host:       "example.com:443",
+		Matched:    "https://example.com",
+		Info:       model.Info{Name: "Tech Detect"},
+	}}
+
+	cdx := newCycloneDX(rows, time.Now())
+	require.Len(t, cdx.Vulnerabilities, 2)
+	require.Len(t, cdx.Components, 2, "components of different cpes were merged")
+
+	vulnerability := cdx.Vulnerabilities[0]
+	require.Equal(t, "CVE-2021-44228", vulnerability.ID)
+	require.Equal(t, []int{502, 400}, vulnerability.CWEs)
+	require.Equal(t, "CVSSv31", vulnerability.Ratings[0].Method)
+	require.Equal(t, "critical", vulnerability.Ratings[0].Severity)
+	require.Equal(t, float64(10), vulnerability.Ratings[0].Score)
+	require.Equal(t, "host:example.com:443|cpe:2.3:a:apache:log4j:*:*:*:*:*:*:*:*", vulnerability.Affects[0].Ref)
+	require.Contains(t, vulnerability.Analysis.Detail, "dns-interaction")
+	require.Equal(t, "tech-detect", cdx.Vulnerabilities[1].ID)
+	require.Equal(t, "unknown", cdx.Vulnerabilities[1].Ratings[0].Severity)
+
+	vex := newOpenVEX(rows, time.Now())
+	require.Len(t, vex.Statements, 2)
+	statement := vex.Statements[0]
+	require.Equal(t, "affected", statement.Status)
+	require.Equal(t, "CVE-2021-44228", statement.Vulnerability.Name)
+	require.Equal(t, "https://example.com", statement.Products[0].ID)
+	require.Equal(t, "cpe:2.3:a:apache:log4j:*:*:*:*:*:*:*:*", statement.Products[0].Identifiers["cpe23"])
+	require.NotEmpty(t, vex.Statements[1].ActionStatement, "affected statement has no action statement")
+}
@@ -0,0 +1,49 @@
+package webhook
+
+import (
+	"encoding/json"
+	"text/template"
+
+	"github.com/projectdiscovery/nuclei/v3/pkg/output"
+	"github.com/projectdiscovery/nuclei/v3/pkg/reporting/format"
+)
+
+// templateFuncs are the functions available in payload templates
+var templateFuncs = template.FuncMap{
+	// json returns the json encoding of a value
+	"json": func(value interface{}) (string, error) {
+		data, err := json.Marshal(value)
+		return string(data), err
+	},
+	// summary returns the one line summary of a finding
+	"summary": func(event *output.ResultEvent) string {
+		return format.Summary(event)
+	},
+	// truncate truncates a string to a maximum number of characters
+	"truncate": func(length int, value string) string {
+		runes := []rune(value)
+		if len(runes) <= length {
+			return value
+		}
+		if length <= 3 {
+			return string(runes[:length])
+		}
+		return string(runes[:length-3]) + "..."
+	},
+}
+
+// findingsText is the text listing the findings of a notification shared by chat presets
+const findingsText = `{{- $text := printf "%d new nuclei finding(s)" .Count -}}
+{{- range .Findings -}}
+{{- $text = printf "%s\n[%s] %s - %s" $text .Info.SeverityHolder.Severity (summary .) .Matched -}}
+{{- end -}}`
+
+// presets are the built-in payload templates
+var presets = map[string]string{
+	"":        `{"count": {{.Count}}, "findings": {{json .Findings}}}`,
+	"generic": `{"count": {{.Count}}, "findings": {{json .Findings}}}`,
+	"slack":   findingsText + `{"text": {{json $text}}}`,
+	"discord": findingsText + `{"content": {{json (truncate 2000 $text)}}}`,
+	"teams": findingsText + `{"@type": "MessageCard", "@context": "https://schema.org/extensions", ` +
+		`"summary": {{json (printf "%d new nuclei finding(s)" .Count)}}, "text": {{json $text}}}`,
+}
@@ -0,0 +1,229 @@
+// Package webhook implements an exporter notifying webhooks and
+// chat services (slack, teams, discord) about findings.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/projectdiscovery/gologger"
+	"github.com/projectdiscovery/nuclei/v3/pkg/output"
+	"github.com/projectdiscovery/nuclei/v3/pkg/reporting/trackers/filters"
+	"github.com/projectdiscovery/retryablehttp-go"
+)
+
+// defaultMaxRetries is the number of retries of failed notifications
+const defaultMaxRetries = 3
+
+// Options contains the configuration options for a webhook notifier
+type Options struct {
+	// Name is the name of the notifier used in logs
+	Name string `yaml:"name"`
+	// URL is the webhook url findings are posted to
+	URL string `yaml:"url"`
+	// Preset is the built-in payload format (generic, slack, teams, discord)
+	Preset string `yaml:"preset"`
+	// Template is a go template for the payload overriding the preset
+	Template string `yaml:"template"`
+	// Method is the http method of notifications (default POST)
+	Method string `yaml:"method"`
+	// ContentType is the content type of the payload (default application/json)
+	ContentType string `yaml:"content-type"`
+	// Headers are additional headers of notifications (ex. authorization)
+	Headers map[string]string `yaml:"headers"`
+	// AllowList contains a list of allowed events for the notifier
+	AllowList *filters.Filter `yaml:"allow-list"`
+	// DenyList contains a list of denied events for the notifier
+	DenyList *filters.Filter `yaml:"deny-list"`
+	// BatchInterval is the digest window findings are collected
+	// for before being sent in a single notification
+	BatchInterval time.Duration `yaml:"batch-interval"`
+	// BatchSize is the maximum number of findings in a notification
+	BatchSize int `yaml:"batch-size"`
+	// MaxRetries is the maximum number of retries of failed notifications
+	MaxRetries int `yaml:"max-retries"`
+
+	HttpClient *retryablehttp.Client `yaml:"-"`
+}
+
+// Exporter is an exporter posting findings to a webhook
+type Exporter struct {
+	options  *Options
+	client   *retryablehttp.Client
+	template *template.Template
+
+	mutex   sync.Mutex
+	pending []*output.ResultEvent
+	timer   *time.Timer
+	// flushes tracks notifications sent by the batch timer
+	flushes sync.WaitGroup
+}
+
+// payload is the data of payload templates
+type payload struct {
+	// Findings are the findings of the notification
+	Findings []*output.ResultEvent
+	// Count is the number of findings of the notification
+	Count int
+}
+
+// New creates a new webhook exporter based on options
+func New(options *Options) (*Exporter, error) {
+	if options.URL == "" {
+		return nil, errors.New("no url specified for webhook")
+	}
+	if options.Name == "" {
+		options.Name = options.URL
+	}
+	if options.Method == "" {
+		options.Method = http.MethodPost
+	}
+	if options.ContentType == "" {
+		options.ContentType = "application/json"
+	}
+	if options.MaxRetries <= 0 {
+		options.MaxRetries = defaultMaxRetries
+	}
+
+	body := options.Template
+	if body == "" {
+		preset, ok := presets[strings.ToLower(options.Preset)]
+		if !ok {
+			return nil, fmt.Errorf("unknown webhook preset %q", options.Preset)
+		}
+		body = preset
+	}
+	tpl, err := template.New("payload").Funcs(templateFuncs).Parse(body)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not parse webhook template")
+	}
+
+	// the transport of the reporting http client is reused (proxy etc.)
+	// with a retry policy for failed notifications
+	httpClient := http.DefaultClient
+	if options.HttpClient != nil {
+		httpClient = options.HttpClient.HTTPClient
+	}
+	clientOptions := retryablehttp.DefaultOptionsSingle
+	clientOptions.RetryMax = options.MaxRetries
+	clientOptions.CheckRetry = retryPolicy
+
+	exporter := &Exporter{
+		options:  options,
+		client:   retryablehttp.NewWithHTTPClient(httpClient, clientOptions),
+		template: tpl,
+	}
+	return exporter, nil
+}
+
+// retryPolicy retries notifications on recoverable errors,
+// rate limiting and server errors
+func retryPolicy(ctx context.Context, resp *http.Response, err error) (bool, error) {
+	if resp != nil && (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError) {
+		return ctx.Err() == nil, ctx.Err()
+	}
+	return retryablehttp.CheckRecoverableErrors(ctx, resp, err)
+}
+
+// ShouldNotify returns true if the event passes the filters of the notifier
+func (exporter *Exporter) ShouldNotify(event *output.ResultEvent) bool {
+	return filters.ShouldReport(exporter.options.AllowList, exporter.options.DenyList, event)
+}
+
+// Export sends the event to the webhook or adds it to the current batch
+func (exporter *Exporter) Export(event *output.ResultEvent) error {
+	if !exporter.ShouldNotify(event) {
+		return nil
+	}
+
+	exporter.mutex.Lock()
+	exporter.pending = append(exporter.pending, event)
+	if exporter.options.BatchInterval <= 0 || (exporter.options.BatchSize > 0 && len(exporter.pending) >= exporter.options.BatchSize) {
+		batch := exporter.takePending()
+		exporter.mutex.Unlock()
+		return exporter.send(batch)
+	}
+	if exporter.timer == nil {
+		exporter.flushes.Add(1)
+		exporter.timer = time.AfterFunc(exporter.options.BatchInterval, exporter.flush)
+	}
+	exporter.mutex.Unlock()
+	return nil
+}
+
+// flush sends the findings of the current batch once the batch interval elapsed
+func (exporter *Exporter) flush() {
+	defer exporter.flushes.Done()
+
+	exporter.mutex.Lock()
+	batch := exporter.takePending()
+	exporter.mutex.Unlock()
+
+	if err := exporter.send(batch); err != nil {
+		gologger.Warning().Msgf("Could not send notification to %s: %s\n", exporter.options.Name, err)
+	}
+}
+
+// takePending returns the pending findings and stops the batch timer.
+// It must be called with the mutex held.
+func (exporter *Exporter) takePending() []*output.ResultEvent {
+	if exporter.timer != nil && exporter.timer.Stop() {
+		// the timer did not fire so its flush is not going to run
+		exporter.flushes.Done()
+	}
+	exporter.timer = nil
+	batch := exporter.pending
+	exporter.pending = nil
+	return batch
+}
+
+// send posts a notification with the findings to the webhook
+func (exporter *Exporter) send(findings []*output.ResultEvent) error {
+	if len(findings) == 0 {
+		return nil
+	}
+
+	body := &bytes.Buffer{}
+	if err := exporter.template.Execute(body, &payload{Findings: findings, Count: len(findings)}); err != nil {
+		return errors.Wrap(err, "could not execute webhook template")
+	}
+	req, err := retryablehttp.NewRequest(exporter.options.Method, exporter.options.URL, body.Bytes())
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", exporter.options.ContentType)
+	for key, value := range exporter.options.Headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := exporter.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "could not send notification")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("unexpected status code %d from webhook: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
+
+// Close sends the pending findings and closes the exporter after operation
+func (exporter *Exporter) Close() error {
+	exporter.mutex.Lock()
+	batch := exporter.takePending()
+	exporter.mutex.Unlock()
+
+	exporter.flushes.Wait()
+	return exporter.send(batch)
+}
@@ -0,0 +1,92 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/projectdiscovery/nuclei/v3/pkg/model"
+	"github.com/projectdiscovery/nuclei/v3/pkg/model/types/severity"
+	"github.com/projectdiscovery/nuclei/v3/pkg/output"
+	"github.com/projectdiscovery/nuclei/v3/pkg/reporting/trackers/filters"
+)
+
+func newEvent(templateID string, value severity.Severity) *output.ResultEvent {
+	return &output.ResultEvent{
+		TemplateID: templateID,
+		Host:       "example.com",
+		Matched:    "https://example.com",
+		Info:       model.Info{Name: templateID, SeverityHolder: severity.Holder{Severity: value}},
+	}
+}
+
+func TestWebhookExporter(t *testing.T) {
+	var mu sync.Mutex
+	var requests []map[string]interface{}
+	var failures int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		// the first request is rate limited and retried
+		if failures == 0 {
+			failures++
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		data, _ := io.ReadAll(r.Body)
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(data, &body), "invalid payload: %s", data)
+		requests = append(requests, body)
+	}))
+	defer server.Close()
+
+	exporter, err := New(&Options{
+		URL:           server.URL,
+		Preset:        "slack",
+		AllowList:     &filters.Filter{Severities: severity.Severities{severity.High, severity.Critical}},
+		BatchInterval: time.Hour,
+		BatchSize:     2,
+		MaxRetries:    2,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, exporter.Export(newEvent("first", severity.Critical)))
+	require.NoError(t, exporter.Export(newEvent("filtered", severity.Info)))
+	require.NoError(t, exporter.Export(newEvent("second", severity.High)))
+	require.NoError(t, exporter.Export(newEvent("third", severity.High)))
+	require.NoError(t, exporter.Close())
+
+	require.Len(t, requests, 2, "findings were not batched")
+	require.Equal(t, "2 new nuclei finding(s)\n[critical] first (first) found on example.com - https://example.com\n[high] second (second) found on example.com - https://example.com", requests[0]["text"])
+	require.Contains(t, requests[1]["text"], "third")
+}
+
+func TestWebhookPresets(t *testing.T) {
+	for preset := range presets {
+		exporter, err := New(&Options{URL: "http://127.0.0.1", Preset: preset})
+		require.NoError(t, err, "could not create exporter for %s preset", preset)
+
+		var body map[string]interface{}
+		data := &payload{Findings: []*output.ResultEvent{newEvent("test", severity.High)}, Count: 1}
+		buffer := &bytes.Buffer{}
+		require.NoError(t, exporter.template.Execute(buffer, data))
+		require.NoError(t, json.Unmarshal(buffer.Bytes(), &body), "invalid %s payload: %s", preset, buffer.String())
+	}
+
+	_, err := New(&Options{URL: "http://127.0.0.1", Preset: "unknown"})
+	require.Error(t, err)
+}
+
+func TestTruncate(t *testing.T) {
+	truncate := templateFuncs["truncate"].(func(int, string) string)
+	require.Equal(t, "short", truncate(10, "short"))
+	require.Equal(t, "héllo w...", truncate(10, "héllo wörld!"), "multi-byte characters should be counted once")
+	require.Equal(t, "日本", truncate(2, "日本語"))
+}
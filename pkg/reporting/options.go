@@ -2,12 +2,20 @@ package reporting
 
 import (
 	"github.com/projectdiscovery/nuclei/v3/pkg/reporting/exporters/es"
+	"github.com/projectdiscovery/nuclei/v3/pkg/reporting/exporters/html"
 	"github.com/projectdiscovery/nuclei/v3/pkg/reporting/exporters/jsonexporter"
 	"github.com/projectdiscovery/nuclei/v3/pkg/reporting/exporters/jsonl"
 	"github.com/projectdiscovery/nuclei/v3/pkg/reporting/exporters/markdown"
 	"github.com/projectdiscovery/nuclei/v3/pkg/reporting/exporters/mongo"
 	"github.com/projectdiscovery/nuclei/v3/pkg/reporting/exporters/sarif"
 	"github.com/projectdiscovery/nuclei/v3/pkg/reporting/exporters/splunk"
+	"github.com/projectdiscovery/nuclei/v3/pkg/reporting/exporters/sqlexporter"
+	"github.com/projectdiscovery/nuclei/v3/pkg/reporting/exporters/stream"
+	"github.com/projectdiscovery/nuclei/v3/pkg/reporting/exporters/vex"
+	"github.com/projectdiscovery/nuclei/v3/pkg/reporting/exporters/webhook"
+	"github.com/projectdiscovery/nuclei/v3/pkg/reporting/trackers/azuredevops"
+	"github.com/projectdiscovery/nuclei/v3/pkg/reporting/trackers/bitbucket"
+	"github.com/projectdiscovery/nuclei/v3/pkg/reporting/trackers/defectdojo"
 	"github.com/projectdiscovery/nuclei/v3/pkg/reporting/trackers/filters"
 	"github.com/projectdiscovery/nuclei/v3/pkg/reporting/trackers/gitea"
 	"github.com/projectdiscovery/nuclei/v3/pkg/reporting/trackers/github"
@@ -23,6 +31,8 @@ type Options struct {
 	AllowList *filters.Filter `yaml:"allow-list"`
 	// DenyList contains a list of denied events for reporting module
 	DenyList *filters.Filter `yaml:"deny-list"`
+	// ExporterFilters contains allow/deny lists of exporters by their configuration key (e.g. markdown, jsonl)
+	ExporterFilters map[string]*ExporterFilter `yaml:"exporter-filters"`
 	// GitHub contains configuration options for GitHub Issue Tracker
 	GitHub *github.Options `yaml:"github"`
 	// GitLab contains configuration options for GitLab Issue Tracker
@@ -33,6 +43,12 @@ type Options struct {
 	Jira *jira.Options `yaml:"jira"`
 	// Linear contains configuration options for Linear Issue Tracker
 	Linear *linear.Options `yaml:"linear"`
+	// DefectDojo contains configuration options for DefectDojo Issue Tracker
+	DefectDojo *defectdojo.Options `yaml:"defectdojo"`
+	// AzureDevOps contains configuration options for Azure DevOps Boards Issue Tracker
+	AzureDevOps *azuredevops.Options `yaml:"azure-devops"`
+	// Bitbucket contains configuration options for Bitbucket Cloud Issue Tracker
+	Bitbucket *bitbucket.Options `yaml:"bitbucket"`
 	// MarkdownExporter contains configuration options for Markdown Exporter Module
 	MarkdownExporter *markdown.Options `yaml:"markdown"`
 	// SarifExporter contains configuration options for Sarif Exporter Module
@@ -45,9 +61,27 @@ type Options struct {
 	JSONExporter *jsonexporter.Options `yaml:"json"`
 	// JSONLExporter contains configuration options for JSONL Exporter Module
 	JSONLExporter *jsonl.Options `yaml:"jsonl"`
+	// HTMLExporter contains configuration options for HTML Exporter Module
+	HTMLExporter *html.Options `yaml:"html"`
+	// VEXExporter contains configuration options for CycloneDX/OpenVEX Exporter Module
+	VEXExporter *vex.Options `yaml:"vex"`
+	// Webhooks contains configuration options for webhook and chat notifiers
+	Webhooks []*webhook.Options `yaml:"webhooks"`
 	// MongoDBExporter containers the configuration options for the MongoDB Exporter Module
 	MongoDBExporter *mongo.Options `yaml:"mongodb"`
+	// SQLExporter contains configuration options for the SQLite/PostgreSQL Exporter Module
+	SQLExporter *sqlexporter.Options `yaml:"sql"`
+	// StreamExporter contains configuration options for the Kafka/NATS Streaming Exporter Module
+	StreamExporter *stream.Options `yaml:"stream"`
 
 	HttpClient *retryablehttp.Client `yaml:"-"`
 	OmitRaw    bool                  `yaml:"-"`
 }
+
+// ExporterFilter contains the allow/deny lists of an exporter
+type ExporterFilter struct {
+	// AllowList contains a list of allowed events for the exporter
+	AllowList *filters.Filter `yaml:"allow-list"`
+	// DenyList contains a list of denied events for the exporter
+	DenyList *filters.Filter `yaml:"deny-list"`
+}
@@ -0,0 +1,122 @@
+package reporting
+
+import (
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+
+	"go.uber.org/multierr"
+
+	"github.com/projectdiscovery/gologger"
+	"github.com/projectdiscovery/nuclei/v3/pkg/output"
+	"github.com/projectdiscovery/nuclei/v3/pkg/reporting/format"
+)
+
+// ReconcileScope contains the template and host pairs tested by a scan.
+// Only issues of findings in the scope of a scan are reconciled.
+type ReconcileScope struct {
+	mu        sync.RWMutex
+	completed map[string]struct{}
+}
+
+// NewReconcileScope returns a new empty reconcile scope
+func NewReconcileScope() *ReconcileScope {
+	return &ReconcileScope{completed: make(map[string]struct{})}
+}
+
+// AddCompleted adds a template executed to completion on a target to the scope.
+// Pairs whose execution failed (e.g. unreachable hosts) must not be added
+// as the absence of their findings doesn't mean they were fixed.
+func (s *ReconcileScope) AddCompleted(templateID, target string) {
+	host := reconcileHost(target)
+	if host == "" {
+		return
+	}
+	s.mu.Lock()
+	s.completed[templateID+"\x00"+host] = struct{}{}
+	s.mu.Unlock()
+}
+
+// Contains returns true if the finding of an event was tested in the scope
+func (s *ReconcileScope) Contains(event *output.ResultEvent) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, ok := s.completed[event.TemplateID+"\x00"+reconcileHost(event.Host)]
+	return ok
+}
+
+// reconcileHost returns the lowercase hostname of a target or event host
+func reconcileHost(value string) string {
+	value = strings.TrimSpace(value)
+	if strings.Contains(value, "://") {
+		if parsed, err := url.Parse(value); err == nil {
+			return strings.ToLower(parsed.Hostname())
+		}
+	}
+	if host, _, err := net.SplitHostPort(value); err == nil {
+		return strings.ToLower(host)
+	}
+	return strings.ToLower(strings.Trim(value, "[]"))
+}
+
+// issueKey returns the key of the issue created for an event. It contains
+// the same fields as the issue summary used by trackers to find issues.
+func issueKey(event *output.ResultEvent) string {
+	return format.GetMatchedTemplateName(event) + "\x00" + event.Host
+}
+
+// observe records an event found during the current scan
+func (c *ReportingClient) observe(event *output.ResultEvent) {
+	c.observedMu.Lock()
+	c.observed[issueKey(event)] = struct{}{}
+	c.observedMu.Unlock()
+}
+
+// Reconcile closes the tracker issues of findings reported by previous scans
+// which were tested by the current scan and no longer match. Closed findings
+// are removed from the dedupe storage so they are reported again if they reappear.
+//
+// It returns the number of reconciled findings.
+func (c *ReportingClient) Reconcile(scope *ReconcileScope) (int, error) {
+	if c.dedupe == nil {
+		return 0, nil
+	}
+
+	type fixedFinding struct {
+		key   []byte
+		event *output.ResultEvent
+	}
+	var fixed []fixedFinding
+
+	c.observedMu.Lock()
+	err := c.dedupe.Iterate(func(key []byte, event *output.ResultEvent) bool {
+		if !scope.Contains(event) {
+			return true
+		}
+		if _, ok := c.observed[issueKey(event)]; ok {
+			return true
+		}
+		fixed = append(fixed, fixedFinding{key: key, event: event})
+		return true
+	})
+	c.observedMu.Unlock()
+	if err != nil {
+		return 0, err
+	}
+
+	var reconciled int
+	for _, finding := range fixed {
+		if closeErr := c.CloseIssue(finding.event); closeErr != nil {
+			err = multierr.Append(err, closeErr)
+			continue
+		}
+		if deleteErr := c.dedupe.Delete(finding.key); deleteErr != nil {
+			err = multierr.Append(err, deleteErr)
+			continue
+		}
+		gologger.Verbose().Msgf("Closed issue for fixed finding: %s", format.Summary(finding.event))
+		reconciled++
+	}
+	return reconciled, err
+}
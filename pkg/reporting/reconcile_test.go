@@ -0,0 +1,72 @@
+package reporting
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/projectdiscovery/nuclei/v3/pkg/model"
+	"github.com/projectdiscovery/nuclei/v3/pkg/output"
+	"github.com/projectdiscovery/nuclei/v3/pkg/reporting/trackers/filters"
+)
+
+type recordingTracker struct {
+	closed []string
+}
+
+func (t *recordingTracker) Name() string { return "recording" }
+
+func (t *recordingTracker) CreateIssue(event *output.ResultEvent) (*filters.CreateIssueResponse, error) {
+	return &filters.CreateIssueResponse{}, nil
+}
+
+func (t *recordingTracker) CloseIssue(event *output.ResultEvent) error {
+	t.closed = append(t.closed, event.TemplateID+"@"+event.Host)
+	return nil
+}
+
+func (t *recordingTracker) ShouldFilter(event *output.ResultEvent) bool { return true }
+
+func TestReconcile(t *testing.T) {
+	db := t.TempDir()
+	event := func(templateID, host string) *output.ResultEvent {
+		return &output.ResultEvent{TemplateID: templateID, Host: host, Info: model.Info{Name: templateID}}
+	}
+
+	// first scan reports findings
+	client, err := New(&Options{}, db, false)
+	require.NoError(t, err)
+	for _, e := range []*output.ResultEvent{
+		event("fixed", "example.com:443"),
+		event("still-vulnerable", "example.com:443"),
+		event("not-retested", "example.com:443"),
+		event("fixed", "other.com"),
+		event("fixed", "unreachable.com"),
+	} {
+		require.NoError(t, client.CreateIssue(e))
+	}
+	client.Close()
+
+	// rescan only finds one of them
+	client, err = New(&Options{}, db, false)
+	require.NoError(t, err)
+	tracker := &recordingTracker{}
+	client.RegisterTracker(tracker)
+	require.NoError(t, client.CreateIssue(event("still-vulnerable", "example.com:443")))
+
+	// the template couldn't be executed on unreachable.com so its issue is kept
+	scope := NewReconcileScope()
+	scope.AddCompleted("fixed", "https://example.com")
+	scope.AddCompleted("still-vulnerable", "https://example.com")
+
+	reconciled, err := client.Reconcile(scope)
+	require.NoError(t, err)
+	require.Equal(t, 1, reconciled)
+	require.Equal(t, []string{"fixed@example.com:443"}, tracker.closed)
+
+	// closed findings are not reconciled again
+	reconciled, err = client.Reconcile(scope)
+	require.NoError(t, err)
+	require.Zero(t, reconciled)
+	client.Close()
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/projectdiscovery/nuclei/v3/pkg/reporting/exporters/mongo"
 	"os"
 	"strings"
+	"sync"
 	"sync/atomic"
 
 	"github.com/projectdiscovery/gologger"
@@ -21,9 +22,17 @@ import (
 	"github.com/projectdiscovery/nuclei/v3/pkg/output"
 	"github.com/projectdiscovery/nuclei/v3/pkg/reporting/dedupe"
 	"github.com/projectdiscovery/nuclei/v3/pkg/reporting/exporters/es"
+	"github.com/projectdiscovery/nuclei/v3/pkg/reporting/exporters/html"
 	"github.com/projectdiscovery/nuclei/v3/pkg/reporting/exporters/markdown"
 	"github.com/projectdiscovery/nuclei/v3/pkg/reporting/exporters/sarif"
 	"github.com/projectdiscovery/nuclei/v3/pkg/reporting/exporters/splunk"
+	"github.com/projectdiscovery/nuclei/v3/pkg/reporting/exporters/sqlexporter"
+	"github.com/projectdiscovery/nuclei/v3/pkg/reporting/exporters/stream"
+	"github.com/projectdiscovery/nuclei/v3/pkg/reporting/exporters/vex"
+	"github.com/projectdiscovery/nuclei/v3/pkg/reporting/exporters/webhook"
+	"github.com/projectdiscovery/nuclei/v3/pkg/reporting/trackers/azuredevops"
+	"github.com/projectdiscovery/nuclei/v3/pkg/reporting/trackers/bitbucket"
+	"github.com/projectdiscovery/nuclei/v3/pkg/reporting/trackers/defectdojo"
 	"github.com/projectdiscovery/nuclei/v3/pkg/reporting/trackers/filters"
 	"github.com/projectdiscovery/nuclei/v3/pkg/reporting/trackers/gitea"
 	"github.com/projectdiscovery/nuclei/v3/pkg/reporting/trackers/github"
@@ -59,6 +68,21 @@ type Exporter interface {
 	Export(event *output.ResultEvent) error
 }
 
+// filteredExporter is an exporter only exporting events
+// matching the allow/deny lists of its exporter filter
+type filteredExporter struct {
+	Exporter
+	filter *ExporterFilter
+}
+
+// Export exports an issue if it matches the exporter filter
+func (f *filteredExporter) Export(event *output.ResultEvent) error {
+	if !filters.ShouldReport(f.filter.AllowList, f.filter.DenyList, event) {
+		return nil
+	}
+	return f.Exporter.Export(event)
+}
+
 // ReportingClient is a client for nuclei issue tracking module
 type ReportingClient struct {
 	trackers  []Tracker
@@ -67,6 +91,10 @@ type ReportingClient struct {
 	dedupe    *dedupe.Storage
 
 	stats map[string]*IssueTrackerStats
+
+	// observed contains the issue keys of findings of the current scan
+	observed   map[string]struct{}
+	observedMu sync.Mutex
 }
 
 type IssueTrackerStats struct {
@@ -76,7 +104,11 @@ type IssueTrackerStats struct {
 
 // New creates a new nuclei issue tracker reporting client
 func New(options *Options, db string, doNotDedupe bool) (Client, error) {
-	client := &ReportingClient{options: options}
+	client := &ReportingClient{options: options, observed: make(map[string]struct{})}
+
+	if err := compileFilters(options); err != nil {
+		return nil, errorutil.NewWithErr(err).Wrap(ErrReportingClientCreation)
+	}
 
 	if options.GitHub != nil {
 		options.GitHub.HttpClient = options.HttpClient
@@ -123,33 +155,74 @@ func New(options *Options, db string, doNotDedupe bool) (Client, error) {
 		}
 		client.trackers = append(client.trackers, tracker)
 	}
+	if options.DefectDojo != nil {
+		options.DefectDojo.HttpClient = options.HttpClient
+		options.DefectDojo.OmitRaw = options.OmitRaw
+		tracker, err := defectdojo.New(options.DefectDojo)
+		if err != nil {
+			return nil, errorutil.NewWithErr(err).Wrap(ErrReportingClientCreation)
+		}
+		client.trackers = append(client.trackers, tracker)
+	}
+	if options.AzureDevOps != nil {
+		options.AzureDevOps.HttpClient = options.HttpClient
+		options.AzureDevOps.OmitRaw = options.OmitRaw
+		tracker, err := azuredevops.New(options.AzureDevOps)
+		if err != nil {
+			return nil, errorutil.NewWithErr(err).Wrap(ErrReportingClientCreation)
+		}
+		client.trackers = append(client.trackers, tracker)
+	}
+	if options.Bitbucket != nil {
+		options.Bitbucket.HttpClient = options.HttpClient
+		options.Bitbucket.OmitRaw = options.OmitRaw
+		tracker, err := bitbucket.New(options.Bitbucket)
+		if err != nil {
+			return nil, errorutil.NewWithErr(err).Wrap(ErrReportingClientCreation)
+		}
+		client.trackers = append(client.trackers, tracker)
+	}
 	if options.MarkdownExporter != nil {
 		exporter, err := markdown.New(options.MarkdownExporter)
 		if err != nil {
 			return nil, errorutil.NewWithErr(err).Wrap(ErrExportClientCreation)
 		}
-		client.exporters = append(client.exporters, exporter)
+		client.addExporter("markdown", exporter)
 	}
 	if options.SarifExporter != nil {
 		exporter, err := sarif.New(options.SarifExporter)
 		if err != nil {
 			return nil, errorutil.NewWithErr(err).Wrap(ErrExportClientCreation)
 		}
-		client.exporters = append(client.exporters, exporter)
+		client.addExporter("sarif", exporter)
 	}
 	if options.JSONExporter != nil {
 		exporter, err := json_exporter.New(options.JSONExporter)
 		if err != nil {
 			return nil, errorutil.NewWithErr(err).Wrap(ErrExportClientCreation)
 		}
-		client.exporters = append(client.exporters, exporter)
+		client.addExporter("json", exporter)
 	}
 	if options.JSONLExporter != nil {
 		exporter, err := jsonl.New(options.JSONLExporter)
 		if err != nil {
 			return nil, errorutil.NewWithErr(err).Wrap(ErrExportClientCreation)
 		}
-		client.exporters = append(client.exporters, exporter)
+		client.addExporter("jsonl", exporter)
+	}
+	if options.HTMLExporter != nil {
+		exporter, err := html.New(options.HTMLExporter)
+		if err != nil {
+			return nil, errorutil.NewWithErr(err).Wrap(ErrExportClientCreation)
+		}
+		client.addExporter("html", exporter)
+	}
+	if options.VEXExporter != nil {
+		exporter, err := vex.New(options.VEXExporter)
+		if err != nil {
+			return nil, errorutil.NewWithErr(err).Wrap(ErrExportClientCreation)
+		}
+		client.addExporter("vex", exporter)
 	}
 	if options.ElasticsearchExporter != nil {
 		options.ElasticsearchExporter.HttpClient = options.HttpClient
@@ -157,7 +230,7 @@ func New(options *Options, db string, doNotDedupe bool) (Client, error) {
 		if err != nil {
 			return nil, errorutil.NewWithErr(err).Wrap(ErrExportClientCreation)
 		}
-		client.exporters = append(client.exporters, exporter)
+		client.addExporter("elasticsearch", exporter)
 	}
 	if options.SplunkExporter != nil {
 		options.SplunkExporter.HttpClient = options.HttpClient
@@ -165,14 +238,36 @@ func New(options *Options, db string, doNotDedupe bool) (Client, error) {
 		if err != nil {
 			return nil, errorutil.NewWithErr(err).Wrap(ErrExportClientCreation)
 		}
-		client.exporters = append(client.exporters, exporter)
+		client.addExporter("splunkhec", exporter)
+	}
+	for _, webhookOptions := range options.Webhooks {
+		webhookOptions.HttpClient = options.HttpClient
+		exporter, err := webhook.New(webhookOptions)
+		if err != nil {
+			return nil, errorutil.NewWithErr(err).Wrap(ErrExportClientCreation)
+		}
+		client.addExporter("webhooks", exporter)
 	}
 	if options.MongoDBExporter != nil {
 		exporter, err := mongo.New(options.MongoDBExporter)
 		if err != nil {
 			return nil, errorutil.NewWithErr(err).Wrap(ErrExportClientCreation)
 		}
-		client.exporters = append(client.exporters, exporter)
+		client.addExporter("mongodb", exporter)
+	}
+	if options.SQLExporter != nil {
+		exporter, err := sqlexporter.New(options.SQLExporter)
+		if err != nil {
+			return nil, errorutil.NewWithErr(err).Wrap(ErrExportClientCreation)
+		}
+		client.addExporter("sql", exporter)
+	}
+	if options.StreamExporter != nil {
+		exporter, err := stream.New(options.StreamExporter)
+		if err != nil {
+			return nil, errorutil.NewWithErr(err).Wrap(ErrExportClientCreation)
+		}
+		client.addExporter("stream", exporter)
 	}
 
 	if doNotDedupe {
@@ -214,13 +309,21 @@ func CreateConfigIfNotExists() error {
 		Gitea:                 &gitea.Options{},
 		Jira:                  &jira.Options{},
 		Linear:                &linear.Options{},
+		DefectDojo:            &defectdojo.Options{},
+		AzureDevOps:           &azuredevops.Options{},
+		Bitbucket:             &bitbucket.Options{},
 		MarkdownExporter:      &markdown.Options{},
 		SarifExporter:         &sarif.Options{},
 		ElasticsearchExporter: &es.Options{},
 		SplunkExporter:        &splunk.Options{},
 		JSONExporter:          &json_exporter.Options{},
 		JSONLExporter:         &jsonl.Options{},
+		HTMLExporter:          &html.Options{},
+		VEXExporter:           &vex.Options{},
+		Webhooks:              []*webhook.Options{},
 		MongoDBExporter:       &mongo.Options{},
+		SQLExporter:           &sqlexporter.Options{},
+		StreamExporter:        &stream.Options{},
 	}
 	reportingFile, err := os.Create(reportingConfig)
 	if err != nil {
@@ -242,6 +345,60 @@ func (c *ReportingClient) RegisterExporter(exporter Exporter) {
 	c.exporters = append(c.exporters, exporter)
 }
 
+// addExporter adds an exporter filtered by the exporter filter of its configuration key
+func (c *ReportingClient) addExporter(key string, exporter Exporter) {
+	if filter, ok := c.options.ExporterFilters[key]; ok && filter != nil {
+		exporter = &filteredExporter{Exporter: exporter, filter: filter}
+	}
+	c.exporters = append(c.exporters, exporter)
+}
+
+// compileFilters compiles the dsl expressions of all filters to report invalid expressions early
+func compileFilters(options *Options) error {
+	lists := []*filters.Filter{options.AllowList, options.DenyList}
+	for _, filter := range options.ExporterFilters {
+		if filter != nil {
+			lists = append(lists, filter.AllowList, filter.DenyList)
+		}
+	}
+	if options.GitHub != nil {
+		lists = append(lists, options.GitHub.AllowList, options.GitHub.DenyList)
+	}
+	if options.GitLab != nil {
+		lists = append(lists, options.GitLab.AllowList, options.GitLab.DenyList)
+	}
+	if options.Gitea != nil {
+		lists = append(lists, options.Gitea.AllowList, options.Gitea.DenyList)
+	}
+	if options.Jira != nil {
+		lists = append(lists, options.Jira.AllowList, options.Jira.DenyList)
+	}
+	if options.Linear != nil {
+		lists = append(lists, options.Linear.AllowList, options.Linear.DenyList)
+	}
+	if options.DefectDojo != nil {
+		lists = append(lists, options.DefectDojo.AllowList, options.DefectDojo.DenyList)
+	}
+	if options.AzureDevOps != nil {
+		lists = append(lists, options.AzureDevOps.AllowList, options.AzureDevOps.DenyList)
+	}
+	if options.Bitbucket != nil {
+		lists = append(lists, options.Bitbucket.AllowList, options.Bitbucket.DenyList)
+	}
+	for _, webhookOptions := range options.Webhooks {
+		lists = append(lists, webhookOptions.AllowList, webhookOptions.DenyList)
+	}
+	for _, filter := range lists {
+		if filter == nil {
+			continue
+		}
+		if err := filter.Compile(); err != nil {
+			return fmt.Errorf("invalid reporting filter dsl: %w", err)
+		}
+	}
+	return nil
+}
+
 // Close closes the issue tracker reporting client
 func (c *ReportingClient) Close() {
 	// If we have stats for the trackers, print them
@@ -276,16 +433,14 @@ func (c *ReportingClient) Close() {
 // CreateIssue creates an issue in the tracker
 func (c *ReportingClient) CreateIssue(event *output.ResultEvent) error {
 	// process global allow/deny list
-	if c.options.AllowList != nil && !c.options.AllowList.GetMatch(event) {
-		return nil
-	}
-	if c.options.DenyList != nil && c.options.DenyList.GetMatch(event) {
+	if !filters.ShouldReport(c.options.AllowList, c.options.DenyList, event) {
 		return nil
 	}
 
 	var err error
 	unique := true
 	if c.dedupe != nil {
+		c.observe(event)
 		unique, err = c.dedupe.Index(event)
 	}
 	if unique {
@@ -329,7 +484,8 @@ func (c *ReportingClient) CreateIssue(event *output.ResultEvent) error {
 // CloseIssue closes an issue in the tracker
 func (c *ReportingClient) CloseIssue(event *output.ResultEvent) error {
 	for _, tracker := range c.trackers {
-		if tracker.ShouldFilter(event) {
+		// process tracker specific allow/deny list
+		if !tracker.ShouldFilter(event) {
 			continue
 		}
 		if err := tracker.CloseIssue(event); err != nil {
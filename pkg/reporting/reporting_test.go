@@ -0,0 +1,62 @@
+package reporting
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/projectdiscovery/nuclei/v3/pkg/model/types/stringslice"
+	"github.com/projectdiscovery/nuclei/v3/pkg/output"
+	"github.com/projectdiscovery/nuclei/v3/pkg/reporting/exporters/webhook"
+	"github.com/projectdiscovery/nuclei/v3/pkg/reporting/trackers/filters"
+)
+
+type recordingExporter struct {
+	exported []string
+}
+
+func (e *recordingExporter) Close() error { return nil }
+
+func (e *recordingExporter) Export(event *output.ResultEvent) error {
+	e.exported = append(e.exported, event.TemplateID)
+	return nil
+}
+
+func TestExporterFilters(t *testing.T) {
+	options := &Options{
+		ExporterFilters: map[string]*ExporterFilter{
+			"jsonl": {AllowList: &filters.Filter{TemplateIDs: stringslice.StringSlice{Value: []string{"cve-*"}}}},
+		},
+	}
+	client, err := New(options, "", true)
+	require.NoError(t, err)
+	reportingClient := client.(*ReportingClient)
+
+	filtered, unfiltered := &recordingExporter{}, &recordingExporter{}
+	reportingClient.addExporter("jsonl", filtered)
+	reportingClient.addExporter("markdown", unfiltered)
+
+	for _, templateID := range []string{"cve-2023-1", "tech-detect"} {
+		require.NoError(t, client.CreateIssue(&output.ResultEvent{TemplateID: templateID}))
+	}
+	require.Equal(t, []string{"cve-2023-1"}, filtered.exported)
+	require.Equal(t, []string{"cve-2023-1", "tech-detect"}, unfiltered.exported)
+
+	// webhooks are filtered by the filter of their configuration key
+	webhookOptions := &Options{
+		ExporterFilters: map[string]*ExporterFilter{
+			"webhooks": {AllowList: &filters.Filter{TemplateIDs: stringslice.StringSlice{Value: []string{"cve-*"}}}},
+		},
+		Webhooks: []*webhook.Options{{URL: "http://127.0.0.1/hook"}},
+	}
+	client, err = New(webhookOptions, "", true)
+	require.NoError(t, err)
+	exporters := client.(*ReportingClient).exporters
+	require.Len(t, exporters, 1)
+	require.IsType(t, &filteredExporter{}, exporters[0])
+
+	// invalid dsl expressions are reported when creating the client
+	options.DenyList = &filters.Filter{DSL: []string{"severity =="}}
+	_, err = New(options, "", true)
+	require.Error(t, err)
+}
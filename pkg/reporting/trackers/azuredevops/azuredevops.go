@@ -0,0 +1,343 @@
+package azuredevops
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/projectdiscovery/nuclei/v3/pkg/output"
+	"github.com/projectdiscovery/nuclei/v3/pkg/reporting/format"
+	"github.com/projectdiscovery/nuclei/v3/pkg/reporting/trackers/filters"
+	"github.com/projectdiscovery/retryablehttp-go"
+)
+
+const (
+	apiVersion        = "7.0"
+	commentAPIVersion = "7.0-preview.3"
+
+	defaultWorkItemType = "Issue"
+	defaultOpenState    = "To Do"
+	defaultClosedState  = "Done"
+)
+
+// Formatter formats work item descriptions as the html of Azure DevOps rich text fields
+type Formatter struct{}
+
+func (Formatter) MakeBold(text string) string {
+	return "<b>" + text + "</b>"
+}
+
+// CreateCodeBlock encodes line breaks of the content as character references
+// so that they are kept when the description line breaks are converted.
+func (f Formatter) CreateCodeBlock(title string, content string, _ string) string {
+	escaped := strings.ReplaceAll(html.EscapeString(content), "\n", "&#10;")
+	return fmt.Sprintf("\n%s\n<pre><code>%s</code></pre>\n", f.MakeBold(title), escaped)
+}
+
+func (Formatter) CreateTable(headers []string, rows [][]string) (string, error) {
+	builder := &strings.Builder{}
+	builder.WriteString("<table><tr>")
+	for _, header := range headers {
+		builder.WriteString("<th>" + header + "</th>")
+	}
+	builder.WriteString("</tr>")
+	for _, row := range rows {
+		builder.WriteString("<tr>")
+		for _, column := range row {
+			builder.WriteString("<td>" + column + "</td>")
+		}
+		builder.WriteString("</tr>")
+	}
+	builder.WriteString("</table>")
+	return builder.String(), nil
+}
+
+func (Formatter) CreateLink(title string, url string) string {
+	return fmt.Sprintf(`<a href="%s">%s</a>`, url, title)
+}
+
+func (Formatter) CreateHorizontalLine() string {
+	return "<hr>"
+}
+
+// Integration is a client for Azure DevOps Boards issue tracker integration
+type Integration struct {
+	Formatter
+	options    *Options
+	httpclient *retryablehttp.Client
+	baseURL    string
+}
+
+// Options contains the configuration options for Azure DevOps Boards issue tracker client
+type Options struct {
+	// OrganizationURL is the url of the organization (e.g. https://dev.azure.com/org)
+	OrganizationURL string `yaml:"organization-url" validate:"required"`
+	// Project is the name of the project for work items
+	Project string `yaml:"project" validate:"required"`
+	// Token is the personal access token with work items read & write scope
+	Token string `yaml:"token" validate:"required"`
+	// WorkItemType (optional) is the type of created work items (default Issue)
+	WorkItemType string `yaml:"work-item-type"`
+	// AssignedTo (optional) is the user work items are assigned to
+	AssignedTo string `yaml:"assigned-to"`
+	// AreaPath (optional) is the area path of created work items
+	AreaPath string `yaml:"area-path"`
+	// OpenState (optional) is the state reopened work items are set to (default To Do)
+	OpenState string `yaml:"open-state"`
+	// ClosedState (optional) is the state closed work items are set to (default Done)
+	ClosedState string `yaml:"closed-state"`
+	// IssueLabel (optional) is the tag of the created work items
+	IssueLabel string `yaml:"issue-label"`
+	// SeverityAsLabel (optional) sends the severity as a tag of the created
+	// work item.
+	SeverityAsLabel bool `yaml:"severity-as-label"`
+	// AllowList contains a list of allowed events for this tracker
+	AllowList *filters.Filter `yaml:"allow-list"`
+	// DenyList contains a list of denied events for this tracker
+	DenyList *filters.Filter `yaml:"deny-list"`
+	// DuplicateIssueCheck (optional) comments under existing finding work item
+	// instead of creating duplicates for subsequent runs.
+	DuplicateIssueCheck bool `yaml:"duplicate-issue-check"`
+
+	HttpClient *retryablehttp.Client `yaml:"-"`
+	OmitRaw    bool                  `yaml:"-"`
+}
+
+// New creates a new issue tracker integration client based on options.
+func New(options *Options) (*Integration, error) {
+	if options.OrganizationURL == "" || options.Project == "" || options.Token == "" {
+		return nil, errors.New("organization-url, project and token are required for azure devops")
+	}
+	if options.WorkItemType == "" {
+		options.WorkItemType = defaultWorkItemType
+	}
+	if options.OpenState == "" {
+		options.OpenState = defaultOpenState
+	}
+	if options.ClosedState == "" {
+		options.ClosedState = defaultClosedState
+	}
+
+	httpclient := options.HttpClient
+	if httpclient == nil {
+		httpclient = retryablehttp.NewClient(retryablehttp.DefaultOptionsSingle)
+	}
+	baseURL := strings.TrimSuffix(options.OrganizationURL, "/") + "/" + url.PathEscape(options.Project)
+	return &Integration{options: options, httpclient: httpclient, baseURL: baseURL}, nil
+}
+
+// CreateIssue creates a work item in the tracker
+func (i *Integration) CreateIssue(event *output.ResultEvent) (*filters.CreateIssueResponse, error) {
+	summary := format.Summary(event)
+	description := i.description(event)
+
+	var existing *workItem
+	if i.options.DuplicateIssueCheck {
+		var err error
+		if existing, err = i.findWorkItemByTitle(summary); err != nil {
+			return nil, err
+		}
+	}
+
+	if existing == nil {
+		operations := []patchOperation{
+			{Op: "add", Path: "/fields/System.Title", Value: summary},
+			{Op: "add", Path: "/fields/System.Description", Value: description},
+		}
+		if tags := i.tags(event); len(tags) > 0 {
+			operations = append(operations, patchOperation{Op: "add", Path: "/fields/System.Tags", Value: strings.Join(tags, "; ")})
+		}
+		if i.options.AssignedTo != "" {
+			operations = append(operations, patchOperation{Op: "add", Path: "/fields/System.AssignedTo", Value: i.options.AssignedTo})
+		}
+		if i.options.AreaPath != "" {
+			operations = append(operations, patchOperation{Op: "add", Path: "/fields/System.AreaPath", Value: i.options.AreaPath})
+		}
+		var created workItem
+		path := "/_apis/wit/workitems/$" + url.PathEscape(i.options.WorkItemType)
+		if err := i.do(http.MethodPost, path, nil, operations, &created); err != nil {
+			return nil, errors.Wrap(err, "could not create work item")
+		}
+		return i.issueResponse(created.ID), nil
+	}
+
+	if existing.Fields.State == i.options.ClosedState {
+		if err := i.setState(existing.ID, i.options.OpenState); err != nil {
+			return nil, fmt.Errorf("error reopening work item %d: %s", existing.ID, err)
+		}
+	}
+	path := fmt.Sprintf("/_apis/wit/workItems/%d/comments", existing.ID)
+	if err := i.do(http.MethodPost, path, url.Values{"api-version": {commentAPIVersion}}, map[string]string{"text": description}, nil); err != nil {
+		return nil, errors.Wrapf(err, "could not comment on work item %d", existing.ID)
+	}
+	return i.issueResponse(existing.ID), nil
+}
+
+// CloseIssue sets the work item of the event to the closed state
+func (i *Integration) CloseIssue(event *output.ResultEvent) error {
+	existing, err := i.findWorkItemByTitle(format.Summary(event))
+	if err != nil || existing == nil {
+		return err
+	}
+	if existing.Fields.State == i.options.ClosedState {
+		return nil
+	}
+	if err := i.setState(existing.ID, i.options.ClosedState); err != nil {
+		return fmt.Errorf("error closing work item %d: %s", existing.ID, err)
+	}
+	return nil
+}
+
+// Name returns the name of the tracker
+func (i *Integration) Name() string {
+	return "azure-devops"
+}
+
+// ShouldFilter determines if an issue should be logged to this tracker
+func (i *Integration) ShouldFilter(event *output.ResultEvent) bool {
+	if i.options.AllowList != nil && !i.options.AllowList.GetMatch(event) {
+		return false
+	}
+
+	if i.options.DenyList != nil && i.options.DenyList.GetMatch(event) {
+		return false
+	}
+
+	return true
+}
+
+// description returns the html description of an event. Line breaks are
+// converted as rich text fields do not preserve them.
+func (i *Integration) description(event *output.ResultEvent) string {
+	description := format.CreateReportDescription(event, i, i.options.OmitRaw)
+	return strings.ReplaceAll(description, "\n", "<br>")
+}
+
+// tags returns the tags of the work item of an event
+func (i *Integration) tags(event *output.ResultEvent) []string {
+	var tags []string
+	if i.options.SeverityAsLabel {
+		tags = append(tags, fmt.Sprintf("Severity: %s", event.Info.SeverityHolder.Severity.String()))
+	}
+	if i.options.IssueLabel != "" {
+		tags = append(tags, i.options.IssueLabel)
+	}
+	return tags
+}
+
+// findWorkItemByTitle returns the most recently changed work item of the project with the title
+func (i *Integration) findWorkItemByTitle(title string) (*workItem, error) {
+	query := fmt.Sprintf(
+		"SELECT [System.Id] FROM WorkItems WHERE [System.TeamProject] = @project AND [System.WorkItemType] = '%s' AND [System.Title] = '%s' ORDER BY [System.ChangedDate] DESC",
+		wiqlEscape(i.options.WorkItemType), wiqlEscape(title),
+	)
+	var result wiqlResult
+	if err := i.do(http.MethodPost, "/_apis/wit/wiql", url.Values{"$top": {"1"}}, map[string]string{"query": query}, &result); err != nil {
+		return nil, errors.Wrap(err, "could not search work items")
+	}
+	if len(result.WorkItems) == 0 {
+		return nil, nil
+	}
+
+	var item workItem
+	path := fmt.Sprintf("/_apis/wit/workitems/%d", result.WorkItems[0].ID)
+	if err := i.do(http.MethodGet, path, nil, nil, &item); err != nil {
+		return nil, errors.Wrapf(err, "could not get work item %d", result.WorkItems[0].ID)
+	}
+	return &item, nil
+}
+
+// setState updates the state of a work item
+func (i *Integration) setState(id int, state string) error {
+	operations := []patchOperation{{Op: "add", Path: "/fields/System.State", Value: state}}
+	return i.do(http.MethodPatch, fmt.Sprintf("/_apis/wit/workitems/%d", id), nil, operations, nil)
+}
+
+func (i *Integration) issueResponse(id int) *filters.CreateIssueResponse {
+	return &filters.CreateIssueResponse{
+		IssueID:  strconv.Itoa(id),
+		IssueURL: fmt.Sprintf("%s/_workitems/edit/%d", i.baseURL, id),
+	}
+}
+
+// do performs a request to the Azure DevOps api and decodes the json response.
+// The default api version is used if the query does not contain one.
+func (i *Integration) do(method, path string, query url.Values, body, result interface{}) error {
+	if query == nil {
+		query = url.Values{}
+	}
+	if query.Get("api-version") == "" {
+		query.Set("api-version", apiVersion)
+	}
+	endpoint := i.baseURL + path + "?" + query.Encode()
+	var data []byte
+	if body != nil {
+		var err error
+		if data, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+	req, err := retryablehttp.NewRequest(method, endpoint, data)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(":"+i.options.Token)))
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		// work item updates are json patch documents
+		if _, ok := body.([]patchOperation); ok {
+			req.Header.Set("Content-Type", "application/json-patch+json")
+		} else {
+			req.Header.Set("Content-Type", "application/json")
+		}
+	}
+
+	resp, err := i.httpclient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(bytes.TrimSpace(data)))
+	}
+	if result == nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+// wiqlEscape escapes a string literal of a WIQL query
+func wiqlEscape(value string) string {
+	return strings.ReplaceAll(value, "'", "''")
+}
+
+type patchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value"`
+}
+
+type wiqlResult struct {
+	WorkItems []struct {
+		ID int `json:"id"`
+	} `json:"workItems"`
+}
+
+type workItem struct {
+	ID     int `json:"id"`
+	Fields struct {
+		Title string `json:"System.Title"`
+		State string `json:"System.State"`
+	} `json:"fields"`
+}
@@ -0,0 +1,140 @@
+package azuredevops
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/projectdiscovery/nuclei/v3/pkg/model"
+	"github.com/projectdiscovery/nuclei/v3/pkg/model/types/severity"
+	"github.com/projectdiscovery/nuclei/v3/pkg/output"
+	"github.com/stretchr/testify/require"
+)
+
+var wiqlTitle = regexp.MustCompile(`\[System.Title\] = '((?:[^']|'')*)'`)
+
+// fakeBoards is a minimal stand-in of the Azure DevOps work item tracking api
+type fakeBoards struct {
+	mu       sync.Mutex
+	items    []map[string]interface{}
+	comments map[int][]string
+}
+
+func (f *fakeBoards) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.Header.Get("Authorization") != "Basic "+base64.StdEncoding.EncodeToString([]byte(":secret")) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	writeJSON := func(value interface{}) {
+		_ = json.NewEncoder(w).Encode(value)
+	}
+	applyPatch := func(fields map[string]interface{}) {
+		var operations []patchOperation
+		_ = json.NewDecoder(r.Body).Decode(&operations)
+		for _, operation := range operations {
+			fields[strings.TrimPrefix(operation.Path, "/fields/")] = operation.Value
+		}
+	}
+	item := func(id int) map[string]interface{} {
+		return map[string]interface{}{"id": id, "fields": f.items[id-1]}
+	}
+
+	const prefix = "/org/web%20app/_apis/wit/"
+	path := strings.TrimPrefix(r.URL.EscapedPath(), prefix)
+	switch {
+	case path == "workitems/$Issue" && r.Method == http.MethodPost:
+		if r.Header.Get("Content-Type") != "application/json-patch+json" {
+			w.WriteHeader(http.StatusUnsupportedMediaType)
+			return
+		}
+		fields := map[string]interface{}{"System.State": "To Do"}
+		applyPatch(fields)
+		f.items = append(f.items, fields)
+		writeJSON(item(len(f.items)))
+	case path == "wiql" && r.Method == http.MethodPost:
+		var body struct {
+			Query string `json:"query"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		title := strings.ReplaceAll(wiqlTitle.FindStringSubmatch(body.Query)[1], "''", "'")
+		workItems := []map[string]interface{}{}
+		for id := len(f.items); id > 0; id-- {
+			if f.items[id-1]["System.Title"] == title {
+				workItems = append(workItems, map[string]interface{}{"id": id})
+			}
+		}
+		writeJSON(map[string]interface{}{"workItems": workItems})
+	case strings.HasPrefix(path, "workitems/"):
+		id, _ := strconv.Atoi(strings.TrimPrefix(path, "workitems/"))
+		if r.Method == http.MethodPatch {
+			applyPatch(f.items[id-1])
+		}
+		writeJSON(item(id))
+	case strings.HasPrefix(path, "workItems/") && strings.HasSuffix(path, "/comments"):
+		id, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(path, "workItems/"), "/comments"))
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		f.comments[id] = append(f.comments[id], body["text"])
+		writeJSON(map[string]interface{}{"id": len(f.comments[id])})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestIntegration(t *testing.T) {
+	boards := &fakeBoards{comments: make(map[int][]string)}
+	server := httptest.NewServer(boards)
+	defer server.Close()
+
+	integration, err := New(&Options{
+		OrganizationURL:     server.URL + "/org/",
+		Project:             "web app",
+		Token:               "secret",
+		IssueLabel:          "nuclei",
+		SeverityAsLabel:     true,
+		DuplicateIssueCheck: true,
+	})
+	require.NoError(t, err)
+
+	event := &output.ResultEvent{
+		TemplateID: "example",
+		Host:       "https://example.com",
+		Matched:    "https://example.com/login",
+		Request:    "GET /login HTTP/1.1\nHost: example.com",
+		Info: model.Info{
+			Name:           "Example's <finding>",
+			SeverityHolder: severity.Holder{Severity: severity.Medium},
+		},
+	}
+
+	response, err := integration.CreateIssue(event)
+	require.NoError(t, err)
+	require.Equal(t, "1", response.IssueID)
+	require.Equal(t, server.URL+"/org/web%20app/_workitems/edit/1", response.IssueURL)
+
+	fields := boards.items[0]
+	require.Equal(t, "Severity: medium; nuclei", fields["System.Tags"])
+	description := fields["System.Description"].(string)
+	require.NotContains(t, description, "\n")
+	require.Contains(t, description, "<pre><code>GET /login HTTP/1.1&#10;Host: example.com</code></pre>")
+
+	require.NoError(t, integration.CloseIssue(event))
+	require.Equal(t, "Done", boards.items[0]["System.State"])
+
+	// duplicate work items are reopened and commented instead of created
+	response, err = integration.CreateIssue(event)
+	require.NoError(t, err)
+	require.Equal(t, "1", response.IssueID)
+	require.Len(t, boards.items, 1)
+	require.Equal(t, "To Do", boards.items[0]["System.State"])
+	require.Len(t, boards.comments[1], 1)
+}
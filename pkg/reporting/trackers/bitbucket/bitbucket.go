@@ -0,0 +1,290 @@
+package bitbucket
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/projectdiscovery/nuclei/v3/pkg/model/types/severity"
+	"github.com/projectdiscovery/nuclei/v3/pkg/output"
+	"github.com/projectdiscovery/nuclei/v3/pkg/reporting/exporters/markdown/util"
+	"github.com/projectdiscovery/nuclei/v3/pkg/reporting/format"
+	"github.com/projectdiscovery/nuclei/v3/pkg/reporting/trackers/filters"
+	"github.com/projectdiscovery/retryablehttp-go"
+)
+
+const (
+	defaultBaseURL = "https://api.bitbucket.org/2.0"
+	defaultKind    = "bug"
+
+	stateOpen     = "open"
+	stateResolved = "resolved"
+)
+
+// closedStates are the issue states reopened by duplicate issue checks
+var closedStates = map[string]struct{}{
+	"resolved":  {},
+	"closed":    {},
+	"invalid":   {},
+	"duplicate": {},
+	"wontfix":   {},
+}
+
+// Integration is a client for Bitbucket Cloud issue tracker integration
+type Integration struct {
+	options    *Options
+	httpclient *retryablehttp.Client
+	baseURL    string
+}
+
+// Options contains the configuration options for Bitbucket Cloud issue tracker client
+type Options struct {
+	// BaseURL (optional) is the url of the Bitbucket api (default https://api.bitbucket.org/2.0)
+	BaseURL string `yaml:"base-url" validate:"omitempty,url"`
+	// Workspace is the workspace of the repository for issues.
+	Workspace string `yaml:"workspace" validate:"required"`
+	// Repository is the slug of the repository for issues.
+	Repository string `yaml:"repository" validate:"required"`
+	// Username (optional) is the username of the app password. Token is
+	// used as a bearer access token if not set.
+	Username string `yaml:"username"`
+	// Token is the app password or access token for Bitbucket account.
+	Token string `yaml:"token" validate:"required"`
+	// Kind (optional) is the kind of created issues (default bug)
+	Kind string `yaml:"kind"`
+	// IssueLabel (optional) is the component of the created issues
+	IssueLabel string `yaml:"issue-label"`
+	// SeverityAsLabel (optional) sends the severity as the priority of the
+	// created issue.
+	SeverityAsLabel bool `yaml:"severity-as-label"`
+	// AllowList contains a list of allowed events for this tracker
+	AllowList *filters.Filter `yaml:"allow-list"`
+	// DenyList contains a list of denied events for this tracker
+	DenyList *filters.Filter `yaml:"deny-list"`
+	// DuplicateIssueCheck (optional) comments under existing finding issue
+	// instead of creating duplicates for subsequent runs.
+	DuplicateIssueCheck bool `yaml:"duplicate-issue-check"`
+
+	HttpClient *retryablehttp.Client `yaml:"-"`
+	OmitRaw    bool                  `yaml:"-"`
+}
+
+// New creates a new issue tracker integration client based on options.
+func New(options *Options) (*Integration, error) {
+	if options.Workspace == "" || options.Repository == "" || options.Token == "" {
+		return nil, errors.New("workspace, repository and token are required for bitbucket")
+	}
+	if options.BaseURL == "" {
+		options.BaseURL = defaultBaseURL
+	}
+	if options.Kind == "" {
+		options.Kind = defaultKind
+	}
+
+	httpclient := options.HttpClient
+	if httpclient == nil {
+		httpclient = retryablehttp.NewClient(retryablehttp.DefaultOptionsSingle)
+	}
+	baseURL := fmt.Sprintf("%s/repositories/%s/%s", strings.TrimSuffix(options.BaseURL, "/"), url.PathEscape(options.Workspace), url.PathEscape(options.Repository))
+	return &Integration{options: options, httpclient: httpclient, baseURL: baseURL}, nil
+}
+
+// CreateIssue creates an issue in the tracker
+func (i *Integration) CreateIssue(event *output.ResultEvent) (*filters.CreateIssueResponse, error) {
+	summary := format.Summary(event)
+	description := format.CreateReportDescription(event, util.MarkdownFormatter{}, i.options.OmitRaw)
+
+	var existing *issue
+	if i.options.DuplicateIssueCheck {
+		var err error
+		if existing, err = i.findIssueByTitle(summary); err != nil {
+			return nil, err
+		}
+	}
+
+	if existing == nil {
+		req := &issueRequest{
+			Title:   summary,
+			Content: &content{Raw: description, Markup: "markdown"},
+			Kind:    i.options.Kind,
+		}
+		if i.options.SeverityAsLabel {
+			req.Priority = priority(event.Info.SeverityHolder.Severity)
+		}
+		if i.options.IssueLabel != "" {
+			req.Component = &named{Name: i.options.IssueLabel}
+		}
+		var created issue
+		if err := i.do(http.MethodPost, "/issues", nil, req, &created); err != nil {
+			return nil, errors.Wrap(err, "could not create issue")
+		}
+		return issueResponse(&created), nil
+	}
+
+	if _, ok := closedStates[existing.State]; ok {
+		if err := i.do(http.MethodPut, fmt.Sprintf("/issues/%d", existing.ID), nil, &issueRequest{State: stateOpen}, nil); err != nil {
+			return nil, fmt.Errorf("error reopening issue %d: %s", existing.ID, err)
+		}
+	}
+	comment := map[string]interface{}{"content": &content{Raw: description, Markup: "markdown"}}
+	if err := i.do(http.MethodPost, fmt.Sprintf("/issues/%d/comments", existing.ID), nil, comment, nil); err != nil {
+		return nil, errors.Wrapf(err, "could not comment on issue %d", existing.ID)
+	}
+	return issueResponse(existing), nil
+}
+
+// CloseIssue resolves the issue of the event
+func (i *Integration) CloseIssue(event *output.ResultEvent) error {
+	existing, err := i.findIssueByTitle(format.Summary(event))
+	if err != nil || existing == nil {
+		return err
+	}
+	if _, ok := closedStates[existing.State]; ok {
+		return nil
+	}
+	if err := i.do(http.MethodPut, fmt.Sprintf("/issues/%d", existing.ID), nil, &issueRequest{State: stateResolved}, nil); err != nil {
+		return fmt.Errorf("error closing issue %d: %s", existing.ID, err)
+	}
+	return nil
+}
+
+// Name returns the name of the tracker
+func (i *Integration) Name() string {
+	return "bitbucket"
+}
+
+// ShouldFilter determines if an issue should be logged to this tracker
+func (i *Integration) ShouldFilter(event *output.ResultEvent) bool {
+	if i.options.AllowList != nil && !i.options.AllowList.GetMatch(event) {
+		return false
+	}
+
+	if i.options.DenyList != nil && i.options.DenyList.GetMatch(event) {
+		return false
+	}
+
+	return true
+}
+
+// findIssueByTitle returns the most recently updated issue of the repository with the title
+func (i *Integration) findIssueByTitle(title string) (*issue, error) {
+	escaped := strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(title)
+	query := url.Values{
+		"q":       {fmt.Sprintf(`title = "%s"`, escaped)},
+		"sort":    {"-updated_on"},
+		"pagelen": {"50"},
+	}
+	var issues issueList
+	if err := i.do(http.MethodGet, "/issues", query, nil, &issues); err != nil {
+		return nil, fmt.Errorf("error listing issues for %s/%s: %w", i.options.Workspace, i.options.Repository, err)
+	}
+	for _, item := range issues.Values {
+		if item.Title == title {
+			return &item, nil
+		}
+	}
+	return nil, nil
+}
+
+// priority returns the Bitbucket issue priority of a severity
+func priority(value severity.Severity) string {
+	switch value {
+	case severity.Critical:
+		return "critical"
+	case severity.High:
+		return "major"
+	case severity.Medium:
+		return "minor"
+	}
+	return "trivial"
+}
+
+func issueResponse(item *issue) *filters.CreateIssueResponse {
+	return &filters.CreateIssueResponse{
+		IssueID:  strconv.Itoa(item.ID),
+		IssueURL: item.Links.HTML.Href,
+	}
+}
+
+// do performs a request to the Bitbucket api and decodes the json response
+func (i *Integration) do(method, path string, query url.Values, body, result interface{}) error {
+	endpoint := i.baseURL + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+	var data []byte
+	if body != nil {
+		var err error
+		if data, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+	req, err := retryablehttp.NewRequest(method, endpoint, data)
+	if err != nil {
+		return err
+	}
+	if i.options.Username != "" {
+		req.SetBasicAuth(i.options.Username, i.options.Token)
+	} else {
+		req.Header.Set("Authorization", "Bearer "+i.options.Token)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := i.httpclient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(bytes.TrimSpace(data)))
+	}
+	if result == nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+type content struct {
+	Raw    string `json:"raw"`
+	Markup string `json:"markup,omitempty"`
+}
+
+type named struct {
+	Name string `json:"name"`
+}
+
+type issueRequest struct {
+	Title     string   `json:"title,omitempty"`
+	Content   *content `json:"content,omitempty"`
+	Kind      string   `json:"kind,omitempty"`
+	Priority  string   `json:"priority,omitempty"`
+	State     string   `json:"state,omitempty"`
+	Component *named   `json:"component,omitempty"`
+}
+
+type issue struct {
+	ID    int    `json:"id"`
+	Title string `json:"title"`
+	State string `json:"state"`
+	Links struct {
+		HTML struct {
+			Href string `json:"href"`
+		} `json:"html"`
+	} `json:"links"`
+}
+
+type issueList struct {
+	Values []issue `json:"values"`
+}
@@ -0,0 +1,122 @@
+package bitbucket
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/projectdiscovery/nuclei/v3/pkg/model"
+	"github.com/projectdiscovery/nuclei/v3/pkg/model/types/severity"
+	"github.com/projectdiscovery/nuclei/v3/pkg/output"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBitbucket is a minimal stand-in of the Bitbucket Cloud issues api
+type fakeBitbucket struct {
+	mu       sync.Mutex
+	issues   []map[string]interface{}
+	comments map[int]int
+}
+
+func (f *fakeBitbucket) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if username, password, ok := r.BasicAuth(); !ok || username != "user" || password != "secret" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	var body map[string]interface{}
+	_ = json.NewDecoder(r.Body).Decode(&body)
+	writeJSON := func(value interface{}) {
+		_ = json.NewEncoder(w).Encode(value)
+	}
+
+	const prefix = "/repositories/ws/repo/issues"
+	path := strings.TrimPrefix(r.URL.Path, prefix)
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	switch {
+	case path == "" && r.Method == http.MethodGet:
+		values := []map[string]interface{}{}
+		for _, issue := range f.issues {
+			if r.URL.Query().Get("q") == fmt.Sprintf(`title = "%s"`, issue["title"]) {
+				values = append(values, issue)
+			}
+		}
+		writeJSON(map[string]interface{}{"values": values})
+	case path == "" && r.Method == http.MethodPost:
+		id := len(f.issues) + 1
+		body["id"] = id
+		body["state"] = "new"
+		body["links"] = map[string]interface{}{"html": map[string]interface{}{"href": "https://bitbucket.org/ws/repo/issues/" + strconv.Itoa(id)}}
+		f.issues = append(f.issues, body)
+		writeJSON(body)
+	case len(parts) == 1 && r.Method == http.MethodPut:
+		id, _ := strconv.Atoi(parts[0])
+		f.issues[id-1]["state"] = body["state"]
+		writeJSON(f.issues[id-1])
+	case len(parts) == 2 && parts[1] == "comments" && r.Method == http.MethodPost:
+		id, _ := strconv.Atoi(parts[0])
+		f.comments[id]++
+		writeJSON(map[string]interface{}{"id": f.comments[id]})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestIntegration(t *testing.T) {
+	bitbucket := &fakeBitbucket{comments: make(map[int]int)}
+	server := httptest.NewServer(bitbucket)
+	defer server.Close()
+
+	integration, err := New(&Options{
+		BaseURL:             server.URL,
+		Workspace:           "ws",
+		Repository:          "repo",
+		Username:            "user",
+		Token:               "secret",
+		IssueLabel:          "security",
+		SeverityAsLabel:     true,
+		DuplicateIssueCheck: true,
+		OmitRaw:             true,
+	})
+	require.NoError(t, err)
+
+	event := &output.ResultEvent{
+		TemplateID: "example",
+		Host:       "https://example.com",
+		Matched:    "https://example.com/login",
+		Request:    "GET /login HTTP/1.1",
+		Info: model.Info{
+			Name:           "Example",
+			SeverityHolder: severity.Holder{Severity: severity.High},
+		},
+	}
+
+	response, err := integration.CreateIssue(event)
+	require.NoError(t, err)
+	require.Equal(t, "1", response.IssueID)
+	require.Equal(t, "https://bitbucket.org/ws/repo/issues/1", response.IssueURL)
+
+	issue := bitbucket.issues[0]
+	require.Equal(t, "bug", issue["kind"])
+	require.Equal(t, "major", issue["priority"])
+	require.Equal(t, map[string]interface{}{"name": "security"}, issue["component"])
+	require.NotContains(t, issue["content"].(map[string]interface{})["raw"], "GET /login", "raw request was not omitted")
+
+	require.NoError(t, integration.CloseIssue(event))
+	require.Equal(t, "resolved", bitbucket.issues[0]["state"])
+
+	// duplicate issues are reopened and commented instead of created
+	response, err = integration.CreateIssue(event)
+	require.NoError(t, err)
+	require.Equal(t, "1", response.IssueID)
+	require.Len(t, bitbucket.issues, 1)
+	require.Equal(t, "open", bitbucket.issues[0]["state"])
+	require.Equal(t, 1, bitbucket.comments[1])
+}
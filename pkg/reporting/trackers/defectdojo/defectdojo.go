@@ -0,0 +1,388 @@
+package defectdojo
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/projectdiscovery/nuclei/v3/pkg/model/types/severity"
+	"github.com/projectdiscovery/nuclei/v3/pkg/output"
+	"github.com/projectdiscovery/nuclei/v3/pkg/reporting/dedupe"
+	"github.com/projectdiscovery/nuclei/v3/pkg/reporting/exporters/markdown/util"
+	"github.com/projectdiscovery/nuclei/v3/pkg/reporting/format"
+	"github.com/projectdiscovery/nuclei/v3/pkg/reporting/trackers/filters"
+	"github.com/projectdiscovery/retryablehttp-go"
+)
+
+// defaultTestType is the DefectDojo test type of nuclei scans
+const defaultTestType = "Nuclei Scan"
+
+// Integration is a client for DefectDojo issue tracker integration
+type Integration struct {
+	options    *Options
+	httpclient *retryablehttp.Client
+
+	productID int
+
+	// engagement and test of the scan are created once on the first finding
+	setupOnce    sync.Once
+	setupErr     error
+	testTypeID   int
+	engagementID int
+	testID       int
+}
+
+// Options contains the configuration options for DefectDojo issue tracker client
+type Options struct {
+	// URL is the url of the DefectDojo instance
+	URL string `yaml:"url" validate:"required"`
+	// APIKey is the API v2 key of the DefectDojo user
+	APIKey string `yaml:"api-key" validate:"required"`
+	// ProductID is the id of the product findings are imported to
+	ProductID int `yaml:"product-id"`
+	// ProductName is the name of the product findings are imported to (if product-id is not set)
+	ProductName string `yaml:"product-name"`
+	// EngagementID is an existing engagement of the product, a new engagement is created per scan if not set
+	EngagementID int `yaml:"engagement-id"`
+	// EngagementName is the name of engagements created per scan
+	EngagementName string `yaml:"engagement-name"`
+	// TestType is the name of the test type of scans (default Nuclei Scan)
+	TestType string `yaml:"test-type"`
+
+	// AllowList contains a list of allowed events for this tracker
+	AllowList *filters.Filter `yaml:"allow-list"`
+	// DenyList contains a list of denied events for this tracker
+	DenyList *filters.Filter `yaml:"deny-list"`
+
+	HttpClient *retryablehttp.Client `yaml:"-"`
+	OmitRaw    bool                  `yaml:"-"`
+}
+
+// New creates a new issue tracker integration client based on options.
+func New(options *Options) (*Integration, error) {
+	if options.URL == "" || options.APIKey == "" {
+		return nil, errors.New("url and api-key are required for defectdojo")
+	}
+	if options.ProductID == 0 && options.ProductName == "" {
+		return nil, errors.New("product-id or product-name is required for defectdojo")
+	}
+	if options.TestType == "" {
+		options.TestType = defaultTestType
+	}
+	options.URL = strings.TrimSuffix(options.URL, "/")
+
+	httpclient := options.HttpClient
+	if httpclient == nil {
+		httpclient = retryablehttp.NewClient(retryablehttp.DefaultOptionsSingle)
+	}
+	integration := &Integration{options: options, httpclient: httpclient}
+	if err := integration.resolveProduct(); err != nil {
+		return nil, err
+	}
+	return integration, nil
+}
+
+// Name returns the name of the tracker
+func (i *Integration) Name() string {
+	return "defectdojo"
+}
+
+// CreateIssue imports a finding into the test of the scan. Findings with the
+// unique id of an active finding of the product are not imported again.
+func (i *Integration) CreateIssue(event *output.ResultEvent) (*filters.CreateIssueResponse, error) {
+	if err := i.setup(); err != nil {
+		return nil, err
+	}
+
+	finding := i.newFinding(event)
+	existing, err := i.findActiveFinding(finding.UniqueIDFromTool)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return i.issueResponse(existing.ID), nil
+	}
+
+	var created dojoFinding
+	if err := i.do(http.MethodPost, "/api/v2/findings/", nil, finding, &created); err != nil {
+		return nil, errors.Wrap(err, "could not create finding")
+	}
+	return i.issueResponse(created.ID), nil
+}
+
+// CloseIssue marks the active finding of the event as mitigated
+func (i *Integration) CloseIssue(event *output.ResultEvent) error {
+	existing, err := i.findActiveFinding(uniqueID(event))
+	if err != nil || existing == nil {
+		return err
+	}
+	update := map[string]interface{}{
+		"active":       false,
+		"is_mitigated": true,
+		"mitigated":    time.Now().UTC().Format(time.RFC3339),
+	}
+	if err := i.do(http.MethodPatch, fmt.Sprintf("/api/v2/findings/%d/", existing.ID), nil, update, nil); err != nil {
+		return errors.Wrapf(err, "could not mitigate finding %d", existing.ID)
+	}
+	return nil
+}
+
+// ShouldFilter determines if an issue should be logged to this tracker
+func (i *Integration) ShouldFilter(event *output.ResultEvent) bool {
+	if i.options.AllowList != nil && !i.options.AllowList.GetMatch(event) {
+		return false
+	}
+
+	if i.options.DenyList != nil && i.options.DenyList.GetMatch(event) {
+		return false
+	}
+
+	return true
+}
+
+// setup resolves the test type and creates the engagement and test of the scan
+func (i *Integration) setup() error {
+	i.setupOnce.Do(func() {
+		var testTypes dojoList[dojoTestType]
+		if err := i.do(http.MethodGet, "/api/v2/test_types/", url.Values{"name": {i.options.TestType}}, nil, &testTypes); err != nil {
+			i.setupErr = errors.Wrap(err, "could not get test type")
+			return
+		}
+		if len(testTypes.Results) == 0 {
+			i.setupErr = fmt.Errorf("test type %q not found", i.options.TestType)
+			return
+		}
+		i.testTypeID = testTypes.Results[0].ID
+
+		now := time.Now()
+		i.engagementID = i.options.EngagementID
+		if i.engagementID == 0 {
+			name := i.options.EngagementName
+			if name == "" {
+				name = "Nuclei Scan " + now.Format("2006-01-02 15:04:05")
+			}
+			engagement := map[string]interface{}{
+				"name":            name,
+				"product":         i.productID,
+				"target_start":    now.Format("2006-01-02"),
+				"target_end":      now.Format("2006-01-02"),
+				"engagement_type": "CI/CD",
+				"status":          "In Progress",
+			}
+			var created dojoObject
+			if err := i.do(http.MethodPost, "/api/v2/engagements/", nil, engagement, &created); err != nil {
+				i.setupErr = errors.Wrap(err, "could not create engagement")
+				return
+			}
+			i.engagementID = created.ID
+		}
+
+		test := map[string]interface{}{
+			"engagement":   i.engagementID,
+			"test_type":    i.testTypeID,
+			"title":        "Nuclei " + now.Format("2006-01-02 15:04:05"),
+			"target_start": now.UTC().Format(time.RFC3339),
+			"target_end":   now.UTC().Format(time.RFC3339),
+		}
+		var created dojoObject
+		if err := i.do(http.MethodPost, "/api/v2/tests/", nil, test, &created); err != nil {
+			i.setupErr = errors.Wrap(err, "could not create test")
+			return
+		}
+		i.testID = created.ID
+	})
+	return i.setupErr
+}
+
+// resolveProduct resolves the id of the product of findings
+func (i *Integration) resolveProduct() error {
+	if i.options.ProductID != 0 {
+		i.productID = i.options.ProductID
+		return nil
+	}
+	var products dojoList[dojoObject]
+	if err := i.do(http.MethodGet, "/api/v2/products/", url.Values{"name": {i.options.ProductName}}, nil, &products); err != nil {
+		return errors.Wrap(err, "could not get product")
+	}
+	if len(products.Results) == 0 {
+		return fmt.Errorf("product %q not found", i.options.ProductName)
+	}
+	i.productID = products.Results[0].ID
+	return nil
+}
+
+// findActiveFinding returns the active finding of the product with the unique id
+func (i *Integration) findActiveFinding(id string) (*dojoFinding, error) {
+	query := url.Values{
+		"unique_id_from_tool":       {id},
+		"test__engagement__product": {strconv.Itoa(i.productID)},
+		"active":                    {"true"},
+		"limit":                     {"1"},
+	}
+	var findings dojoList[dojoFinding]
+	if err := i.do(http.MethodGet, "/api/v2/findings/", query, nil, &findings); err != nil {
+		return nil, errors.Wrap(err, "could not search findings")
+	}
+	if len(findings.Results) == 0 {
+		return nil, nil
+	}
+	return &findings.Results[0], nil
+}
+
+// newFinding creates a DefectDojo finding from an event
+func (i *Integration) newFinding(event *output.ResultEvent) *dojoFinding {
+	name, numerical := dojoSeverity(event.Info.SeverityHolder.Severity)
+	finding := &dojoFinding{
+		Title:             format.Summary(event),
+		Description:       format.CreateReportDescription(event, util.MarkdownFormatter{}, i.options.OmitRaw),
+		Severity:          name,
+		NumericalSeverity: numerical,
+		Mitigation:        event.Info.Remediation,
+		Impact:            event.Info.Impact,
+		Test:              i.testID,
+		FoundBy:           []int{i.testTypeID},
+		Active:            true,
+		DynamicFinding:    true,
+		Date:              time.Now().Format("2006-01-02"),
+		VulnIDFromTool:    event.TemplateID,
+		UniqueIDFromTool:  uniqueID(event),
+		Tags:              event.Info.Tags.ToSlice(),
+	}
+	if !event.Timestamp.IsZero() {
+		finding.Date = event.Timestamp.Format("2006-01-02")
+	}
+	if event.Info.Reference != nil {
+		finding.References = strings.Join(event.Info.Reference.ToSlice(), "\n")
+	}
+	if classification := event.Info.Classification; classification != nil {
+		for _, cwe := range classification.CWEID.ToSlice() {
+			if id, err := strconv.Atoi(strings.TrimPrefix(strings.ToUpper(cwe), "CWE-")); err == nil {
+				finding.CWE = id
+				break
+			}
+		}
+		if strings.HasPrefix(classification.CVSSMetrics, "CVSS:3") {
+			finding.CVSSv3 = classification.CVSSMetrics
+		}
+		if classification.CVSSScore > 0 {
+			score := classification.CVSSScore
+			finding.CVSSv3Score = &score
+		}
+	}
+	return finding
+}
+
+// uniqueID returns the unique id of the finding of an event. It is
+// the dedupe hash so findings are identified like by other trackers.
+func uniqueID(event *output.ResultEvent) string {
+	return hex.EncodeToString(dedupe.Key(event))
+}
+
+// dojoSeverity returns the DefectDojo severity and numerical severity
+func dojoSeverity(value severity.Severity) (string, string) {
+	switch value {
+	case severity.Critical:
+		return "Critical", "S0"
+	case severity.High:
+		return "High", "S1"
+	case severity.Medium:
+		return "Medium", "S2"
+	case severity.Low:
+		return "Low", "S3"
+	}
+	return "Info", "S4"
+}
+
+func (i *Integration) issueResponse(id int) *filters.CreateIssueResponse {
+	return &filters.CreateIssueResponse{
+		IssueID:  strconv.Itoa(id),
+		IssueURL: fmt.Sprintf("%s/finding/%d", i.options.URL, id),
+	}
+}
+
+// do performs a request to the DefectDojo api and decodes the json response
+func (i *Integration) do(method, path string, query url.Values, body, result interface{}) error {
+	endpoint := i.options.URL + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+	var data []byte
+	if body != nil {
+		var err error
+		if data, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+	req, err := retryablehttp.NewRequest(method, endpoint, data)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Token "+i.options.APIKey)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := i.httpclient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(bytes.TrimSpace(data)))
+	}
+	if result == nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+// dojoList is a paginated list response of the DefectDojo api
+type dojoList[T any] struct {
+	Count   int `json:"count"`
+	Results []T `json:"results"`
+}
+
+type dojoObject struct {
+	ID int `json:"id"`
+}
+
+type dojoTestType struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type dojoFinding struct {
+	ID                int      `json:"id,omitempty"`
+	Title             string   `json:"title"`
+	Description       string   `json:"description"`
+	Severity          string   `json:"severity"`
+	NumericalSeverity string   `json:"numerical_severity"`
+	Mitigation        string   `json:"mitigation,omitempty"`
+	Impact            string   `json:"impact,omitempty"`
+	References        string   `json:"references,omitempty"`
+	CWE               int      `json:"cwe"`
+	CVSSv3            string   `json:"cvssv3,omitempty"`
+	CVSSv3Score       *float64 `json:"cvssv3_score,omitempty"`
+	Test              int      `json:"test"`
+	FoundBy           []int    `json:"found_by"`
+	Active            bool     `json:"active"`
+	Verified          bool     `json:"verified"`
+	DynamicFinding    bool     `json:"dynamic_finding"`
+	Date              string   `json:"date"`
+	VulnIDFromTool    string   `json:"vuln_id_from_tool,omitempty"`
+	UniqueIDFromTool  string   `json:"unique_id_from_tool,omitempty"`
+	Tags              []string `json:"tags,omitempty"`
+}
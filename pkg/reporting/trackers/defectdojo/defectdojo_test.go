@@ -0,0 +1,135 @@
+package defectdojo
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/projectdiscovery/nuclei/v3/pkg/model"
+	"github.com/projectdiscovery/nuclei/v3/pkg/model/types/severity"
+	"github.com/projectdiscovery/nuclei/v3/pkg/model/types/stringslice"
+	"github.com/projectdiscovery/nuclei/v3/pkg/output"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDojo is a minimal stand-in of the DefectDojo api v2
+type fakeDojo struct {
+	mu          sync.Mutex
+	products    int
+	engagements int
+	tests       int
+	findings    []map[string]interface{}
+}
+
+func (f *fakeDojo) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.Header.Get("Authorization") != "Token secret" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	var body map[string]interface{}
+	if r.Body != nil {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+	}
+	writeJSON := func(value interface{}) {
+		_ = json.NewEncoder(w).Encode(value)
+	}
+
+	switch {
+	case r.URL.Path == "/api/v2/products/" && r.Method == http.MethodGet:
+		f.products++
+		writeJSON(map[string]interface{}{"count": 1, "results": []map[string]interface{}{{"id": 3}}})
+	case r.URL.Path == "/api/v2/test_types/" && r.Method == http.MethodGet:
+		writeJSON(map[string]interface{}{"count": 1, "results": []map[string]interface{}{{"id": 7, "name": r.URL.Query().Get("name")}}})
+	case r.URL.Path == "/api/v2/engagements/" && r.Method == http.MethodPost:
+		f.engagements++
+		writeJSON(map[string]interface{}{"id": 10 + f.engagements})
+	case r.URL.Path == "/api/v2/tests/" && r.Method == http.MethodPost:
+		f.tests++
+		writeJSON(map[string]interface{}{"id": 20 + f.tests})
+	case r.URL.Path == "/api/v2/findings/" && r.Method == http.MethodGet:
+		results := []map[string]interface{}{}
+		for _, finding := range f.findings {
+			if finding["unique_id_from_tool"] == r.URL.Query().Get("unique_id_from_tool") && finding["active"] == true {
+				results = append(results, finding)
+			}
+		}
+		writeJSON(map[string]interface{}{"count": len(results), "results": results})
+	case r.URL.Path == "/api/v2/findings/" && r.Method == http.MethodPost:
+		body["id"] = float64(len(f.findings) + 1)
+		f.findings = append(f.findings, body)
+		writeJSON(body)
+	case strings.HasPrefix(r.URL.Path, "/api/v2/findings/") && r.Method == http.MethodPatch:
+		id, _ := strconv.Atoi(strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/v2/findings/"), "/"))
+		for key, value := range body {
+			f.findings[id-1][key] = value
+		}
+		writeJSON(f.findings[id-1])
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestIntegration(t *testing.T) {
+	dojo := &fakeDojo{}
+	server := httptest.NewServer(dojo)
+	defer server.Close()
+
+	integration, err := New(&Options{URL: server.URL + "/", APIKey: "secret", ProductName: "web"})
+	require.NoError(t, err)
+	require.Equal(t, 1, dojo.products)
+	require.Equal(t, 3, integration.productID)
+
+	event := &output.ResultEvent{
+		TemplateID: "CVE-2021-1234",
+		Host:       "https://example.com",
+		Matched:    "https://example.com/login",
+		Info: model.Info{
+			Name:           "Example RCE",
+			SeverityHolder: severity.Holder{Severity: severity.Critical},
+			Classification: &model.Classification{
+				CWEID:       stringslice.StringSlice{Value: []string{"cwe-78"}},
+				CVSSMetrics: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H",
+				CVSSScore:   9.8,
+			},
+		},
+	}
+
+	response, err := integration.CreateIssue(event)
+	require.NoError(t, err)
+	require.Equal(t, "1", response.IssueID)
+	require.Equal(t, server.URL+"/finding/1", response.IssueURL)
+	require.Equal(t, 1, dojo.engagements)
+	require.Equal(t, 1, dojo.tests)
+
+	finding := dojo.findings[0]
+	require.Equal(t, "Critical", finding["severity"])
+	require.Equal(t, "S0", finding["numerical_severity"])
+	require.Equal(t, float64(78), finding["cwe"])
+	require.Equal(t, 9.8, finding["cvssv3_score"])
+	require.Equal(t, float64(21), finding["test"])
+	require.Equal(t, []interface{}{float64(7)}, finding["found_by"])
+
+	// findings with the unique id of an active finding are not imported again
+	response, err = integration.CreateIssue(event)
+	require.NoError(t, err)
+	require.Equal(t, "1", response.IssueID)
+	require.Len(t, dojo.findings, 1)
+	require.Equal(t, 1, dojo.engagements)
+
+	require.NoError(t, integration.CloseIssue(event))
+	require.Equal(t, false, dojo.findings[0]["active"])
+	require.Equal(t, true, dojo.findings[0]["is_mitigated"])
+	require.NotEmpty(t, dojo.findings[0]["mitigated"])
+
+	// mitigated findings are imported again when found
+	response, err = integration.CreateIssue(event)
+	require.NoError(t, err)
+	require.Equal(t, "2", response.IssueID)
+}
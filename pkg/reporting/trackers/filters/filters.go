@@ -1,8 +1,17 @@
 package filters
 
 import (
+	"net"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/Knetic/govaluate"
+	"github.com/projectdiscovery/gologger"
 	"github.com/projectdiscovery/nuclei/v3/pkg/model/types/severity"
 	"github.com/projectdiscovery/nuclei/v3/pkg/model/types/stringslice"
+	"github.com/projectdiscovery/nuclei/v3/pkg/operators/common/dsl"
 	"github.com/projectdiscovery/nuclei/v3/pkg/output"
 
 	sliceutil "github.com/projectdiscovery/utils/slice"
@@ -16,15 +25,67 @@ type CreateIssueResponse struct {
 }
 
 // Filter filters the received event and decides whether to perform
-// reporting for it or not.
+// reporting for it or not. An event matches a filter if it matches
+// all of the configured fields and any of the values of a field.
 type Filter struct {
 	Severities severity.Severities     `yaml:"severity"`
 	Tags       stringslice.StringSlice `yaml:"tags"`
+	// TemplateIDs are glob patterns of template ids (e.g. CVE-2023-*)
+	TemplateIDs stringslice.StringSlice `yaml:"template-id"`
+	// Authors are the authors of templates
+	Authors stringslice.StringSlice `yaml:"author"`
+	// Types are the protocol types of results (e.g. http, dns)
+	Types stringslice.StringSlice `yaml:"type"`
+	// Hosts are glob patterns of hostnames (e.g. *.example.com) or ip ranges in cidr notation
+	Hosts stringslice.StringSlice `yaml:"host"`
+	// HasCVE matches results of templates with (true) or without (false) cve ids
+	HasCVE *bool `yaml:"has-cve"`
+	// MinCVSSScore is the minimum cvss score of templates
+	MinCVSSScore float64 `yaml:"min-cvss-score"`
+	// MinEPSSScore is the minimum epss score of templates
+	MinEPSSScore float64 `yaml:"min-epss-score"`
+	// MinEPSSPercentile is the minimum epss percentile of templates
+	MinEPSSPercentile float64 `yaml:"min-epss-percentile"`
+	// DSL are expressions evaluated on the result which must all be true
+	DSL []string `yaml:"dsl"`
+
+	compileOnce sync.Once
+	compiled    []*govaluate.EvaluableExpression
+	compileErr  error
+}
+
+// Compile compiles the dsl expressions of the filter
+func (filter *Filter) Compile() error {
+	filter.compileOnce.Do(func() {
+		for _, expression := range filter.DSL {
+			compiled, err := govaluate.NewEvaluableExpressionWithFunctions(expression, dsl.HelperFunctions)
+			if err != nil {
+				filter.compileErr = err
+				return
+			}
+			filter.compiled = append(filter.compiled, compiled)
+		}
+	})
+	return filter.compileErr
 }
 
 // GetMatch returns true if a filter matches result event
 func (filter *Filter) GetMatch(event *output.ResultEvent) bool {
-	return isSeverityMatch(event, filter) && isTagMatch(event, filter) // TODO revisit this
+	return isSeverityMatch(event, filter) && isTagMatch(event, filter) && // TODO revisit this
+		isTemplateIDMatch(event, filter) && isAuthorMatch(event, filter) &&
+		isTypeMatch(event, filter) && isHostMatch(event, filter) &&
+		isClassificationMatch(event, filter) && isDSLMatch(event, filter)
+}
+
+// ShouldReport returns true if an event matches the allow list and does not match the deny list
+func ShouldReport(allowList, denyList *Filter, event *output.ResultEvent) bool {
+	if allowList != nil && !allowList.GetMatch(event) {
+		return false
+	}
+	if denyList != nil && denyList.GetMatch(event) {
+		return false
+	}
+	return true
 }
 
 func isTagMatch(event *output.ResultEvent, filter *Filter) bool {
@@ -52,3 +113,162 @@ func isSeverityMatch(event *output.ResultEvent, filter *Filter) bool {
 
 	return sliceutil.Contains(filter.Severities, resultEventSeverity)
 }
+
+func isTemplateIDMatch(event *output.ResultEvent, filter *Filter) bool {
+	if filter.TemplateIDs.IsEmpty() {
+		return true
+	}
+
+	templateID := strings.ToLower(event.TemplateID)
+	for _, pattern := range filter.TemplateIDs.ToSlice() {
+		if matched, _ := path.Match(strings.ToLower(pattern), templateID); matched {
+			return true
+		}
+	}
+	return false
+}
+
+func isAuthorMatch(event *output.ResultEvent, filter *Filter) bool {
+	if filter.Authors.IsEmpty() {
+		return true
+	}
+
+	for _, author := range event.Info.Authors.ToSlice() {
+		for _, filterAuthor := range filter.Authors.ToSlice() {
+			if strings.EqualFold(author, filterAuthor) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isTypeMatch(event *output.ResultEvent, filter *Filter) bool {
+	if filter.Types.IsEmpty() {
+		return true
+	}
+
+	for _, filterType := range filter.Types.ToSlice() {
+		if strings.EqualFold(event.Type, filterType) {
+			return true
+		}
+	}
+	return false
+}
+
+func isHostMatch(event *output.ResultEvent, filter *Filter) bool {
+	if filter.Hosts.IsEmpty() {
+		return true
+	}
+
+	hostname := getHostname(event.Host)
+	ip := net.ParseIP(event.IP)
+	if ip == nil {
+		ip = net.ParseIP(hostname)
+	}
+	for _, pattern := range filter.Hosts.ToSlice() {
+		if _, network, err := net.ParseCIDR(pattern); err == nil {
+			if ip != nil && network.Contains(ip) {
+				return true
+			}
+			continue
+		}
+		if matched, _ := path.Match(strings.ToLower(pattern), hostname); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// getHostname returns the lowercase hostname of a url or host:port
+func getHostname(host string) string {
+	if strings.Contains(host, "://") {
+		if parsed, err := url.Parse(host); err == nil {
+			host = parsed.Hostname()
+		}
+	} else if hostname, _, err := net.SplitHostPort(host); err == nil {
+		host = hostname
+	}
+	return strings.ToLower(host)
+}
+
+func isClassificationMatch(event *output.ResultEvent, filter *Filter) bool {
+	if filter.HasCVE == nil && filter.MinCVSSScore == 0 && filter.MinEPSSScore == 0 && filter.MinEPSSPercentile == 0 {
+		return true
+	}
+
+	classification := event.Info.Classification
+	if classification == nil {
+		return filter.HasCVE != nil && !*filter.HasCVE && filter.MinCVSSScore == 0 && filter.MinEPSSScore == 0 && filter.MinEPSSPercentile == 0
+	}
+	if filter.HasCVE != nil && classification.CVEID.IsEmpty() == *filter.HasCVE {
+		return false
+	}
+	return classification.CVSSScore >= filter.MinCVSSScore &&
+		classification.EPSSScore >= filter.MinEPSSScore &&
+		classification.EPSSPercentile >= filter.MinEPSSPercentile
+}
+
+func isDSLMatch(event *output.ResultEvent, filter *Filter) bool {
+	if len(filter.DSL) == 0 {
+		return true
+	}
+	if err := filter.Compile(); err != nil {
+		gologger.Warning().Msgf("Could not compile reporting filter dsl: %s\n", err)
+		return false
+	}
+
+	parameters := eventParameters(event)
+	for _, expression := range filter.compiled {
+		result, err := expression.Evaluate(parameters)
+		if err != nil {
+			gologger.Debug().Msgf("Could not evaluate reporting filter dsl %q: %s\n", expression.String(), err)
+			return false
+		}
+		if matched, ok := result.(bool); !ok || !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// eventParameters returns the variables of a result for dsl expressions
+func eventParameters(event *output.ResultEvent) map[string]interface{} {
+	parameters := map[string]interface{}{
+		"template_id":       event.TemplateID,
+		"template_path":     event.TemplatePath,
+		"name":              event.Info.Name,
+		"severity":          event.Info.SeverityHolder.Severity.String(),
+		"tags":              event.Info.Tags.ToSlice(),
+		"authors":           event.Info.Authors.ToSlice(),
+		"type":              event.Type,
+		"host":              event.Host,
+		"hostname":          getHostname(event.Host),
+		"port":              event.Port,
+		"ip":                event.IP,
+		"matched_at":        event.Matched,
+		"matcher_name":      event.MatcherName,
+		"extractor_name":    event.ExtractorName,
+		"extracted_results": event.ExtractedResults,
+		"cve_id":            []string{},
+		"cwe_id":            []string{},
+		"cvss_score":        float64(0),
+		"epss_score":        float64(0),
+		"epss_percentile":   float64(0),
+		"cpe":               "",
+	}
+	if classification := event.Info.Classification; classification != nil {
+		parameters["cve_id"] = classification.CVEID.ToSlice()
+		parameters["cwe_id"] = classification.CWEID.ToSlice()
+		parameters["cvss_score"] = classification.CVSSScore
+		parameters["epss_score"] = classification.EPSSScore
+		parameters["epss_percentile"] = classification.EPSSPercentile
+		parameters["cpe"] = classification.CPE
+	}
+	for key, value := range event.Info.Metadata {
+		if _, ok := parameters[key]; !ok {
+			parameters[key] = value
+		}
+	}
+	return parameters
+}
@@ -0,0 +1,78 @@
+package filters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/projectdiscovery/nuclei/v3/pkg/model"
+	"github.com/projectdiscovery/nuclei/v3/pkg/model/types/severity"
+	"github.com/projectdiscovery/nuclei/v3/pkg/model/types/stringslice"
+	"github.com/projectdiscovery/nuclei/v3/pkg/output"
+)
+
+func TestFilterGetMatch(t *testing.T) {
+	event := &output.ResultEvent{
+		TemplateID: "CVE-2023-1234",
+		Type:       "http",
+		Host:       "https://api.example.com:8443",
+		IP:         "10.0.0.5",
+		Info: model.Info{
+			Authors:        stringslice.StringSlice{Value: []string{"pdteam"}},
+			Tags:           stringslice.StringSlice{Value: []string{"cve", "rce"}},
+			SeverityHolder: severity.Holder{Severity: severity.High},
+			Classification: &model.Classification{
+				CVEID:          stringslice.StringSlice{Value: []string{"CVE-2023-1234"}},
+				CVSSScore:      8.1,
+				EPSSScore:      0.3,
+				EPSSPercentile: 0.95,
+			},
+		},
+	}
+	withCVE, withoutCVE := true, false
+	slice := func(values ...string) stringslice.StringSlice {
+		return stringslice.StringSlice{Value: values}
+	}
+
+	tests := []struct {
+		name    string
+		filter  *Filter
+		matches bool
+	}{
+		{"empty", &Filter{}, true},
+		{"severity", &Filter{Severities: severity.Severities{severity.Critical, severity.High}}, true},
+		{"template id glob", &Filter{TemplateIDs: slice("cve-2023-*")}, true},
+		{"template id glob mismatch", &Filter{TemplateIDs: slice("CVE-2022-*")}, false},
+		{"author", &Filter{Authors: slice("PDTeam")}, true},
+		{"type", &Filter{Types: slice("dns", "http")}, true},
+		{"type mismatch", &Filter{Types: slice("dns")}, false},
+		{"host glob", &Filter{Hosts: slice("*.example.com")}, true},
+		{"host exact", &Filter{Hosts: slice("api.example.com")}, true},
+		{"host cidr", &Filter{Hosts: slice("10.0.0.0/24")}, true},
+		{"host mismatch", &Filter{Hosts: slice("*.example.org", "192.168.0.0/16")}, false},
+		{"has cve", &Filter{HasCVE: &withCVE}, true},
+		{"has no cve", &Filter{HasCVE: &withoutCVE}, false},
+		{"cvss threshold", &Filter{MinCVSSScore: 7}, true},
+		{"cvss threshold mismatch", &Filter{MinCVSSScore: 9}, false},
+		{"epss thresholds", &Filter{MinEPSSScore: 0.1, MinEPSSPercentile: 0.9}, true},
+		{"epss threshold mismatch", &Filter{MinEPSSScore: 0.5}, false},
+		{"dsl", &Filter{DSL: []string{`hostname == "api.example.com" && cvss_score > 8`, `contains(template_id, "2023")`}}, true},
+		{"dsl mismatch", &Filter{DSL: []string{`severity == "critical"`}}, false},
+		{"all fields must match", &Filter{Types: slice("http"), Authors: slice("someone")}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.matches, test.filter.GetMatch(event))
+		})
+	}
+
+	// templates without classification only match filters not requiring it
+	plain := &output.ResultEvent{TemplateID: "tech-detect", Host: "example.com"}
+	require.True(t, (&Filter{HasCVE: &withoutCVE}).GetMatch(plain))
+	require.False(t, (&Filter{MinCVSSScore: 1}).GetMatch(plain))
+
+	require.True(t, ShouldReport(nil, nil, event))
+	require.False(t, ShouldReport(&Filter{Types: slice("dns")}, nil, event))
+	require.False(t, ShouldReport(nil, &Filter{HasCVE: &withCVE}, event))
+	require.Error(t, (&Filter{DSL: []string{"template_id =="}}).Compile())
+}
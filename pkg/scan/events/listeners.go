@@ -0,0 +1,60 @@
+package events
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	// listeners receive scan events regardless of the stats build tag.
+	// the slice is replaced on changes so events are sent without locking.
+	listeners   atomic.Pointer[[]ScanEventWorker]
+	listenersMu sync.Mutex
+)
+
+// AddListener registers a worker receiving all scan and run events
+func AddListener(listener ScanEventWorker) {
+	listenersMu.Lock()
+	defer listenersMu.Unlock()
+
+	var updated []ScanEventWorker
+	if current := listeners.Load(); current != nil {
+		updated = append(updated, *current...)
+	}
+	updated = append(updated, listener)
+	listeners.Store(&updated)
+}
+
+// RemoveListener unregisters a worker added with AddListener
+func RemoveListener(listener ScanEventWorker) {
+	listenersMu.Lock()
+	defer listenersMu.Unlock()
+
+	current := listeners.Load()
+	if current == nil {
+		return
+	}
+	updated := make([]ScanEventWorker, 0, len(*current))
+	for _, item := range *current {
+		if item != listener {
+			updated = append(updated, item)
+		}
+	}
+	listeners.Store(&updated)
+}
+
+// AddRunEvent sends a run event to listeners. Run events are
+// not recorded by the stats worker.
+func AddRunEvent(event ScanEvent) {
+	notifyListeners(event)
+}
+
+func notifyListeners(event ScanEvent) {
+	current := listeners.Load()
+	if current == nil {
+		return
+	}
+	for _, listener := range *current {
+		listener.AddScanEvent(event)
+	}
+}
@@ -3,8 +3,9 @@
 
 package events
 
-// AddScanEvent is a no-op function
+// AddScanEvent sends the event to listeners
 func AddScanEvent(event ScanEvent) {
+	notifyListeners(event)
 }
 
 func InitWithConfig(config *ScanConfig, statsDirectory string) {
@@ -75,6 +75,7 @@ func (s *ScanStatsWorker) AddScanEvent(event ScanEvent) {
 
 // AddScanEvent adds a scan event to the worker
 func AddScanEvent(event ScanEvent) {
+	notifyListeners(event)
 	if defaultWorker == nil {
 		return
 	}
@@ -15,6 +15,10 @@ type ScanStatus string
 const (
 	ScanStarted  ScanStatus = "scan_start"
 	ScanFinished ScanStatus = "scan_end"
+	// RunStarted and RunFinished track the start / finish of a whole
+	// nuclei run and are only sent to listeners
+	RunStarted  ScanStatus = "run_start"
+	RunFinished ScanStatus = "run_end"
 )
 
 const (
@@ -31,6 +35,9 @@ type ScanEvent struct {
 	MaxRequests  int        `json:"max_requests" yaml:"max_requests"`
 	Time         time.Time  `json:"time" yaml:"time"`
 	EventType    ScanStatus `json:"event_type" yaml:"event_type"`
+	// TargetCount and TemplatesCount are set for run events
+	TargetCount    int `json:"target_count,omitempty" yaml:"target_count,omitempty"`
+	TemplatesCount int `json:"templates_count,omitempty" yaml:"templates_count,omitempty"`
 }
 
 // ScanConfig is only in context of scan event analysis
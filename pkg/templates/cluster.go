@@ -218,6 +218,15 @@ func NewClusterExecuter(requests []*Template, options *protocols.ExecutorOptions
 	return executer
 }
 
+// TemplateIDs returns the IDs of the clustered templates
+func (e *ClusterExecuter) TemplateIDs() []string {
+	ids := make([]string, 0, len(e.operators))
+	for _, operator := range e.operators {
+		ids = append(ids, operator.templateID)
+	}
+	return ids
+}
+
 // Compile compiles the execution generators preparing any requests possible.
 func (e *ClusterExecuter) Compile() error {
 	return e.requests.Compile(e.options)
@@ -136,7 +136,11 @@ type Template struct {
 	//   WARNING: 'signature' will be deprecated and will be removed in a future release. Prefer using 'code' protocol for writing cloud checks
 	// values:
 	//   - "AWS"
-	Signature http.SignatureTypeHolder `yaml:"signature,omitempty" json:"signature,omitempty" jsonschema:"title=signature is the http request signature method,description=Signature is the HTTP Request signature Method,enum=AWS,deprecated=true"`
+	//   - "HTTPSIG"
+	//   - "OAUTH1"
+	//   - "AZURE-SHARED-KEY"
+	//   - "GCP-HMAC"
+	Signature http.SignatureTypeHolder `yaml:"signature,omitempty" json:"signature,omitempty" jsonschema:"title=signature is the http request signature method,description=Signature is the HTTP Request signature Method,enum=AWS,enum=HTTPSIG,enum=OAUTH1,enum=AZURE-SHARED-KEY,enum=GCP-HMAC,deprecated=true"`
 
 	// description: |
 	//   Variables contains any variables for the current request.
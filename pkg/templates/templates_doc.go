@@ -20,7 +20,10 @@ var (
 	HTTPMethodTypeHolderDoc       encoder.Doc
 	FUZZRuleDoc                   encoder.Doc
 	SliceOrMapSliceDoc            encoder.Doc
+	ANALYZERSAnalyzerTemplateDoc  encoder.Doc
 	SignatureTypeHolderDoc        encoder.Doc
+	H2RAWRequestDoc               encoder.Doc
+	H2RAWFrameDoc                 encoder.Doc
 	MATCHERSMatcherDoc            encoder.Doc
 	MatcherTypeHolderDoc          encoder.Doc
 	DNSRequestDoc                 encoder.Doc
@@ -152,6 +155,10 @@ func init() {
 	TemplateDoc.Fields[17].Comments[encoder.LineComment] = "Signature is the request signature method"
 	TemplateDoc.Fields[17].Values = []string{
 		"AWS",
+		"HTTPSIG",
+		"OAUTH1",
+		"AZURE-SHARED-KEY",
+		"GCP-HMAC",
 	}
 	TemplateDoc.Fields[18].Name = "variables"
 	TemplateDoc.Fields[18].Type = "variables.Variable"
@@ -430,6 +437,10 @@ func init() {
 			Key:   "status_code",
 			Value: "Status Code received from the Server",
 		},
+		{
+			Key:   "proto",
+			Value: "Protocol of the HTTP response (e.g. HTTP/3.0)",
+		},
 		{
 			Key:   "body",
 			Value: "HTTP response body received from server (default)",
@@ -458,8 +469,72 @@ func init() {
 			Key:   "headers_from_response",
 			Value: "HTTP response headers in name:value format",
 		},
+		{
+			Key:   "baseline_status_code",
+			Value: "Status Code of the baseline response (fuzzing only)",
+		},
+		{
+			Key:   "baseline_content_length",
+			Value: "Content length of the baseline response (fuzzing only)",
+		},
+		{
+			Key:   "baseline_status_changed",
+			Value: "True if the Status Code differs from the baseline response (fuzzing only)",
+		},
+		{
+			Key:   "baseline_length_delta",
+			Value: "Difference of the content length to the baseline response (fuzzing only)",
+		},
+		{
+			Key:   "baseline_words_delta",
+			Value: "Difference of the word count to the baseline response (fuzzing only)",
+		},
+		{
+			Key:   "baseline_similarity",
+			Value: "Structural similarity between 0 and 1 with the baseline response (fuzzing only)",
+		},
+		{
+			Key:   "stream_<id>_status_code",
+			Value: "Status Code received on the stream with the given id (h2 only)",
+		},
+		{
+			Key:   "stream_<id>_body",
+			Value: "Response body received on the stream with the given id (h2 only)",
+		},
+		{
+			Key:   "stream_<id>_header",
+			Value: "Response headers received on the stream with the given id (h2 only)",
+		},
+		{
+			Key:   "stream_<id>_error_code",
+			Value: "Error code of the RST_STREAM frame received on the stream with the given id (h2 only)",
+		},
+		{
+			Key:   "goaway",
+			Value: "True if a GOAWAY frame was received (h2 only)",
+		},
+		{
+			Key:   "goaway_error_code",
+			Value: "Error code of the received GOAWAY frame (h2 only)",
+		},
+		{
+			Key:   "goaway_last_stream_id",
+			Value: "Last stream id of the received GOAWAY frame (h2 only)",
+		},
+		{
+			Key:   "goaway_debug",
+			Value: "Debug data of the received GOAWAY frame (h2 only)",
+		},
+		{
+			Key:   "rst_stream_count",
+			Value: "Number of RST_STREAM frames received (h2 only)",
+		},
+		{
+			Key:   "connection_closed",
+			Value: "True if the server closed the connection (h2 only)",
+		},
 	}
-	HTTPRequestDoc.Fields = make([]encoder.Doc, 37)
+	HTTPRequestDoc.Fields = make([]encoder.Doc, 39)
 	HTTPRequestDoc.Fields[0].Name = "path"
 	HTTPRequestDoc.Fields[0].Type = "[]string"
 	HTTPRequestDoc.Fields[0].Note = ""
@@ -577,6 +652,10 @@ func init() {
 	HTTPRequestDoc.Fields[17].Comments[encoder.LineComment] = "Signature is the request signature method"
 	HTTPRequestDoc.Fields[17].Values = []string{
 		"AWS",
+		"HTTPSIG",
+		"OAUTH1",
+		"AZURE-SHARED-KEY",
+		"GCP-HMAC",
 	}
 	HTTPRequestDoc.Fields[18].Name = "skip-secret-file"
 	HTTPRequestDoc.Fields[18].Type = "bool"
@@ -618,61 +697,71 @@ func init() {
 	HTTPRequestDoc.Fields[25].Note = ""
 	HTTPRequestDoc.Fields[25].Description = "Unsafe specifies whether to use rawhttp engine for sending Non RFC-Compliant requests.\n\nThis uses the [rawhttp](https://github.com/projectdiscovery/rawhttp) engine to achieve complete\ncontrol over the request, with no normalization performed by the client."
 	HTTPRequestDoc.Fields[25].Comments[encoder.LineComment] = "Unsafe specifies whether to use rawhttp engine for sending Non RFC-Compliant requests."
-	HTTPRequestDoc.Fields[26].Name = "race"
+	HTTPRequestDoc.Fields[26].Name = "http3"
 	HTTPRequestDoc.Fields[26].Type = "bool"
 	HTTPRequestDoc.Fields[26].Note = ""
-	HTTPRequestDoc.Fields[26].Description = "Race determines if all the request have to be attempted at the same time (Race Condition)\n\nThe actual number of requests that will be sent is determined by the `race_count`  field."
-	HTTPRequestDoc.Fields[26].Comments[encoder.LineComment] = "Race determines if all the request have to be attempted at the same time (Race Condition)"
-	HTTPRequestDoc.Fields[27].Name = "req-condition"
-	HTTPRequestDoc.Fields[27].Type = "bool"
+	HTTPRequestDoc.Fields[26].Description = "HTTP3 sends the requests over QUIC (HTTP/3).\n\nOnly https targets are supported and the requests can not be sent through a proxy.\nThe negotiated protocol of the response is available as `proto` in the DSL."
+	HTTPRequestDoc.Fields[26].Comments[encoder.LineComment] = "HTTP3 sends the requests over QUIC (HTTP/3)."
+	HTTPRequestDoc.Fields[27].Name = "h2"
+	HTTPRequestDoc.Fields[27].Type = "[]h2raw.Request"
 	HTTPRequestDoc.Fields[27].Note = ""
-	HTTPRequestDoc.Fields[27].Description = "ReqCondition automatically assigns numbers to requests and preserves their history.\n\nThis allows matching on them later for multi-request conditions."
-	HTTPRequestDoc.Fields[27].Comments[encoder.LineComment] = "ReqCondition automatically assigns numbers to requests and preserves their history."
-	HTTPRequestDoc.Fields[28].Name = "stop-at-first-match"
+	HTTPRequestDoc.Fields[27].Description = "H2 contains raw HTTP/2 requests described as a sequence of frames.\n\nEach request is sent on a new connection (TLS with h2 ALPN for https, prior knowledge for http)\nwithout any validation of the frames, pseudo-headers or stream identifiers. It can be used for\nHTTP/2 request smuggling, CONTINUATION flood and rapid reset checks.\n\nThe responses of the streams are available as `stream_<id>_status_code`, `stream_<id>_body`,\n`stream_<id>_header` and `stream_<id>_error_code` in the DSL, while `goaway`, `goaway_error_code`,\n`goaway_last_stream_id`, `rst_stream_count` and `connection_closed` describe the connection."
+	HTTPRequestDoc.Fields[27].Comments[encoder.LineComment] = "H2 contains raw HTTP/2 requests described as a sequence of frames."
+	HTTPRequestDoc.Fields[28].Name = "race"
 	HTTPRequestDoc.Fields[28].Type = "bool"
 	HTTPRequestDoc.Fields[28].Note = ""
-	HTTPRequestDoc.Fields[28].Description = "StopAtFirstMatch stops the execution of the requests and template as soon as a match is found."
-	HTTPRequestDoc.Fields[28].Comments[encoder.LineComment] = "StopAtFirstMatch stops the execution of the requests and template as soon as a match is found."
-	HTTPRequestDoc.Fields[29].Name = "skip-variables-check"
+	HTTPRequestDoc.Fields[28].Description = "Race determines if all the request have to be attempted at the same time (Race Condition)\n\nThe actual number of requests that will be sent is determined by the `race_count`  field."
+	HTTPRequestDoc.Fields[28].Comments[encoder.LineComment] = "Race determines if all the request have to be attempted at the same time (Race Condition)"
+	HTTPRequestDoc.Fields[29].Name = "req-condition"
 	HTTPRequestDoc.Fields[29].Type = "bool"
 	HTTPRequestDoc.Fields[29].Note = ""
-	HTTPRequestDoc.Fields[29].Description = "SkipVariablesCheck skips the check for unresolved variables in request"
-	HTTPRequestDoc.Fields[29].Comments[encoder.LineComment] = "SkipVariablesCheck skips the check for unresolved variables in request"
-	HTTPRequestDoc.Fields[30].Name = "iterate-all"
+	HTTPRequestDoc.Fields[29].Description = "ReqCondition automatically assigns numbers to requests and preserves their history.\n\nThis allows matching on them later for multi-request conditions."
+	HTTPRequestDoc.Fields[29].Comments[encoder.LineComment] = "ReqCondition automatically assigns numbers to requests and preserves their history."
+	HTTPRequestDoc.Fields[30].Name = "stop-at-first-match"
 	HTTPRequestDoc.Fields[30].Type = "bool"
 	HTTPRequestDoc.Fields[30].Note = ""
-	HTTPRequestDoc.Fields[30].Description = "IterateAll iterates all the values extracted from internal extractors"
-	HTTPRequestDoc.Fields[30].Comments[encoder.LineComment] = "IterateAll iterates all the values extracted from internal extractors"
-	HTTPRequestDoc.Fields[31].Name = "digest-username"
-	HTTPRequestDoc.Fields[31].Type = "string"
+	HTTPRequestDoc.Fields[30].Description = "StopAtFirstMatch stops the execution of the requests and template as soon as a match is found."
+	HTTPRequestDoc.Fields[30].Comments[encoder.LineComment] = "StopAtFirstMatch stops the execution of the requests and template as soon as a match is found."
+	HTTPRequestDoc.Fields[31].Name = "skip-variables-check"
+	HTTPRequestDoc.Fields[31].Type = "bool"
 	HTTPRequestDoc.Fields[31].Note = ""
-	HTTPRequestDoc.Fields[31].Description = "DigestAuthUsername specifies the username for digest authentication"
-	HTTPRequestDoc.Fields[31].Comments[encoder.LineComment] = "DigestAuthUsername specifies the username for digest authentication"
-	HTTPRequestDoc.Fields[32].Name = "digest-password"
-	HTTPRequestDoc.Fields[32].Type = "string"
+	HTTPRequestDoc.Fields[31].Description = "SkipVariablesCheck skips the check for unresolved variables in request"
+	HTTPRequestDoc.Fields[31].Comments[encoder.LineComment] = "SkipVariablesCheck skips the check for unresolved variables in request"
+	HTTPRequestDoc.Fields[32].Name = "iterate-all"
+	HTTPRequestDoc.Fields[32].Type = "bool"
 	HTTPRequestDoc.Fields[32].Note = ""
-	HTTPRequestDoc.Fields[32].Description = "DigestAuthPassword specifies the password for digest authentication"
-	HTTPRequestDoc.Fields[32].Comments[encoder.LineComment] = "DigestAuthPassword specifies the password for digest authentication"
-	HTTPRequestDoc.Fields[33].Name = "disable-path-automerge"
-	HTTPRequestDoc.Fields[33].Type = "bool"
+	HTTPRequestDoc.Fields[32].Description = "IterateAll iterates all the values extracted from internal extractors"
+	HTTPRequestDoc.Fields[32].Comments[encoder.LineComment] = "IterateAll iterates all the values extracted from internal extractors"
+	HTTPRequestDoc.Fields[33].Name = "digest-username"
+	HTTPRequestDoc.Fields[33].Type = "string"
 	HTTPRequestDoc.Fields[33].Note = ""
-	HTTPRequestDoc.Fields[33].Description = "DisablePathAutomerge disables merging target url path with raw request path"
-	HTTPRequestDoc.Fields[33].Comments[encoder.LineComment] = "DisablePathAutomerge disables merging target url path with raw request path"
-	HTTPRequestDoc.Fields[34].Name = "pre-condition"
-	HTTPRequestDoc.Fields[34].Type = "[]matchers.Matcher"
+	HTTPRequestDoc.Fields[33].Description = "DigestAuthUsername specifies the username for digest authentication"
+	HTTPRequestDoc.Fields[33].Comments[encoder.LineComment] = "DigestAuthUsername specifies the username for digest authentication"
+	HTTPRequestDoc.Fields[34].Name = "digest-password"
+	HTTPRequestDoc.Fields[34].Type = "string"
 	HTTPRequestDoc.Fields[34].Note = ""
-	HTTPRequestDoc.Fields[34].Description = "Fuzz PreCondition is matcher-like field to check if fuzzing should be performed on this request or not"
-	HTTPRequestDoc.Fields[34].Comments[encoder.LineComment] = "Fuzz PreCondition is matcher-like field to check if fuzzing should be performed on this request or not"
-	HTTPRequestDoc.Fields[35].Name = "pre-condition-operator"
-	HTTPRequestDoc.Fields[35].Type = "string"
+	HTTPRequestDoc.Fields[34].Description = "DigestAuthPassword specifies the password for digest authentication"
+	HTTPRequestDoc.Fields[34].Comments[encoder.LineComment] = "DigestAuthPassword specifies the password for digest authentication"
+	HTTPRequestDoc.Fields[35].Name = "disable-path-automerge"
+	HTTPRequestDoc.Fields[35].Type = "bool"
 	HTTPRequestDoc.Fields[35].Note = ""
-	HTTPRequestDoc.Fields[35].Description = "FuzzPreConditionOperator is the operator between multiple PreConditions for fuzzing Default is OR"
-	HTTPRequestDoc.Fields[35].Comments[encoder.LineComment] = "FuzzPreConditionOperator is the operator between multiple PreConditions for fuzzing Default is OR"
-	HTTPRequestDoc.Fields[36].Name = "global-matchers"
-	HTTPRequestDoc.Fields[36].Type = "bool"
+	HTTPRequestDoc.Fields[35].Description = "DisablePathAutomerge disables merging target url path with raw request path"
+	HTTPRequestDoc.Fields[35].Comments[encoder.LineComment] = "DisablePathAutomerge disables merging target url path with raw request path"
+	HTTPRequestDoc.Fields[36].Name = "pre-condition"
+	HTTPRequestDoc.Fields[36].Type = "[]matchers.Matcher"
 	HTTPRequestDoc.Fields[36].Note = ""
-	HTTPRequestDoc.Fields[36].Description = "GlobalMatchers marks matchers as static and applies globally to all result events from other templates"
-	HTTPRequestDoc.Fields[36].Comments[encoder.LineComment] = "GlobalMatchers marks matchers as static and applies globally to all result events from other templates"
+	HTTPRequestDoc.Fields[36].Description = "Fuzz PreCondition is matcher-like field to check if fuzzing should be performed on this request or not"
+	HTTPRequestDoc.Fields[36].Comments[encoder.LineComment] = "Fuzz PreCondition is matcher-like field to check if fuzzing should be performed on this request or not"
+	HTTPRequestDoc.Fields[37].Name = "pre-condition-operator"
+	HTTPRequestDoc.Fields[37].Type = "string"
+	HTTPRequestDoc.Fields[37].Note = ""
+	HTTPRequestDoc.Fields[37].Description = "FuzzPreConditionOperator is the operator between multiple PreConditions for fuzzing Default is OR"
+	HTTPRequestDoc.Fields[37].Comments[encoder.LineComment] = "FuzzPreConditionOperator is the operator between multiple PreConditions for fuzzing Default is OR"
+	HTTPRequestDoc.Fields[38].Name = "global-matchers"
+	HTTPRequestDoc.Fields[38].Type = "bool"
+	HTTPRequestDoc.Fields[38].Note = ""
+	HTTPRequestDoc.Fields[38].Description = "GlobalMatchers marks matchers as static and applies globally to all result events from other templates"
+	HTTPRequestDoc.Fields[38].Comments[encoder.LineComment] = "GlobalMatchers marks matchers as static and applies globally to all result events from other templates"
 
 	GENERATORSAttackTypeHolderDoc.Type = "generators.AttackTypeHolder"
 	GENERATORSAttackTypeHolderDoc.Comments[encoder.LineComment] = " AttackTypeHolder is used to hold internal type of the protocol"
@@ -757,7 +846,7 @@ func init() {
 			FieldName: "fuzzing",
 		},
 	}
-	FUZZRuleDoc.Fields = make([]encoder.Doc, 9)
+	FUZZRuleDoc.Fields = make([]encoder.Doc, 10)
 	FUZZRuleDoc.Fields[0].Name = "type"
 	FUZZRuleDoc.Fields[0].Type = "string"
 	FUZZRuleDoc.Fields[0].Note = ""
@@ -835,6 +924,11 @@ func init() {
 	FUZZRuleDoc.Fields[8].Note = ""
 	FUZZRuleDoc.Fields[8].Description = "replace-regex is regex for regex-replace rule type\nit is only required for replace-regex rule type"
 	FUZZRuleDoc.Fields[8].Comments[encoder.LineComment] = "replace-regex is regex for regex-replace rule type"
+	FUZZRuleDoc.Fields[9].Name = "analyzer"
+	FUZZRuleDoc.Fields[9].Type = "analyzers.AnalyzerTemplate"
+	FUZZRuleDoc.Fields[9].Note = ""
+	FUZZRuleDoc.Fields[9].Description = "Analyzer is the optional analyzer run on the responses of the\ngenerated requests to detect blind issues (ex: time based injections).\n\nAnalyzers are only supported with single mode. The time_delay analyzer\nreplaces the [SLEEPTIME] marker in payloads with the delay in seconds."
+	FUZZRuleDoc.Fields[9].Comments[encoder.LineComment] = "Analyzer is the optional analyzer run on the responses of the"
 
 	SliceOrMapSliceDoc.Type = "SliceOrMapSlice"
 	SliceOrMapSliceDoc.Comments[encoder.LineComment] = ""
@@ -847,6 +941,30 @@ func init() {
 	}
 	SliceOrMapSliceDoc.Fields = make([]encoder.Doc, 0)
 
+	ANALYZERSAnalyzerTemplateDoc.Type = "analyzers.AnalyzerTemplate"
+	ANALYZERSAnalyzerTemplateDoc.Comments[encoder.LineComment] = " AnalyzerTemplate is the analyzer configuration of a fuzzing rule"
+	ANALYZERSAnalyzerTemplateDoc.Description = "AnalyzerTemplate is the analyzer configuration of a fuzzing rule"
+	ANALYZERSAnalyzerTemplateDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "fuzz.Rule",
+			FieldName: "analyzer",
+		},
+	}
+	ANALYZERSAnalyzerTemplateDoc.Fields = make([]encoder.Doc, 2)
+	ANALYZERSAnalyzerTemplateDoc.Fields[0].Name = "name"
+	ANALYZERSAnalyzerTemplateDoc.Fields[0].Type = "string"
+	ANALYZERSAnalyzerTemplateDoc.Fields[0].Note = ""
+	ANALYZERSAnalyzerTemplateDoc.Fields[0].Description = "Name is the name of the analyzer to run."
+	ANALYZERSAnalyzerTemplateDoc.Fields[0].Comments[encoder.LineComment] = "Name is the name of the analyzer to run."
+	ANALYZERSAnalyzerTemplateDoc.Fields[0].Values = []string{
+		"time_delay",
+	}
+	ANALYZERSAnalyzerTemplateDoc.Fields[1].Name = "parameters"
+	ANALYZERSAnalyzerTemplateDoc.Fields[1].Type = "map[string]interface{}"
+	ANALYZERSAnalyzerTemplateDoc.Fields[1].Note = ""
+	ANALYZERSAnalyzerTemplateDoc.Fields[1].Description = "Parameters is the map of parameters for the analyzer.\n\nParameters differ for each analyzer, for example time_delay accepts\nsleep_duration, requests_limit, time_slope_error_range and\ntime_correlation_error_range."
+	ANALYZERSAnalyzerTemplateDoc.Fields[1].Comments[encoder.LineComment] = "Parameters is the map of parameters for the analyzer."
+
 	SignatureTypeHolderDoc.Type = "SignatureTypeHolder"
 	SignatureTypeHolderDoc.Comments[encoder.LineComment] = " SignatureTypeHolder is used to hold internal type of the signature"
 	SignatureTypeHolderDoc.Description = "SignatureTypeHolder is used to hold internal type of the signature"
@@ -858,6 +976,117 @@ func init() {
 	}
 	SignatureTypeHolderDoc.Fields = make([]encoder.Doc, 0)
 
+	H2RAWRequestDoc.Type = "h2raw.Request"
+	H2RAWRequestDoc.Comments[encoder.LineComment] = " Request is a sequence of raw http2 frames sent on a single connection"
+	H2RAWRequestDoc.Description = "Request is a sequence of raw http2 frames sent on a single connection"
+	H2RAWRequestDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "http.Request",
+			FieldName: "h2",
+		},
+	}
+	H2RAWRequestDoc.Fields = make([]encoder.Doc, 3)
+	H2RAWRequestDoc.Fields[0].Name = "frames"
+	H2RAWRequestDoc.Fields[0].Type = "[]h2raw.Frame"
+	H2RAWRequestDoc.Fields[0].Note = ""
+	H2RAWRequestDoc.Fields[0].Description = "Frames is the list of frames to send on the connection.\n\nThe connection preface and an empty SETTINGS frame are always sent first."
+	H2RAWRequestDoc.Fields[0].Comments[encoder.LineComment] = "Frames is the list of frames to send on the connection."
+	H2RAWRequestDoc.Fields[1].Name = "repeat"
+	H2RAWRequestDoc.Fields[1].Type = "int"
+	H2RAWRequestDoc.Fields[1].Note = ""
+	H2RAWRequestDoc.Fields[1].Description = "Repeat sends the frames the given number of times. The stream identifiers\nof the frames are advanced by 2 on each repetition (e.g. for rapid reset checks)."
+	H2RAWRequestDoc.Fields[1].Comments[encoder.LineComment] = "Repeat sends the frames the given number of times. The stream identifiers"
+	H2RAWRequestDoc.Fields[2].Name = "read-timeout"
+	H2RAWRequestDoc.Fields[2].Type = "string"
+	H2RAWRequestDoc.Fields[2].Note = ""
+	H2RAWRequestDoc.Fields[2].Description = "ReadTimeout is the maximum time to wait for the responses of the server (default http timeout)."
+	H2RAWRequestDoc.Fields[2].Comments[encoder.LineComment] = "ReadTimeout is the maximum time to wait for the responses of the server (default http timeout)."
+
+	H2RAWRequestDoc.Fields[2].AddExample("", "5s")
+
+	H2RAWFrameDoc.Type = "h2raw.Frame"
+	H2RAWFrameDoc.Comments[encoder.LineComment] = " Frame is a single raw http2 frame"
+	H2RAWFrameDoc.Description = "Frame is a single raw http2 frame"
+	H2RAWFrameDoc.AppearsIn = []encoder.Appearance{
+		{
+			TypeName:  "h2raw.Request",
+			FieldName: "frames",
+		},
+	}
+	H2RAWFrameDoc.Fields = make([]encoder.Doc, 11)
+	H2RAWFrameDoc.Fields[0].Name = "type"
+	H2RAWFrameDoc.Fields[0].Type = "string"
+	H2RAWFrameDoc.Fields[0].Note = ""
+	H2RAWFrameDoc.Fields[0].Description = "Type is the type of the frame."
+	H2RAWFrameDoc.Fields[0].Comments[encoder.LineComment] = "Type is the type of the frame."
+	H2RAWFrameDoc.Fields[0].Values = []string{
+		"headers",
+		"continuation",
+		"data",
+		"rst_stream",
+		"settings",
+		"ping",
+		"window_update",
+		"priority",
+		"goaway",
+	}
+	H2RAWFrameDoc.Fields[1].Name = "stream"
+	H2RAWFrameDoc.Fields[1].Type = "uint32"
+	H2RAWFrameDoc.Fields[1].Note = ""
+	H2RAWFrameDoc.Fields[1].Description = "Stream is the stream identifier of the frame.\n\nIt defaults to 1 for stream level frames and to 0 for connection level frames."
+	H2RAWFrameDoc.Fields[1].Comments[encoder.LineComment] = "Stream is the stream identifier of the frame."
+	H2RAWFrameDoc.Fields[2].Name = "headers"
+	H2RAWFrameDoc.Fields[2].Type = "[]string"
+	H2RAWFrameDoc.Fields[2].Note = ""
+	H2RAWFrameDoc.Fields[2].Description = "Headers is the list of headers of a HEADERS or CONTINUATION frame in `name: value` format.\n\nPseudo-headers are not validated and can be repeated or sent in any order."
+	H2RAWFrameDoc.Fields[2].Comments[encoder.LineComment] = "Headers is the list of headers of a HEADERS or CONTINUATION frame in `name: value` format."
+
+	H2RAWFrameDoc.Fields[2].AddExample("", []string{":method: POST", ":path: /", ":scheme: https", ":authority: {{Hostname}}", "content-length: 0"})
+	H2RAWFrameDoc.Fields[3].Name = "data"
+	H2RAWFrameDoc.Fields[3].Type = "string"
+	H2RAWFrameDoc.Fields[3].Note = ""
+	H2RAWFrameDoc.Fields[3].Description = "Data is the payload of a DATA frame or the debug data of a GOAWAY frame."
+	H2RAWFrameDoc.Fields[3].Comments[encoder.LineComment] = "Data is the payload of a DATA frame or the debug data of a GOAWAY frame."
+	H2RAWFrameDoc.Fields[4].Name = "end-stream"
+	H2RAWFrameDoc.Fields[4].Type = "bool"
+	H2RAWFrameDoc.Fields[4].Note = ""
+	H2RAWFrameDoc.Fields[4].Description = "EndStream sets the END_STREAM flag of HEADERS and DATA frames."
+	H2RAWFrameDoc.Fields[4].Comments[encoder.LineComment] = "EndStream sets the END_STREAM flag of HEADERS and DATA frames."
+	H2RAWFrameDoc.Fields[5].Name = "end-headers"
+	H2RAWFrameDoc.Fields[5].Type = "bool"
+	H2RAWFrameDoc.Fields[5].Note = ""
+	H2RAWFrameDoc.Fields[5].Description = "EndHeaders sets the END_HEADERS flag of HEADERS and CONTINUATION frames.\n\nBy default the flag is set unless the frame is followed by a CONTINUATION frame."
+	H2RAWFrameDoc.Fields[5].Comments[encoder.LineComment] = "EndHeaders sets the END_HEADERS flag of HEADERS and CONTINUATION frames."
+	H2RAWFrameDoc.Fields[6].Name = "error-code"
+	H2RAWFrameDoc.Fields[6].Type = "string"
+	H2RAWFrameDoc.Fields[6].Note = ""
+	H2RAWFrameDoc.Fields[6].Description = "ErrorCode is the error code of RST_STREAM and GOAWAY frames (default NO_ERROR for GOAWAY and CANCEL for RST_STREAM)."
+	H2RAWFrameDoc.Fields[6].Comments[encoder.LineComment] = "ErrorCode is the error code of RST_STREAM and GOAWAY frames (default NO_ERROR for GOAWAY and CANCEL for RST_STREAM)."
+
+	H2RAWFrameDoc.Fields[6].AddExample("", "CANCEL")
+	H2RAWFrameDoc.Fields[7].Name = "settings"
+	H2RAWFrameDoc.Fields[7].Type = "[]string"
+	H2RAWFrameDoc.Fields[7].Note = ""
+	H2RAWFrameDoc.Fields[7].Description = "Settings is the list of parameters of a SETTINGS frame in `NAME=value` format."
+	H2RAWFrameDoc.Fields[7].Comments[encoder.LineComment] = "Settings is the list of parameters of a SETTINGS frame in `NAME=value` format."
+
+	H2RAWFrameDoc.Fields[7].AddExample("", []string{"MAX_CONCURRENT_STREAMS=100", "INITIAL_WINDOW_SIZE=65535"})
+	H2RAWFrameDoc.Fields[8].Name = "increment"
+	H2RAWFrameDoc.Fields[8].Type = "uint32"
+	H2RAWFrameDoc.Fields[8].Note = ""
+	H2RAWFrameDoc.Fields[8].Description = "Increment is the window size increment of a WINDOW_UPDATE frame."
+	H2RAWFrameDoc.Fields[8].Comments[encoder.LineComment] = "Increment is the window size increment of a WINDOW_UPDATE frame."
+	H2RAWFrameDoc.Fields[9].Name = "last-stream"
+	H2RAWFrameDoc.Fields[9].Type = "uint32"
+	H2RAWFrameDoc.Fields[9].Note = ""
+	H2RAWFrameDoc.Fields[9].Description = "LastStream is the last stream identifier of a GOAWAY frame."
+	H2RAWFrameDoc.Fields[9].Comments[encoder.LineComment] = "LastStream is the last stream identifier of a GOAWAY frame."
+	H2RAWFrameDoc.Fields[10].Name = "repeat"
+	H2RAWFrameDoc.Fields[10].Type = "int"
+	H2RAWFrameDoc.Fields[10].Note = ""
+	H2RAWFrameDoc.Fields[10].Description = "Repeat sends the frame the given number of times (e.g. for CONTINUATION floods)."
+	H2RAWFrameDoc.Fields[10].Comments[encoder.LineComment] = "Repeat sends the frame the given number of times (e.g. for CONTINUATION floods)."
+
 	MATCHERSMatcherDoc.Type = "matchers.Matcher"
 	MATCHERSMatcherDoc.Comments[encoder.LineComment] = " Matcher is used to match a part in the output from a protocol."
 	MATCHERSMatcherDoc.Description = "Matcher is used to match a part in the output from a protocol."
@@ -1343,7 +1572,7 @@ func init() {
 	NETWORKRequestDoc.Fields[1].Name = "host"
 	NETWORKRequestDoc.Fields[1].Type = "[]string"
 	NETWORKRequestDoc.Fields[1].Note = ""
-	NETWORKRequestDoc.Fields[1].Description = "Host to send network requests to.\n\nUsually it's set to `{{Hostname}}`. If you want to enable TLS for\nTCP Connection, you can use `tls://{{Hostname}}`."
+	NETWORKRequestDoc.Fields[1].Description = "Host to send network requests to.\n\nUsually it's set to `{{Hostname}}`. If you want to enable TLS for\nTCP Connection, you can use `tls://{{Hostname}}`.\n\nUDP can be used with `udp://{{Hostname}}` and DTLS with `dtls://{{Hostname}}`.\nOver UDP every input is sent as a single datagram and every read returns\na single datagram truncated to the read size."
 	NETWORKRequestDoc.Fields[1].Comments[encoder.LineComment] = "Host to send network requests to."
 
 	NETWORKRequestDoc.Fields[1].AddExample("", []string{"{{Hostname}}"})
@@ -1775,7 +2004,7 @@ func init() {
 			Value: "TLS version is the version of the TLS protocol used",
 		},
 	}
-	SSLRequestDoc.Fields = make([]encoder.Doc, 9)
+	SSLRequestDoc.Fields = make([]encoder.Doc, 10)
 	SSLRequestDoc.Fields[0].Name = "id"
 	SSLRequestDoc.Fields[0].Type = "string"
 	SSLRequestDoc.Fields[0].Note = ""
@@ -1835,6 +2064,20 @@ func init() {
 	SSLRequestDoc.Fields[8].Note = ""
 	SSLRequestDoc.Fields[8].Description = "description: |\n  TLS Cipher types to enumerate\n values:\n   - \"insecure\" (default)\n   - \"weak\"\n   - \"secure\"\n   - \"all\""
 	SSLRequestDoc.Fields[8].Comments[encoder.LineComment] = " description: |"
+	SSLRequestDoc.Fields[9].Name = "starttls"
+	SSLRequestDoc.Fields[9].Type = "string"
+	SSLRequestDoc.Fields[9].Note = ""
+	SSLRequestDoc.Fields[9].Description = "StartTLS upgrades a plaintext connection using the protocol before the\ntls handshake for services not speaking tls directly on the port.\n\nThe handshake is always performed with ctls and tls version or cipher\nenumeration are not supported."
+	SSLRequestDoc.Fields[9].Comments[encoder.LineComment] = "StartTLS upgrades a plaintext connection using the protocol before the"
+	SSLRequestDoc.Fields[9].Values = []string{
+		"smtp",
+		"imap",
+		"pop3",
+		"ftp",
+		"xmpp",
+		"ldap",
+		"postgres",
+	}
 
 	WEBSOCKETRequestDoc.Type = "websocket.Request"
 	WEBSOCKETRequestDoc.Comments[encoder.LineComment] = " Request is a request for the Websocket protocol"
@@ -2127,7 +2370,10 @@ func GetTemplateDoc() *encoder.FileDoc {
 			&HTTPMethodTypeHolderDoc,
 			&FUZZRuleDoc,
 			&SliceOrMapSliceDoc,
+			&ANALYZERSAnalyzerTemplateDoc,
 			&SignatureTypeHolderDoc,
+			&H2RAWRequestDoc,
+			&H2RAWFrameDoc,
 			&MATCHERSMatcherDoc,
 			&MatcherTypeHolderDoc,
 			&DNSRequestDoc,
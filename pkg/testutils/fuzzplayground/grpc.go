@@ -0,0 +1,133 @@
+package fuzzplayground
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// The playground implements a stand-in for the following gRPC service
+// without depending on grpc-go. Requests can be sent over HTTP/1.1 or
+// h2c and status codes are returned as headers for errors (trailers-only
+// responses) and as trailers for successful responses.
+//
+//	package playground;
+//
+//	service UserService {
+//	  rpc GetUser (GetUserRequest) returns (User);
+//	}
+//	message GetUserRequest { string id = 1; }
+//	message User { int32 id = 1; string name = 2; int32 age = 3; string role = 4; }
+
+// grpc status codes used by the playground
+const (
+	grpcStatusOK              = 0
+	grpcStatusUnknown         = 2
+	grpcStatusInvalidArgument = 3
+)
+
+// grpcGetUserHandler is vulnerable to SQL injection in the id field
+// of the GetUserRequest message
+func grpcGetUserHandler(ctx echo.Context) error {
+	if !strings.HasPrefix(ctx.Request().Header.Get("Content-Type"), "application/grpc") {
+		return ctx.NoContent(http.StatusUnsupportedMediaType)
+	}
+	body, err := io.ReadAll(ctx.Request().Body)
+	if err != nil {
+		return grpcError(ctx, grpcStatusInvalidArgument, err.Error())
+	}
+	message, err := readGRPCFrame(body)
+	if err != nil {
+		return grpcError(ctx, grpcStatusInvalidArgument, err.Error())
+	}
+	id, err := readGetUserRequest(message)
+	if err != nil {
+		return grpcError(ctx, grpcStatusInvalidArgument, err.Error())
+	}
+
+	user, err := getUnsanitizedUser(db, id)
+	if err != nil {
+		return grpcError(ctx, grpcStatusUnknown, err.Error())
+	}
+
+	var response []byte
+	response = protowire.AppendTag(response, 1, protowire.VarintType)
+	response = protowire.AppendVarint(response, uint64(user.ID))
+	response = protowire.AppendTag(response, 2, protowire.BytesType)
+	response = protowire.AppendString(response, user.Name)
+	response = protowire.AppendTag(response, 3, protowire.VarintType)
+	response = protowire.AppendVarint(response, uint64(user.Age))
+	response = protowire.AppendTag(response, 4, protowire.BytesType)
+	response = protowire.AppendString(response, user.Role)
+
+	frame := make([]byte, 5, 5+len(response))
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(response)))
+	frame = append(frame, response...)
+
+	header := ctx.Response().Header()
+	header.Set("Content-Type", "application/grpc")
+	header.Set("Trailer", "Grpc-Status")
+	ctx.Response().WriteHeader(http.StatusOK)
+	if _, err := ctx.Response().Write(frame); err != nil {
+		return err
+	}
+	header.Set("Grpc-Status", strconv.Itoa(grpcStatusOK))
+	return nil
+}
+
+// grpcError writes a trailers-only grpc error response
+func grpcError(ctx echo.Context, status int, message string) error {
+	header := ctx.Response().Header()
+	header.Set("Content-Type", "application/grpc")
+	header.Set("Grpc-Status", strconv.Itoa(status))
+	header.Set("Grpc-Message", message)
+	return ctx.NoContent(http.StatusOK)
+}
+
+// readGRPCFrame returns the message of a single uncompressed grpc frame
+func readGRPCFrame(body []byte) ([]byte, error) {
+	if len(body) < 5 {
+		return nil, errors.New("invalid grpc frame")
+	}
+	if body[0] != 0 {
+		return nil, errors.New("compressed messages are not supported")
+	}
+	length := binary.BigEndian.Uint32(body[1:5])
+	if int(length) != len(body)-5 {
+		return nil, errors.New("invalid grpc frame length")
+	}
+	return body[5:], nil
+}
+
+// readGetUserRequest returns the id field of a GetUserRequest message
+func readGetUserRequest(message []byte) (string, error) {
+	var id string
+	for len(message) > 0 {
+		number, wireType, n := protowire.ConsumeTag(message)
+		if n < 0 {
+			return "", protowire.ParseError(n)
+		}
+		message = message[n:]
+		if number == 1 && wireType == protowire.BytesType {
+			value, n := protowire.ConsumeBytes(message)
+			if n < 0 {
+				return "", protowire.ParseError(n)
+			}
+			id = string(value)
+			message = message[n:]
+			continue
+		}
+		n = protowire.ConsumeFieldValue(number, wireType, message)
+		if n < 0 {
+			return "", protowire.ParseError(n)
+		}
+		message = message[n:]
+	}
+	return id, nil
+}
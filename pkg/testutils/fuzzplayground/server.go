@@ -8,8 +8,10 @@ import (
 	"net/http"
 	"net/url"
 	"os/exec"
+	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
@@ -34,6 +36,9 @@ func GetPlaygroundServer() *echo.Echo {
 	e.GET("/user/:id/profile", userProfileHandler)
 	e.POST("/user", patchUnsanitizedUserHandler)
 	e.GET("/blog/posts", getPostsHandler)
+	e.POST("/playground.UserService/GetUser", grpcGetUserHandler)
+	e.GET("/user/search", timeBasedSearchHandler)
+	e.GET("/user/lookup", booleanBasedLookupHandler)
 	return e
 }
 
@@ -60,6 +65,9 @@ func indexHandler(ctx echo.Context) error {
 	<li><a href="/user/75/profile">User Profile Page SQLI (path parameter)</a></li>
 	<li><a href="/user">POST on /user SQLI (body parameter)</a></li>
 	<li><a href="/blog/posts">SQLI in cookie lang parameter value (eg. lang=en)</a></li>
+	<li><a href="/playground.UserService/GetUser">gRPC GetUser SQLI (protobuf id field)</a></li>
+	<li><a href="/user/search?name=admin">Time based blind SQLI in name parameter (simulated)</a></li>
+	<li><a href="/user/lookup?id=75">Boolean based blind SQLI in id parameter</a></li>
 	
 	</ul>
 `))
@@ -218,3 +226,29 @@ func getPostsHandler(c echo.Context) error {
 	}
 	return c.JSON(http.StatusOK, posts)
 }
+
+var sleepRegex = regexp.MustCompile(`(?i)sleep\((\d+)\)`)
+
+// timeBasedSearchHandler simulates a time based blind sql injection
+// since sqlite does not support sleep functions
+func timeBasedSearchHandler(c echo.Context) error {
+	name := c.QueryParam("name")
+	if matches := sleepRegex.FindStringSubmatch(name); len(matches) == 2 {
+		seconds, _ := strconv.Atoi(matches[1])
+		if seconds > 10 {
+			seconds = 10
+		}
+		time.Sleep(time.Duration(seconds) * time.Second)
+	}
+	return c.JSON(http.StatusOK, "Search completed")
+}
+
+// booleanBasedLookupHandler has a boolean based blind sql injection
+// where database errors are not visible in the response
+func booleanBasedLookupHandler(c echo.Context) error {
+	user, err := getUnsanitizedUser(db, c.QueryParam("id"))
+	if err != nil {
+		return c.JSON(http.StatusOK, map[string]string{"error": "no results"})
+	}
+	return c.JSON(http.StatusOK, user)
+}
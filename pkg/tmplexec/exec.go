@@ -99,8 +99,7 @@ func (e *TemplateExecuter) Requests() int {
 // Execute executes the protocol group and returns true or false if results were found.
 func (e *TemplateExecuter) Execute(ctx *scan.ScanContext) (bool, error) {
 
-	// === when nuclei is built with -tags=stats ===
-	// Note: this is no-op (empty functions) when nuclei is built in normal or without -tags=stats
+	// === scan events are sent to listeners (e.g. stream exporters) and recorded by the stats worker with -tags=stats ===
 	events.AddScanEvent(events.ScanEvent{
 		Target:       ctx.Input.MetaInput.Input,
 		Time:         time.Now(),
@@ -121,7 +120,7 @@ func (e *TemplateExecuter) Execute(ctx *scan.ScanContext) (bool, error) {
 			MaxRequests:  e.Requests(),
 		})
 	}()
-	// ==== end of stats ====
+	// ==== end of scan events ====
 
 	// executed contains status of execution if it was successfully executed or not
 	// doesn't matter if it was matched or not
@@ -7,23 +7,35 @@ import (
 	"sync"
 
 	"github.com/projectdiscovery/nuclei/v3/pkg/catalog/config"
+	"github.com/projectdiscovery/nuclei/v3/pkg/types/resume"
 	"github.com/rs/xid"
 )
 
 // Default resume file
 const DefaultResumeFileName = "resume-%s.cfg"
 
+// Default resume store file
+const DefaultResumeStoreFileName = "resume-%s.db"
+
 func DefaultResumeFilePath() string {
 	configDir := config.DefaultConfig.GetCacheDir()
 	resumeFile := filepath.Join(configDir, fmt.Sprintf(DefaultResumeFileName, xid.New().String()))
 	return resumeFile
 }
 
+// DefaultResumeStorePath returns a new path for the scan state store
+func DefaultResumeStorePath() string {
+	configDir := config.DefaultConfig.GetCacheDir()
+	return filepath.Join(configDir, fmt.Sprintf(DefaultResumeStoreFileName, xid.New().String()))
+}
+
 // ResumeCfg contains the scan progression
 type ResumeCfg struct {
 	sync.RWMutex
 	ResumeFrom map[string]*ResumeInfo `json:"resumeFrom"`
 	Current    map[string]*ResumeInfo `json:"-"`
+	// Store records completed (template, target) pairs as they finish (optional)
+	Store resume.Store `json:"-"`
 }
 
 type ResumeInfo struct {
@@ -83,6 +95,7 @@ func (resumeCfg *ResumeCfg) Clone() *ResumeCfg {
 	return &ResumeCfg{
 		ResumeFrom: resumeFrom,
 		Current:    current,
+		Store:      resumeCfg.Store,
 	}
 }
 
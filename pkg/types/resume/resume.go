@@ -0,0 +1,94 @@
+// Package resume implements a crash-safe on-disk store of the scan
+// progression which is used to resume interrupted scans.
+//
+// Completed (template, target) pairs are recorded as soon as they finish
+// so that progress is not lost even if nuclei is killed or crashes.
+package resume
+
+import (
+	"encoding/binary"
+	"io"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.etcd.io/bbolt"
+)
+
+// Store records the completed (template, target) pairs of a scan
+type Store interface {
+	// IsCompleted returns true if the template was completed on the target
+	IsCompleted(templateID, target string) bool
+	// MarkCompleted records the template as completed on the target
+	MarkCompleted(templateID, target string) error
+	// Close closes the store
+	Close() error
+}
+
+// boltMagic is the magic number at the start of every bbolt file
+const boltMagic = 0xED0CDAED
+
+// BoltStore is a Store persisted to a bbolt database
+type BoltStore struct {
+	db *bbolt.DB
+}
+
+var _ Store = &BoltStore{}
+
+// NewBoltStore opens or creates a bbolt scan state store at path
+func NewBoltStore(path string) (*BoltStore, error) {
+	db, err := bbolt.Open(path, 0600, &bbolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, errors.Wrap(err, "could not open resume store")
+	}
+	return &BoltStore{db: db}, nil
+}
+
+// IsCompleted returns true if the template was completed on the target
+func (s *BoltStore) IsCompleted(templateID, target string) bool {
+	var completed bool
+	_ = s.db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket([]byte(templateID))
+		if bucket != nil {
+			completed = bucket.Get([]byte(target)) != nil
+		}
+		return nil
+	})
+	return completed
+}
+
+// MarkCompleted records the template as completed on the target.
+//
+// Writes of concurrent callers are batched into a single transaction
+// and the call returns once the transaction is synced to disk.
+func (s *BoltStore) MarkCompleted(templateID, target string) error {
+	return s.db.Batch(func(tx *bbolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists([]byte(templateID))
+		if err != nil {
+			return err
+		}
+		return bucket.Put([]byte(target), []byte{})
+	})
+}
+
+// Close closes the store
+func (s *BoltStore) Close() error {
+	return s.db.Close()
+}
+
+// IsStore returns true if the file at path is a bbolt scan state store
+// and not a legacy json resume file
+func IsStore(path string) bool {
+	file, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+
+	// the first page starts with a 16 bytes page header followed by the meta magic
+	header := make([]byte, 20)
+	if _, err := io.ReadFull(file, header); err != nil {
+		return false
+	}
+	return binary.LittleEndian.Uint32(header[16:]) == boltMagic || binary.BigEndian.Uint32(header[16:]) == boltMagic
+}
@@ -0,0 +1,36 @@
+package resume
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoltStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "resume.db")
+
+	store, err := NewBoltStore(path)
+	require.NoError(t, err, "could not create store")
+	require.False(t, store.IsCompleted("tech-detect", "https://example.com"))
+	require.NoError(t, store.MarkCompleted("tech-detect", "https://example.com"))
+	require.True(t, store.IsCompleted("tech-detect", "https://example.com"))
+	require.False(t, store.IsCompleted("tech-detect", "https://example.org"))
+	require.False(t, store.IsCompleted("other", "https://example.com"))
+	require.NoError(t, store.Close())
+
+	t.Run("reopen", func(t *testing.T) {
+		require.True(t, IsStore(path), "could not detect store")
+
+		store, err := NewBoltStore(path)
+		require.NoError(t, err, "could not open store")
+		defer store.Close()
+		require.True(t, store.IsCompleted("tech-detect", "https://example.com"), "progress was not persisted")
+	})
+	t.Run("legacy", func(t *testing.T) {
+		legacy := filepath.Join(t.TempDir(), "resume.cfg")
+		require.NoError(t, os.WriteFile(legacy, []byte(`{"resumeFrom":{}}`), 0600))
+		require.False(t, IsStore(legacy), "json file detected as store")
+	})
+}
@@ -0,0 +1,61 @@
+package types
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Shard is a deterministic partition of the (template, target) work space
+// of a scan. Independent nuclei processes running all shards 1/M...M/M
+// of the same scan together execute every (template, target) pair exactly once.
+type Shard struct {
+	// Index is the 1-based index of the shard
+	Index uint64
+	// Count is the total number of shards
+	Count uint64
+}
+
+// ParseShard parses a shard in N/M format. nil is returned for empty value.
+func ParseShard(value string) (*Shard, error) {
+	if value == "" {
+		return nil, nil
+	}
+	index, count, ok := strings.Cut(value, "/")
+	if !ok {
+		return nil, fmt.Errorf("invalid shard %q: expected N/M format", value)
+	}
+	shard := &Shard{}
+	var err error
+	if shard.Index, err = strconv.ParseUint(strings.TrimSpace(index), 10, 64); err != nil {
+		return nil, errors.Wrapf(err, "invalid shard index %q", index)
+	}
+	if shard.Count, err = strconv.ParseUint(strings.TrimSpace(count), 10, 64); err != nil {
+		return nil, errors.Wrapf(err, "invalid shard count %q", count)
+	}
+	if shard.Count == 0 || shard.Index == 0 || shard.Index > shard.Count {
+		return nil, fmt.Errorf("invalid shard %q: index must be between 1 and %d", value, shard.Count)
+	}
+	return shard, nil
+}
+
+// Contains returns true if the (template, target) pair belongs to the shard.
+// targetID is empty for self-contained templates which are not bound to a target.
+func (s *Shard) Contains(templateID, targetID string) bool {
+	if s == nil || s.Count <= 1 {
+		return true
+	}
+	hasher := fnv.New64a()
+	_, _ = hasher.Write([]byte(templateID))
+	_, _ = hasher.Write([]byte{0})
+	_, _ = hasher.Write([]byte(targetID))
+	return hasher.Sum64()%s.Count == s.Index-1
+}
+
+// String returns the shard in N/M format
+func (s *Shard) String() string {
+	return fmt.Sprintf("%d/%d", s.Index, s.Count)
+}
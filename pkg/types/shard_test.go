@@ -0,0 +1,52 @@
+package types
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseShard(t *testing.T) {
+	shard, err := ParseShard("2/3")
+	require.NoError(t, err, "could not parse shard")
+	require.Equal(t, &Shard{Index: 2, Count: 3}, shard)
+	require.Equal(t, "2/3", shard.String())
+
+	shard, err = ParseShard("")
+	require.NoError(t, err)
+	require.Nil(t, shard)
+
+	for _, value := range []string{"3", "0/3", "4/3", "1/0", "a/b", "-1/2"} {
+		_, err := ParseShard(value)
+		require.Error(t, err, "invalid shard %q was parsed", value)
+	}
+}
+
+func TestShardContains(t *testing.T) {
+	shards := make([]*Shard, 4)
+	for i := range shards {
+		shards[i] = &Shard{Index: uint64(i + 1), Count: uint64(len(shards))}
+	}
+	counts := make([]int, len(shards))
+	for template := 0; template < 50; template++ {
+		for target := 0; target < 50; target++ {
+			templateID, targetID := fmt.Sprintf("template-%d", template), fmt.Sprintf("https://%d.example.com", target)
+
+			owners := 0
+			for i, shard := range shards {
+				if shard.Contains(templateID, targetID) {
+					owners++
+					counts[i]++
+				}
+			}
+			require.Equal(t, 1, owners, "pair must belong to exactly one shard")
+		}
+	}
+	for _, count := range counts {
+		require.InDelta(t, 2500/len(shards), count, 100, "pairs are not distributed evenly")
+	}
+
+	var shard *Shard
+	require.True(t, shard.Contains("template", "target"), "nil shard must contain everything")
+}
@@ -86,6 +86,10 @@ type Options struct {
 	TargetsFilePath string
 	// Resume the scan from the state stored in the resume config file
 	Resume string
+	// ResumeStore enables recording the scan progression to the on-disk resume store
+	ResumeStore bool
+	// Shard is the N/M partition of (template, target) pairs executed by this instance
+	Shard string
 	// Output is the file to write found results to.
 	Output string
 	// ProxyInternal requests
@@ -104,6 +108,8 @@ type Options struct {
 	ReportingDB string
 	// ReportingConfig is the config file for nuclei reporting module
 	ReportingConfig string
+	// ReportingReconcile closes tracker issues of findings no longer found on rescan
+	ReportingReconcile bool
 	// MarkdownExportDirectory is the directory to export reports in Markdown format
 	MarkdownExportDirectory string
 	// MarkdownExportSortMode is the method to sort the markdown reports (options: severity, template, host, none)
@@ -165,6 +171,8 @@ type Options struct {
 	OfflineHTTP bool
 	// Force HTTP2 requests
 	ForceAttemptHTTP2 bool
+	// ForceHTTP3 sends the requests of http templates over QUIC
+	ForceHTTP3 bool
 	// StatsJSON writes stats output in JSON format
 	StatsJSON bool
 	// Headless specifies whether to allow headless mode templates
@@ -225,6 +233,16 @@ type Options struct {
 	JSONExport string
 	// JSONLExport is the file to export JSONL output format to
 	JSONLExport string
+	// HTMLExport is the file to export HTML report to
+	HTMLExport string
+	// VEXExport is the file to export CycloneDX/OpenVEX output format to
+	VEXExport string
+	// VEXFormat is the format of the vex export (cyclonedx, openvex)
+	VEXFormat string
+	// SQLExport is the sqlite database file or postgres connection url to export results to
+	SQLExport string
+	// Baseline is the jsonl result file of a previous scan, only new findings are written
+	Baseline string
 	// Redact redacts given keys in
 	Redact goflags.StringSlice
 	// EnableProgressBar enables progress bar
@@ -403,6 +421,9 @@ type Options struct {
 	FormatUseRequiredOnly bool
 	// SkipFormatValidation is used to skip format validation
 	SkipFormatValidation bool
+	// PostmanEnvironmentFile is the postman environment file used to
+	// resolve variables of postman collections
+	PostmanEnvironmentFile string
 	// PayloadConcurrency is the number of concurrent payloads to run per template
 	PayloadConcurrency int
 	// ProbeConcurrency is the number of concurrent http probes to run with httpx
@@ -411,6 +432,8 @@ type Options struct {
 	DAST bool
 	// HttpApiEndpoint is the experimental http api endpoint
 	HttpApiEndpoint string
+	// HttpApiService runs nuclei as a long-running service where scans are controlled through the http api endpoint
+	HttpApiService bool
 	// ListTemplateProfiles lists all available template profiles
 	ListTemplateProfiles bool
 	// LoadHelperFileFunction is a function that will be used to execute LoadHelperFile.
@@ -0,0 +1,164 @@
+// Package graphql implements a minimal lexer for GraphQL documents
+// used by the graphql input format and fuzzing dataformat.
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// TokenKind is the kind of a lexed token
+type TokenKind int
+
+const (
+	// Punctuator is one of ! $ & ( ) ... : = @ [ ] { | }
+	Punctuator TokenKind = iota
+	// Name is a name or keyword
+	Name
+	// Int is an integer value
+	Int
+	// Float is a float value
+	Float
+	// String is a quoted string value
+	String
+	// BlockString is a triple quoted string value
+	BlockString
+)
+
+// Token is a lexed token of a GraphQL document
+type Token struct {
+	Kind  TokenKind
+	Value string
+	// Start and End are the byte offsets of the token in the document
+	Start int
+	End   int
+}
+
+// Is returns true if the token is a punctuator or name with the given value
+func (t Token) Is(value string) bool {
+	return (t.Kind == Punctuator || t.Kind == Name) && t.Value == value
+}
+
+// Lex tokenizes a GraphQL document. Whitespace, commas
+// and comments are ignored.
+func Lex(document string) ([]Token, error) {
+	var tokens []Token
+	for i := 0; i < len(document); {
+		c := document[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',':
+			i++
+		case c == '#':
+			for i < len(document) && document[i] != '\n' && document[i] != '\r' {
+				i++
+			}
+		case strings.HasPrefix(document[i:], "..."):
+			tokens = append(tokens, Token{Kind: Punctuator, Value: "...", Start: i, End: i + 3})
+			i += 3
+		case strings.ContainsRune("!$&():=@[]{|}", rune(c)):
+			tokens = append(tokens, Token{Kind: Punctuator, Value: string(c), Start: i, End: i + 1})
+			i++
+		case c == '_' || isLetter(c):
+			start := i
+			for i < len(document) && (document[i] == '_' || isLetter(document[i]) || isDigit(document[i])) {
+				i++
+			}
+			tokens = append(tokens, Token{Kind: Name, Value: document[start:i], Start: start, End: i})
+		case c == '-' || isDigit(c):
+			start := i
+			kind := Int
+			i++
+			for i < len(document) && isDigit(document[i]) {
+				i++
+			}
+			if i < len(document) && document[i] == '.' {
+				kind = Float
+				i++
+				for i < len(document) && isDigit(document[i]) {
+					i++
+				}
+			}
+			if i < len(document) && (document[i] == 'e' || document[i] == 'E') {
+				kind = Float
+				i++
+				if i < len(document) && (document[i] == '+' || document[i] == '-') {
+					i++
+				}
+				for i < len(document) && isDigit(document[i]) {
+					i++
+				}
+			}
+			tokens = append(tokens, Token{Kind: kind, Value: document[start:i], Start: start, End: i})
+		case strings.HasPrefix(document[i:], `"""`):
+			start := i
+			end := strings.Index(document[i+3:], `"""`)
+			for end >= 0 && document[i+3+end-1] == '\\' {
+				next := strings.Index(document[i+3+end+3:], `"""`)
+				if next < 0 {
+					end = -1
+					break
+				}
+				end += 3 + next
+			}
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated block string at %d", start)
+			}
+			i += 3 + end + 3
+			tokens = append(tokens, Token{Kind: BlockString, Value: document[start+3 : i-3], Start: start, End: i})
+		case c == '"':
+			start := i
+			var sb strings.Builder
+			i++
+			for ; i < len(document) && document[i] != '"'; i++ {
+				if document[i] == '\n' {
+					return nil, fmt.Errorf("unterminated string at %d", start)
+				}
+				if document[i] == '\\' && i+1 < len(document) {
+					i++
+					switch document[i] {
+					case 'n':
+						sb.WriteByte('\n')
+					case 't':
+						sb.WriteByte('\t')
+					case 'r':
+						sb.WriteByte('\r')
+					case 'b':
+						sb.WriteByte('\b')
+					case 'f':
+						sb.WriteByte('\f')
+					case 'u':
+						if i+4 < len(document) {
+							if r, err := strconv.ParseUint(document[i+1:i+5], 16, 32); err == nil {
+								sb.WriteRune(rune(r))
+								i += 4
+								continue
+							}
+						}
+						sb.WriteByte(document[i])
+					default:
+						sb.WriteByte(document[i])
+					}
+					continue
+				}
+				sb.WriteByte(document[i])
+			}
+			if i >= len(document) {
+				return nil, fmt.Errorf("unterminated string at %d", start)
+			}
+			i++
+			tokens = append(tokens, Token{Kind: String, Value: sb.String(), Start: start, End: i})
+		default:
+			return nil, fmt.Errorf("unexpected character %q at %d", c, i)
+		}
+	}
+	return tokens, nil
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}